@@ -0,0 +1,258 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"spy-cat-agency/internal/models"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var catsCmd = &cobra.Command{
+	Use:   "cats",
+	Short: "Manage spy cats",
+}
+
+var catsListCmd = &cobra.Command{
+	Use:          "list",
+	Short:        "List cats",
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runCatsList,
+}
+
+var catsCreateCmd = &cobra.Command{
+	Use:          "create",
+	Short:        "Create a cat, validating its breed against TheCatAPI",
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runCatsCreate,
+}
+
+var catsUpdateSalaryCmd = &cobra.Command{
+	Use:          "update-salary <cat-id> <salary>",
+	Short:        "Change a cat's salary",
+	Args:         cobra.ExactArgs(2),
+	SilenceUsage: true,
+	RunE:         runCatsUpdateSalary,
+}
+
+var catsDeleteCmd = &cobra.Command{
+	Use:          "delete <cat-id>",
+	Short:        "Delete a cat",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runCatsDelete,
+}
+
+var catsImportCmd = &cobra.Command{
+	Use:          "import <file>",
+	Short:        "Create cats from a JSON or CSV file in one transaction",
+	Long:         "Create cats from a JSON array or a CSV file with the columns name, years_experience, breed and salary. Nothing is imported if any row is invalid.",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runCatsImport,
+}
+
+var catsExportCmd = &cobra.Command{
+	Use:          "export",
+	Short:        "Write cats as JSON or CSV in the import format",
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runCatsExport,
+}
+
+func init() {
+	addPageFlags(catsListCmd)
+	catsListCmd.Flags().String("breed", "", "only cats of this breed")
+	catsListCmd.Flags().Bool("has-active-mission", false, "only cats with (true) or without (false) an active mission")
+
+	catsCreateCmd.Flags().String("name", "", "cat name")
+	catsCreateCmd.Flags().String("breed", "", "breed name or TheCatAPI breed id")
+	catsCreateCmd.Flags().Int("years-experience", 0, "years of experience")
+	catsCreateCmd.Flags().Float64("salary", 0, "salary")
+	catsCreateCmd.MarkFlagRequired("name")
+	catsCreateCmd.MarkFlagRequired("breed")
+	catsCreateCmd.MarkFlagRequired("years-experience")
+	catsCreateCmd.MarkFlagRequired("salary")
+
+	catsUpdateSalaryCmd.Flags().String("effective-from", "", "backdate the change to this RFC3339 time or YYYY-MM-DD day (UTC)")
+
+	catsDeleteCmd.Flags().Bool("force", false, "unassign the cat from its active mission instead of refusing")
+
+	addTransferFlags(catsImportCmd, "")
+	addTransferFlags(catsExportCmd, "file to write, defaults to stdout")
+	catsExportCmd.Flags().String("breed", "", "only cats of this breed")
+	catsExportCmd.Flags().Bool("has-active-mission", false, "only cats with (true) or without (false) an active mission")
+
+	addOutputFlag(catsCmd)
+	catsCmd.AddCommand(catsListCmd, catsCreateCmd, catsUpdateSalaryCmd, catsDeleteCmd, catsImportCmd, catsExportCmd)
+	rootCmd.AddCommand(catsCmd)
+}
+
+func runCatsList(cmd *cobra.Command, args []string) error {
+	filter := models.CatFilter{PageQuery: pageQuery(cmd)}
+	filter.Breed, _ = cmd.Flags().GetString("breed")
+	if cmd.Flags().Changed("has-active-mission") {
+		hasActiveMission, _ := cmd.Flags().GetBool("has-active-mission")
+		filter.HasActiveMission = &hasActiveMission
+	}
+	if err := validate(&filter); err != nil {
+		return err
+	}
+
+	services, err := newServices()
+	if err != nil {
+		return err
+	}
+
+	page, err := services.Cat.GetAll(cliContext(), filter)
+	if err != nil {
+		return err
+	}
+
+	return render(cmd, page, func(w io.Writer) {
+		writeCats(w, page.Items)
+		writePageMeta(w, page.Meta)
+	})
+}
+
+func runCatsCreate(cmd *cobra.Command, args []string) error {
+	var dto models.CreateCatDTO
+	dto.Name, _ = cmd.Flags().GetString("name")
+	dto.Breed, _ = cmd.Flags().GetString("breed")
+	dto.YearsExperience, _ = cmd.Flags().GetInt("years-experience")
+	dto.Salary, _ = cmd.Flags().GetFloat64("salary")
+	if err := validate(&dto); err != nil {
+		return err
+	}
+
+	services, err := newServices()
+	if err != nil {
+		return err
+	}
+
+	cat, err := services.Cat.Create(cliContext(), &dto)
+	if err != nil {
+		return err
+	}
+
+	return render(cmd, cat, func(w io.Writer) {
+		writeCats(w, []models.Cat{*cat})
+	})
+}
+
+func runCatsUpdateSalary(cmd *cobra.Command, args []string) error {
+	id, err := parseID(args[0], "cat id")
+	if err != nil {
+		return err
+	}
+	salary, err := strconv.ParseFloat(args[1], 64)
+	if err != nil {
+		return fmt.Errorf("invalid salary %q", args[1])
+	}
+
+	dto := models.UpdateCatDTO{Salary: salary}
+	if value, _ := cmd.Flags().GetString("effective-from"); value != "" {
+		effectiveFrom, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			if effectiveFrom, err = time.Parse(models.PayrollDateFormat, value); err != nil {
+				return fmt.Errorf("invalid effective-from %q, expected RFC3339 or YYYY-MM-DD", value)
+			}
+		}
+		dto.EffectiveFrom = &effectiveFrom
+	}
+	if err := validate(&dto); err != nil {
+		return err
+	}
+
+	services, err := newServices()
+	if err != nil {
+		return err
+	}
+
+	cat, err := services.Cat.Update(cliContext(), id, dto)
+	if err != nil {
+		return err
+	}
+
+	return render(cmd, cat, func(w io.Writer) {
+		writeCats(w, []models.Cat{*cat})
+	})
+}
+
+func runCatsDelete(cmd *cobra.Command, args []string) error {
+	id, err := parseID(args[0], "cat id")
+	if err != nil {
+		return err
+	}
+	force, _ := cmd.Flags().GetBool("force")
+
+	services, err := newServices()
+	if err != nil {
+		return err
+	}
+
+	if err := services.Cat.Delete(cliContext(), id, force); err != nil {
+		return err
+	}
+
+	return render(cmd, map[string]any{"id": id, "deleted": true}, func(w io.Writer) {
+		fmt.Fprintf(w, "Deleted cat %d\n", id)
+	})
+}
+
+func runCatsImport(cmd *cobra.Command, args []string) error {
+	format, err := transferFormat(cmd, args[0])
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(args[0])
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	services, err := newServices()
+	if err != nil {
+		return err
+	}
+
+	result, err := services.Transfer.ImportCats(cliContext(), format, file)
+	if err != nil {
+		return importErr(err)
+	}
+
+	return writeImportResult(cmd, "cats", result)
+}
+
+func runCatsExport(cmd *cobra.Command, args []string) error {
+	path, _ := cmd.Flags().GetString("file")
+	format, err := transferFormat(cmd, path)
+	if err != nil {
+		return err
+	}
+
+	filter := models.CatExportFilter{TransferQuery: models.TransferQuery{Format: format}}
+	filter.Breed, _ = cmd.Flags().GetString("breed")
+	if cmd.Flags().Changed("has-active-mission") {
+		hasActiveMission, _ := cmd.Flags().GetBool("has-active-mission")
+		filter.HasActiveMission = &hasActiveMission
+	}
+
+	services, err := newServices()
+	if err != nil {
+		return err
+	}
+
+	data, err := services.Transfer.ExportCats(cliContext(), filter)
+	if err != nil {
+		return err
+	}
+
+	return writeExport(cmd, data)
+}
@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/user"
+	"path/filepath"
+	"spy-cat-agency/config"
+	"spy-cat-agency/internal/actor"
+	"spy-cat-agency/internal/database"
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/internal/repository"
+	"spy-cat-agency/internal/service"
+	"spy-cat-agency/pkg/custerr"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// connectDB connects for command line use, where only warnings are logged
+// and to stderr, so stdout stays clean for JSON output.
+func connectDB(cfg *config.Config) (*gorm.DB, error) {
+	db, err := database.Connect(cfg.DatabaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	db.Logger = logger.New(log.New(os.Stderr, "", log.LstdFlags), logger.Config{
+		SlowThreshold:             time.Second,
+		LogLevel:                  logger.Warn,
+		IgnoreRecordNotFoundError: true,
+	})
+	return db, nil
+}
+
+func newServices() (*service.Service, error) {
+	cfg := config.Load()
+
+	db, err := connectDB(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return service.New(repository.New(db), cfg), nil
+}
+
+// cliContext attributes changes made from the command line to the OS user
+// running it.
+func cliContext() context.Context {
+	name := "cli"
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		name = "cli:" + u.Username
+	}
+	return actor.WithName(context.Background(), name)
+}
+
+// validate applies the same binding rules the API enforces on request bodies.
+func validate(dto any) error {
+	return binding.Validator.ValidateStruct(dto)
+}
+
+func parseID(value, name string) (uint, error) {
+	id, err := strconv.ParseUint(value, 10, 32)
+	if err != nil || id == 0 {
+		return 0, fmt.Errorf("invalid %s %q", name, value)
+	}
+	return uint(id), nil
+}
+
+// addOutputFlag adds --output to cmd and its subcommands and rejects unknown
+// formats before anything is changed.
+func addOutputFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringP("output", "o", "table", "output format, table or json")
+	cmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("output")
+		if format != "table" && format != "json" {
+			return fmt.Errorf("unknown output format %q, expected table or json", format)
+		}
+		return nil
+	}
+}
+
+func addPageFlags(cmd *cobra.Command) {
+	cmd.Flags().Int("page", 1, "page number")
+	cmd.Flags().Int("per-page", models.DefaultPerPage, "items per page")
+	cmd.Flags().String("sort", "", "comma separated sort fields, prefix with - for descending order")
+}
+
+func pageQuery(cmd *cobra.Command) models.PageQuery {
+	page, _ := cmd.Flags().GetInt("page")
+	perPage, _ := cmd.Flags().GetInt("per-page")
+	sort, _ := cmd.Flags().GetString("sort")
+	return models.PageQuery{Page: page, PerPage: perPage, Sort: sort}
+}
+
+// render writes v as indented JSON or, by default, as the table written by
+// table.
+func render(cmd *cobra.Command, v any, table func(w io.Writer)) error {
+	if format, _ := cmd.Flags().GetString("output"); format == "json" {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	table(w)
+	return w.Flush()
+}
+
+// transferFormat reads --format, falling back to the extension of path.
+func transferFormat(cmd *cobra.Command, path string) (models.TransferFormat, error) {
+	format, _ := cmd.Flags().GetString("format")
+	if format == "" {
+		format = strings.TrimPrefix(filepath.Ext(path), ".")
+	}
+
+	switch models.TransferFormat(strings.ToLower(format)) {
+	case models.TransferFormatCSV:
+		return models.TransferFormatCSV, nil
+	case models.TransferFormatJSON, "":
+		return models.TransferFormatJSON, nil
+	default:
+		return "", fmt.Errorf("unknown format %q, expected json or csv", format)
+	}
+}
+
+func addTransferFlags(cmd *cobra.Command, fileUsage string) {
+	cmd.Flags().String("format", "", "json or csv, defaults to the file extension and then json")
+	if fileUsage != "" {
+		cmd.Flags().String("file", "", fileUsage)
+	}
+}
+
+// writeExport writes exported data to --file, or to stdout without one.
+func writeExport(cmd *cobra.Command, data []byte) error {
+	path, _ := cmd.Flags().GetString("file")
+	if path == "" {
+		_, err := cmd.OutOrStdout().Write(data)
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// importErr spells out the invalid rows a rejected import reports in its
+// details.
+func importErr(err error) error {
+	var badRequest custerr.BadRequestErr
+	if !errors.As(err, &badRequest) {
+		return err
+	}
+	rowErrs, ok := badRequest.Details()["errors"].([]models.ImportRowError)
+	if !ok {
+		return err
+	}
+
+	lines := []string{err.Error()}
+	for _, rowErr := range rowErrs {
+		lines = append(lines, fmt.Sprintf("  row %d: %s", rowErr.Row, rowErr.Message))
+	}
+	return errors.New(strings.Join(lines, "\n"))
+}
+
+func writeImportResult(cmd *cobra.Command, name string, result *models.ImportResult) error {
+	return render(cmd, result, func(w io.Writer) {
+		ids := make([]string, len(result.IDs))
+		for i, id := range result.IDs {
+			ids[i] = strconv.FormatUint(uint64(id), 10)
+		}
+		fmt.Fprintf(w, "Imported %d %s: %s\n", result.Created, name, strings.Join(ids, ", "))
+	})
+}
+
+func writePageMeta(w io.Writer, meta models.PageMeta) {
+	fmt.Fprintf(w, "\npage %d of %d, %d total\n", meta.Page, meta.TotalPages, meta.Total)
+}
+
+func writeCats(w io.Writer, cats []models.Cat) {
+	fmt.Fprintln(w, "ID\tNAME\tBREED\tEXPERIENCE\tSALARY\tMISSION")
+	for _, cat := range cats {
+		mission := "-"
+		if cat.Mission != nil {
+			mission = strconv.FormatUint(uint64(cat.Mission.ID), 10)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%d\t%.2f\t%s\n", cat.ID, cat.Name, cat.Breed, cat.YearsExperience, cat.Salary, mission)
+	}
+}
+
+func writeMissions(w io.Writer, missions []models.Mission) {
+	fmt.Fprintln(w, "ID\tCAT\tCOMPLETE\tTARGETS")
+	for _, mission := range missions {
+		cat := "-"
+		if mission.CatID != nil {
+			cat = strconv.FormatUint(uint64(*mission.CatID), 10)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%t\t%d\n", mission.ID, cat, mission.Complete, len(mission.Targets))
+	}
+}
+
+// writeMission writes a single mission followed by its targets.
+func writeMission(w io.Writer, mission *models.Mission) {
+	writeMissions(w, []models.Mission{*mission})
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "TARGET\tNAME\tCOUNTRY\tSTATUS\tNOTES")
+	for _, target := range mission.Targets {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", target.ID, target.Name, target.Country, target.Status, strings.ReplaceAll(target.Notes, "\n", " "))
+	}
+}
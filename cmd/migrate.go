@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"fmt"
+	"spy-cat-agency/config"
+	"spy-cat-agency/internal/database"
+	"spy-cat-agency/migrations"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Manage database schema migrations",
+}
+
+var migrateUpCmd = &cobra.Command{
+	Use:          "up",
+	Short:        "Apply all pending migrations",
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runMigrateUp,
+}
+
+var migrateDownCmd = &cobra.Command{
+	Use:          "down",
+	Short:        "Revert the latest applied migrations",
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runMigrateDown,
+}
+
+var migrateStatusCmd = &cobra.Command{
+	Use:          "status",
+	Short:        "List migrations and whether they are applied",
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runMigrateStatus,
+}
+
+var migrateCreateCmd = &cobra.Command{
+	Use:          "create <name>",
+	Short:        "Create empty up and down files for a new migration",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runMigrateCreate,
+}
+
+func init() {
+	migrateDownCmd.Flags().Int("steps", 1, "number of migrations to revert")
+	migrateCreateCmd.Flags().String("dir", "migrations", "directory holding the migration files")
+
+	migrateCmd.AddCommand(migrateUpCmd, migrateDownCmd, migrateStatusCmd, migrateCreateCmd)
+	rootCmd.AddCommand(migrateCmd)
+}
+
+func newMigrator() (*database.Migrator, error) {
+	all, err := database.LoadMigrations(migrations.FS)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := connectDB(config.Load())
+	if err != nil {
+		return nil, err
+	}
+
+	return database.NewMigrator(db, all), nil
+}
+
+func runMigrateUp(cmd *cobra.Command, args []string) error {
+	migrator, err := newMigrator()
+	if err != nil {
+		return err
+	}
+
+	applied, err := migrator.Up()
+	if err != nil {
+		return err
+	}
+
+	if len(applied) == 0 {
+		fmt.Println("No pending migrations")
+	}
+	for _, migration := range applied {
+		fmt.Printf("Applied %s\n", migration)
+	}
+	return nil
+}
+
+func runMigrateDown(cmd *cobra.Command, args []string) error {
+	steps, _ := cmd.Flags().GetInt("steps")
+	if steps < 1 {
+		return fmt.Errorf("steps must be at least 1")
+	}
+
+	migrator, err := newMigrator()
+	if err != nil {
+		return err
+	}
+
+	reverted, err := migrator.Down(steps)
+	if err != nil {
+		return err
+	}
+
+	if len(reverted) == 0 {
+		fmt.Println("No applied migrations")
+	}
+	for _, migration := range reverted {
+		fmt.Printf("Reverted %s\n", migration)
+	}
+	return nil
+}
+
+func runMigrateStatus(cmd *cobra.Command, args []string) error {
+	migrator, err := newMigrator()
+	if err != nil {
+		return err
+	}
+
+	statuses, err := migrator.Status()
+	if err != nil {
+		return err
+	}
+
+	for _, status := range statuses {
+		appliedAt := "pending"
+		if status.AppliedAt != nil {
+			appliedAt = status.AppliedAt.Format(time.RFC3339)
+		}
+		fmt.Printf("%-40s %s\n", status.Migration, appliedAt)
+	}
+	return nil
+}
+
+func runMigrateCreate(cmd *cobra.Command, args []string) error {
+	dir, _ := cmd.Flags().GetString("dir")
+
+	up, down, err := database.CreateMigration(dir, args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Created %s\nCreated %s\n", up, down)
+	return nil
+}
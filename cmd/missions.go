@@ -0,0 +1,259 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"spy-cat-agency/internal/models"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var missionsCmd = &cobra.Command{
+	Use:   "missions",
+	Short: "Manage missions",
+}
+
+var missionsListCmd = &cobra.Command{
+	Use:          "list",
+	Short:        "List missions",
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runMissionsList,
+}
+
+var missionsCreateCmd = &cobra.Command{
+	Use:          "create",
+	Short:        "Create a mission with 1-3 targets",
+	Long:         "Create a mission with 1-3 targets, e.g. missions create --target \"Boris:Russia\" --target \"Ivan:Ukraine:meets at noon\"",
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runMissionsCreate,
+}
+
+var missionsAssignCmd = &cobra.Command{
+	Use:          "assign <mission-id> <cat-id>",
+	Short:        "Assign a cat to a mission without one",
+	Args:         cobra.ExactArgs(2),
+	SilenceUsage: true,
+	RunE:         runMissionsAssign,
+}
+
+var missionsCompleteCmd = &cobra.Command{
+	Use:          "complete <mission-id>",
+	Short:        "Mark a mission as complete",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runMissionsComplete,
+}
+
+var missionsImportCmd = &cobra.Command{
+	Use:          "import <file>",
+	Short:        "Create missions from a JSON or CSV file in one transaction",
+	Long:         "Create open missions from a JSON array or a CSV file with the columns mission, cat_id, target_name, target_country and target_notes, one line per target grouped by the mission column. Nothing is imported if any row is invalid.",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runMissionsImport,
+}
+
+var missionsExportCmd = &cobra.Command{
+	Use:          "export",
+	Short:        "Write missions as JSON or CSV in the import format",
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runMissionsExport,
+}
+
+func init() {
+	addPageFlags(missionsListCmd)
+	missionsListCmd.Flags().Bool("complete", false, "only complete (true) or open (false) missions")
+	missionsListCmd.Flags().Uint("cat-id", 0, "only missions of this cat")
+	missionsListCmd.Flags().String("country", "", "only missions with a target in this country")
+
+	missionsCreateCmd.Flags().StringArray("target", nil, "target as name:country[:notes], repeat for each target")
+	missionsCreateCmd.MarkFlagRequired("target")
+
+	missionsCompleteCmd.Flags().Bool("force", false, "complete the mission even if some targets are not terminal yet")
+
+	addTransferFlags(missionsImportCmd, "")
+	addTransferFlags(missionsExportCmd, "file to write, defaults to stdout")
+	missionsExportCmd.Flags().Bool("complete", false, "only complete (true) or open (false) missions")
+	missionsExportCmd.Flags().Uint("cat-id", 0, "only missions of this cat")
+	missionsExportCmd.Flags().String("country", "", "only missions with a target in this country")
+
+	addOutputFlag(missionsCmd)
+	missionsCmd.AddCommand(missionsListCmd, missionsCreateCmd, missionsAssignCmd, missionsCompleteCmd, missionsImportCmd, missionsExportCmd)
+	rootCmd.AddCommand(missionsCmd)
+}
+
+func runMissionsList(cmd *cobra.Command, args []string) error {
+	filter := models.MissionFilter{PageQuery: pageQuery(cmd)}
+	filter.Country, _ = cmd.Flags().GetString("country")
+	if cmd.Flags().Changed("complete") {
+		complete, _ := cmd.Flags().GetBool("complete")
+		filter.Complete = &complete
+	}
+	if cmd.Flags().Changed("cat-id") {
+		catID, _ := cmd.Flags().GetUint("cat-id")
+		filter.CatID = &catID
+	}
+	if err := validate(&filter); err != nil {
+		return err
+	}
+
+	services, err := newServices()
+	if err != nil {
+		return err
+	}
+
+	page, err := services.Mission.GetAll(cliContext(), filter)
+	if err != nil {
+		return err
+	}
+
+	return render(cmd, page, func(w io.Writer) {
+		writeMissions(w, page.Items)
+		writePageMeta(w, page.Meta)
+	})
+}
+
+func runMissionsCreate(cmd *cobra.Command, args []string) error {
+	values, _ := cmd.Flags().GetStringArray("target")
+
+	var dto models.CreateMissionDTO
+	for _, value := range values {
+		parts := strings.SplitN(value, ":", 3)
+		if len(parts) < 2 {
+			return fmt.Errorf("invalid target %q, expected name:country[:notes]", value)
+		}
+		target := models.CreateTargetDTO{Name: parts[0], Country: parts[1]}
+		if len(parts) == 3 {
+			target.Notes = parts[2]
+		}
+		dto.Targets = append(dto.Targets, target)
+	}
+	if err := validate(&dto); err != nil {
+		return err
+	}
+
+	services, err := newServices()
+	if err != nil {
+		return err
+	}
+
+	mission, err := services.Mission.Create(cliContext(), dto)
+	if err != nil {
+		return err
+	}
+
+	return render(cmd, mission, func(w io.Writer) {
+		writeMission(w, mission)
+	})
+}
+
+func runMissionsAssign(cmd *cobra.Command, args []string) error {
+	missionID, err := parseID(args[0], "mission id")
+	if err != nil {
+		return err
+	}
+	catID, err := parseID(args[1], "cat id")
+	if err != nil {
+		return err
+	}
+
+	services, err := newServices()
+	if err != nil {
+		return err
+	}
+
+	mission, err := services.Mission.AssignCat(cliContext(), missionID, catID)
+	if err != nil {
+		return err
+	}
+
+	return render(cmd, mission, func(w io.Writer) {
+		writeMission(w, mission)
+	})
+}
+
+func runMissionsComplete(cmd *cobra.Command, args []string) error {
+	missionID, err := parseID(args[0], "mission id")
+	if err != nil {
+		return err
+	}
+
+	complete := true
+	dto := models.UpdateMissionDTO{Complete: &complete}
+	dto.Force, _ = cmd.Flags().GetBool("force")
+
+	services, err := newServices()
+	if err != nil {
+		return err
+	}
+
+	mission, err := services.Mission.Update(cliContext(), missionID, dto)
+	if err != nil {
+		return err
+	}
+
+	return render(cmd, mission, func(w io.Writer) {
+		writeMission(w, mission)
+	})
+}
+
+func runMissionsImport(cmd *cobra.Command, args []string) error {
+	format, err := transferFormat(cmd, args[0])
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(args[0])
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	services, err := newServices()
+	if err != nil {
+		return err
+	}
+
+	result, err := services.Transfer.ImportMissions(cliContext(), format, file)
+	if err != nil {
+		return importErr(err)
+	}
+
+	return writeImportResult(cmd, "missions", result)
+}
+
+func runMissionsExport(cmd *cobra.Command, args []string) error {
+	path, _ := cmd.Flags().GetString("file")
+	format, err := transferFormat(cmd, path)
+	if err != nil {
+		return err
+	}
+
+	filter := models.MissionExportFilter{TransferQuery: models.TransferQuery{Format: format}}
+	filter.Country, _ = cmd.Flags().GetString("country")
+	if cmd.Flags().Changed("complete") {
+		complete, _ := cmd.Flags().GetBool("complete")
+		filter.Complete = &complete
+	}
+	if cmd.Flags().Changed("cat-id") {
+		catID, _ := cmd.Flags().GetUint("cat-id")
+		filter.CatID = &catID
+	}
+
+	services, err := newServices()
+	if err != nil {
+		return err
+	}
+
+	data, err := services.Transfer.ExportMissions(cliContext(), filter)
+	if err != nil {
+		return err
+	}
+
+	return writeExport(cmd, data)
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"fmt"
+	"spy-cat-agency/config"
+	"spy-cat-agency/internal/repository"
+	"spy-cat-agency/internal/service"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var purgeCmd = &cobra.Command{
+	Use:          "purge",
+	Short:        "Permanently remove deleted records",
+	Long:         "Permanently remove cats, missions and targets deleted longer ago than the retention window (TRASH_RETENTION, 30 days by default)",
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runPurge,
+}
+
+func init() {
+	purgeCmd.Flags().Duration("older-than", 0, "retention window, overrides TRASH_RETENTION")
+
+	rootCmd.AddCommand(purgeCmd)
+}
+
+func runPurge(cmd *cobra.Command, args []string) error {
+	cfg := config.Load()
+
+	retention := cfg.TrashRetention
+	if cmd.Flags().Changed("older-than") {
+		retention, _ = cmd.Flags().GetDuration("older-than")
+	}
+	if retention < 0 {
+		return fmt.Errorf("retention window cannot be negative")
+	}
+
+	db, err := connectDB(cfg)
+	if err != nil {
+		return err
+	}
+
+	services := service.New(repository.New(db), cfg)
+
+	before := time.Now().Add(-retention)
+	result, err := services.Trash.Purge(cliContext(), before)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Purged records deleted before %s: %d cats, %d missions, %d targets\n",
+		before.Format(time.RFC3339), result.Cats, result.Missions, result.Targets)
+	return nil
+}
@@ -1,9 +1,11 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"spy-cat-agency/config"
+	"spy-cat-agency/internal/auth"
 	"spy-cat-agency/internal/database"
 	"spy-cat-agency/internal/handler"
 	"spy-cat-agency/internal/middleware"
@@ -36,9 +38,16 @@ func run(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
-	err = database.Migrate(db)
+	if cfg.AutoMigrate {
+		if _, err := database.Migrate(db); err != nil {
+			fmt.Printf("Failed to run migrations: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	authenticator, err := auth.NewAuthenticator(cfg)
 	if err != nil {
-		fmt.Printf("Failed to run migrations: %v\n", err)
+		fmt.Printf("Failed to configure authentication: %v\n", err)
 		os.Exit(1)
 	}
 
@@ -46,37 +55,27 @@ func run(cmd *cobra.Command, args []string) {
 	services := service.New(repos, cfg)
 	handlers := handler.New(services)
 
+	go services.Catalog.Run(context.Background(), cfg.BreedRefreshInterval)
+
 	// gin includes logger middleware by default
 	r := gin.Default()
 
 	corsConfig := cors.DefaultConfig()
 	corsConfig.AllowOrigins = []string{"http://localhost"}
 	corsConfig.AllowCredentials = true
+	corsConfig.AllowHeaders = append(corsConfig.AllowHeaders, "Authorization", middleware.APIKeyHeader, middleware.RequestIDHeader)
+	corsConfig.ExposeHeaders = []string{middleware.RequestIDHeader}
 	r.Use(cors.New(corsConfig))
 
+	r.Use(middleware.RequestID())
 	r.Use(middleware.ErrorHandler())
 
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
 	api := r.Group("/api/v1")
+	api.Use(middleware.Auth(authenticator))
 
-	cats := api.Group("/cats")
-	cats.POST("", handlers.CreateCat)
-	cats.GET("", handlers.GetCats)
-	cats.GET("/:id", handlers.GetCat)
-	cats.PATCH("/:id", handlers.UpdateCat)
-	cats.DELETE("/:id", handlers.DeleteCat)
-
-	missions := api.Group("/missions")
-	missions.POST("", handlers.CreateMission)
-	missions.GET("", handlers.GetMissions)
-	missions.GET("/:id", handlers.GetMission)
-	missions.PATCH("/:id", handlers.UpdateMission)
-	missions.DELETE("/:id", handlers.DeleteMission)
-	missions.PATCH("/:id/assign/:cat_id", handlers.AssignCatToMission)
-	missions.POST("/:id/targets", handlers.CreateTarget)
-	missions.PATCH("/:id/targets/:target_id", handlers.UpdateTarget)
-	missions.DELETE("/:id/targets/:target_id", handlers.DeleteTarget)
+	handlers.Routes(api)
 
 	fmt.Printf("Server starting on port %s\n", cfg.Port)
 	r.Run(":" + cfg.Port)
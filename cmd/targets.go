@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"io"
+	"spy-cat-agency/internal/models"
+
+	"github.com/spf13/cobra"
+)
+
+var targetsCmd = &cobra.Command{
+	Use:   "targets",
+	Short: "Manage mission targets",
+}
+
+var targetsAddCmd = &cobra.Command{
+	Use:          "add <mission-id>",
+	Short:        "Add a target to an open mission",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runTargetsAdd,
+}
+
+var targetsUpdateCmd = &cobra.Command{
+	Use:          "update <mission-id> <target-id>",
+	Short:        "Update a target's notes or status",
+	Args:         cobra.ExactArgs(2),
+	SilenceUsage: true,
+	RunE:         runTargetsUpdate,
+}
+
+var targetsDeleteCmd = &cobra.Command{
+	Use:          "delete <mission-id> <target-id>",
+	Short:        "Delete a target that is not complete",
+	Args:         cobra.ExactArgs(2),
+	SilenceUsage: true,
+	RunE:         runTargetsDelete,
+}
+
+func init() {
+	targetsAddCmd.Flags().String("name", "", "target name")
+	targetsAddCmd.Flags().String("country", "", "target country")
+	targetsAddCmd.Flags().String("notes", "", "target notes")
+	targetsAddCmd.MarkFlagRequired("name")
+	targetsAddCmd.MarkFlagRequired("country")
+
+	targetsUpdateCmd.Flags().String("notes", "", "new notes")
+	targetsUpdateCmd.Flags().String("status", "", "pending, under_surveillance, compromised, escaped or neutralized")
+	targetsUpdateCmd.Flags().Bool("complete", false, "shorthand for --status neutralized")
+	targetsUpdateCmd.MarkFlagsOneRequired("notes", "status", "complete")
+
+	addOutputFlag(targetsCmd)
+	targetsCmd.AddCommand(targetsAddCmd, targetsUpdateCmd, targetsDeleteCmd)
+	rootCmd.AddCommand(targetsCmd)
+}
+
+func runTargetsAdd(cmd *cobra.Command, args []string) error {
+	missionID, err := parseID(args[0], "mission id")
+	if err != nil {
+		return err
+	}
+
+	var dto models.CreateTargetDTO
+	dto.Name, _ = cmd.Flags().GetString("name")
+	dto.Country, _ = cmd.Flags().GetString("country")
+	dto.Notes, _ = cmd.Flags().GetString("notes")
+	if err := validate(&dto); err != nil {
+		return err
+	}
+
+	services, err := newServices()
+	if err != nil {
+		return err
+	}
+
+	mission, err := services.Mission.CreateTarget(cliContext(), missionID, dto)
+	if err != nil {
+		return err
+	}
+
+	return render(cmd, mission, func(w io.Writer) {
+		writeMission(w, mission)
+	})
+}
+
+func runTargetsUpdate(cmd *cobra.Command, args []string) error {
+	missionID, err := parseID(args[0], "mission id")
+	if err != nil {
+		return err
+	}
+	targetID, err := parseID(args[1], "target id")
+	if err != nil {
+		return err
+	}
+
+	var dto models.UpdateTargetDTO
+	if cmd.Flags().Changed("notes") {
+		notes, _ := cmd.Flags().GetString("notes")
+		dto.Notes = &notes
+	}
+	if cmd.Flags().Changed("status") {
+		status, _ := cmd.Flags().GetString("status")
+		targetStatus := models.TargetStatus(status)
+		dto.Status = &targetStatus
+	}
+	if cmd.Flags().Changed("complete") {
+		complete, _ := cmd.Flags().GetBool("complete")
+		dto.Complete = &complete
+	}
+	if err := validate(&dto); err != nil {
+		return err
+	}
+
+	services, err := newServices()
+	if err != nil {
+		return err
+	}
+
+	mission, err := services.Mission.UpdateTarget(cliContext(), missionID, targetID, dto)
+	if err != nil {
+		return err
+	}
+
+	return render(cmd, mission, func(w io.Writer) {
+		writeMission(w, mission)
+	})
+}
+
+func runTargetsDelete(cmd *cobra.Command, args []string) error {
+	missionID, err := parseID(args[0], "mission id")
+	if err != nil {
+		return err
+	}
+	targetID, err := parseID(args[1], "target id")
+	if err != nil {
+		return err
+	}
+
+	services, err := newServices()
+	if err != nil {
+		return err
+	}
+
+	mission, err := services.Mission.DeleteTarget(cliContext(), missionID, targetID)
+	if err != nil {
+		return err
+	}
+
+	return render(cmd, mission, func(w io.Writer) {
+		writeMission(w, mission)
+	})
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+	"spy-cat-agency/config"
+	"spy-cat-agency/internal/auth"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var tokenCmd = &cobra.Command{
+	Use:          "token",
+	Short:        "Issue a signed API token",
+	Long:         "Issue a JWT bearer token signed with JWT_SECRET, e.g. token --subject tom --role field-cat --cat-id 3",
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runToken,
+}
+
+func init() {
+	tokenCmd.Flags().String("subject", "", "who the token identifies, recorded as the actor of their changes")
+	tokenCmd.Flags().String("role", string(auth.RoleHandler), "admin, handler or field-cat")
+	tokenCmd.Flags().Uint("cat-id", 0, "cat the token belongs to, required for field-cat")
+	tokenCmd.Flags().Duration("ttl", 24*time.Hour, "how long the token is valid")
+	tokenCmd.MarkFlagRequired("subject")
+
+	rootCmd.AddCommand(tokenCmd)
+}
+
+func runToken(cmd *cobra.Command, args []string) error {
+	subject, _ := cmd.Flags().GetString("subject")
+	role, _ := cmd.Flags().GetString("role")
+	ttl, _ := cmd.Flags().GetDuration("ttl")
+
+	principal := auth.Principal{Subject: subject, Role: auth.Role(role)}
+	if cmd.Flags().Changed("cat-id") {
+		catID, _ := cmd.Flags().GetUint("cat-id")
+		principal.CatID = &catID
+	}
+
+	authenticator, err := auth.NewAuthenticator(config.Load())
+	if err != nil {
+		return err
+	}
+
+	token, err := authenticator.IssueToken(principal, ttl)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(token)
+	return nil
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -11,6 +13,24 @@ import (
 type Config struct {
 	DatabaseURL string
 	Port        string
+	AutoMigrate bool
+
+	CatAPIBaseURL        string
+	BreedCacheTTL        time.Duration
+	BreedRefreshInterval time.Duration
+	BreedSnapshotPath    string
+
+	JWTSecret string
+	APIKeys   []APIKey
+
+	TrashRetention time.Duration
+}
+
+// APIKey is a static credential for a service account.
+type APIKey struct {
+	Name string
+	Role string
+	Key  string
 }
 
 func Load() *Config {
@@ -24,7 +44,18 @@ func Load() *Config {
 			getEnv("DB_HOST", "localhost"),
 			getEnv("DB_NAME", "spy_cat_agency"),
 		),
-		Port: getEnv("PORT", "8080"),
+		Port:        getEnv("PORT", "8080"),
+		AutoMigrate: getEnvBool("AUTO_MIGRATE", true),
+
+		CatAPIBaseURL:        getEnv("CAT_API_BASE_URL", "https://api.thecatapi.com/v1"),
+		BreedCacheTTL:        getEnvDuration("BREED_CACHE_TTL", time.Hour),
+		BreedRefreshInterval: getEnvDuration("BREED_REFRESH_INTERVAL", 30*time.Minute),
+		BreedSnapshotPath:    getEnv("BREED_SNAPSHOT_PATH", "data/breeds.json"),
+
+		JWTSecret: getEnv("JWT_SECRET", ""),
+		APIKeys:   getEnvAPIKeys("API_KEYS"),
+
+		TrashRetention: getEnvDuration("TRASH_RETENTION", 30*24*time.Hour),
 	}
 
 	return cfg
@@ -43,3 +74,39 @@ func getEnvInt(key string, defaultValue int) (int, error) {
 	}
 	return defaultValue, nil
 }
+
+// getEnvBool accepts the values understood by strconv.ParseBool, falling
+// back to the default when the variable is unset or malformed.
+func getEnvBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return defaultValue
+}
+
+// getEnvDuration parses values like "90s" or "1h30m", falling back to the
+// default when the variable is unset or malformed.
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if d, err := time.ParseDuration(value); err == nil {
+			return d
+		}
+	}
+	return defaultValue
+}
+
+// getEnvAPIKeys parses a comma separated list of name:role:key entries.
+// Malformed entries are skipped, so they never grant access.
+func getEnvAPIKeys(key string) []APIKey {
+	var keys []APIKey
+	for _, entry := range strings.Split(os.Getenv(key), ",") {
+		parts := strings.SplitN(strings.TrimSpace(entry), ":", 3)
+		if len(parts) != 3 || parts[0] == "" || parts[2] == "" {
+			continue
+		}
+		keys = append(keys, APIKey{Name: parts[0], Role: parts[1], Key: parts[2]})
+	}
+	return keys
+}
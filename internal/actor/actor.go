@@ -0,0 +1,21 @@
+// Package actor carries the identity responsible for a request through
+// context so services can attribute the changes they make.
+package actor
+
+import "context"
+
+const Anonymous = "anonymous"
+
+type ctxKey struct{}
+
+func WithName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, name)
+}
+
+// FromContext returns the actor stored in ctx, or Anonymous if there is none.
+func FromContext(ctx context.Context) string {
+	if name, ok := ctx.Value(ctxKey{}).(string); ok && name != "" {
+		return name
+	}
+	return Anonymous
+}
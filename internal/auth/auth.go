@@ -0,0 +1,50 @@
+// Package auth identifies API callers and carries their identity through
+// context.
+package auth
+
+import (
+	"context"
+	"slices"
+)
+
+type Role string
+
+const (
+	RoleAdmin    Role = "admin"
+	RoleHandler  Role = "handler"
+	RoleFieldCat Role = "field-cat"
+)
+
+func (r Role) IsValid() bool {
+	return slices.Contains([]Role{RoleAdmin, RoleHandler, RoleFieldCat}, r)
+}
+
+// Principal is an authenticated caller. CatID is set for field cats only.
+type Principal struct {
+	Subject string
+	Role    Role
+	CatID   *uint
+}
+
+// HasRole reports whether the principal has any of roles.
+func (p *Principal) HasRole(roles ...Role) bool {
+	return slices.Contains(roles, p.Role)
+}
+
+// IsCat reports whether the principal is the field cat with the given id.
+func (p *Principal) IsCat(catID *uint) bool {
+	return p.Role == RoleFieldCat && p.CatID != nil && catID != nil && *p.CatID == *catID
+}
+
+type ctxKey struct{}
+
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, ctxKey{}, principal)
+}
+
+// FromContext returns the principal stored in ctx. Work that does not come
+// from the API, such as maintenance commands, has none.
+func FromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(ctxKey{}).(*Principal)
+	return principal, ok
+}
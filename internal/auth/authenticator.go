@@ -0,0 +1,125 @@
+package auth
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"spy-cat-agency/config"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var ErrInvalidCredentials = errors.New("invalid credentials")
+
+type Claims struct {
+	Role  Role  `json:"role"`
+	CatID *uint `json:"cat_id,omitempty"`
+	jwt.RegisteredClaims
+}
+
+type apiKey struct {
+	key       []byte
+	principal Principal
+}
+
+// Authenticator verifies JWT bearer tokens signed with the configured secret
+// and API keys issued to service accounts.
+type Authenticator struct {
+	secret  []byte
+	apiKeys []apiKey
+}
+
+func NewAuthenticator(cfg *config.Config) (*Authenticator, error) {
+	if cfg.JWTSecret == "" && len(cfg.APIKeys) == 0 {
+		return nil, errors.New("no credentials configured, set JWT_SECRET or API_KEYS")
+	}
+
+	a := &Authenticator{secret: []byte(cfg.JWTSecret)}
+	for _, k := range cfg.APIKeys {
+		role := Role(k.Role)
+		// service accounts are not cats, so they cannot act as one
+		if role != RoleAdmin && role != RoleHandler {
+			return nil, fmt.Errorf("api key %q has invalid role %q", k.Name, k.Role)
+		}
+		a.apiKeys = append(a.apiKeys, apiKey{
+			key:       []byte(k.Key),
+			principal: Principal{Subject: k.Name, Role: role},
+		})
+	}
+
+	return a, nil
+}
+
+// IssueToken signs a token for principal that expires after ttl.
+func (a *Authenticator) IssueToken(principal Principal, ttl time.Duration) (string, error) {
+	if len(a.secret) == 0 {
+		return "", errors.New("JWT_SECRET is not configured")
+	}
+	if err := validatePrincipal(principal); err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	claims := Claims{
+		Role:  principal.Role,
+		CatID: principal.CatID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   principal.Subject,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+	}
+
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(a.secret)
+}
+
+func (a *Authenticator) AuthenticateToken(token string) (*Principal, error) {
+	if len(a.secret) == 0 {
+		return nil, ErrInvalidCredentials
+	}
+
+	var claims Claims
+	_, err := jwt.ParseWithClaims(token, &claims, func(*jwt.Token) (any, error) {
+		return a.secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	principal := Principal{Subject: claims.Subject, Role: claims.Role, CatID: claims.CatID}
+	if err := validatePrincipal(principal); err != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	return &principal, nil
+}
+
+func (a *Authenticator) AuthenticateAPIKey(key string) (*Principal, error) {
+	// every key is compared so the response time does not reveal a match
+	var match *Principal
+	for i := range a.apiKeys {
+		if subtle.ConstantTimeCompare(a.apiKeys[i].key, []byte(key)) == 1 {
+			match = &a.apiKeys[i].principal
+		}
+	}
+	if match == nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	principal := *match
+	return &principal, nil
+}
+
+func validatePrincipal(p Principal) error {
+	if p.Subject == "" {
+		return errors.New("subject is required")
+	}
+	if !p.Role.IsValid() {
+		return fmt.Errorf("invalid role %q", p.Role)
+	}
+	if p.Role == RoleFieldCat && p.CatID == nil {
+		return errors.New("field cats need a cat id")
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package tests
+
+import (
+	"spy-cat-agency/config"
+	"spy-cat-agency/internal/auth"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newAuthenticator(t *testing.T) *auth.Authenticator {
+	authenticator, err := auth.NewAuthenticator(&config.Config{
+		JWTSecret: "test-secret",
+		APIKeys:   []config.APIKey{{Name: "reporting", Role: "handler", Key: "reporting-key"}},
+	})
+	require.NoError(t, err)
+	return authenticator
+}
+
+func TestNewAuthenticator(t *testing.T) {
+	t.Run("requires credentials", func(t *testing.T) {
+		_, err := auth.NewAuthenticator(&config.Config{})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects api key acting as field cat", func(t *testing.T) {
+		_, err := auth.NewAuthenticator(&config.Config{
+			APIKeys: []config.APIKey{{Name: "bot", Role: "field-cat", Key: "key"}},
+		})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestAuthenticator_Token(t *testing.T) {
+	t.Run("round trips principal", func(t *testing.T) {
+		authenticator := newAuthenticator(t)
+		catID := uint(3)
+
+		token, err := authenticator.IssueToken(auth.Principal{Subject: "tom", Role: auth.RoleFieldCat, CatID: &catID}, time.Hour)
+		require.NoError(t, err)
+
+		principal, err := authenticator.AuthenticateToken(token)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &auth.Principal{Subject: "tom", Role: auth.RoleFieldCat, CatID: &catID}, principal)
+	})
+
+	t.Run("rejects expired token", func(t *testing.T) {
+		authenticator := newAuthenticator(t)
+
+		token, err := authenticator.IssueToken(auth.Principal{Subject: "jane", Role: auth.RoleAdmin}, -time.Minute)
+		require.NoError(t, err)
+
+		_, err = authenticator.AuthenticateToken(token)
+
+		assert.ErrorIs(t, err, auth.ErrInvalidCredentials)
+	})
+
+	t.Run("rejects token signed with another key", func(t *testing.T) {
+		other, err := auth.NewAuthenticator(&config.Config{JWTSecret: "other-secret"})
+		require.NoError(t, err)
+
+		token, err := other.IssueToken(auth.Principal{Subject: "jane", Role: auth.RoleAdmin}, time.Hour)
+		require.NoError(t, err)
+
+		_, err = newAuthenticator(t).AuthenticateToken(token)
+
+		assert.ErrorIs(t, err, auth.ErrInvalidCredentials)
+	})
+
+	t.Run("rejects unsigned token", func(t *testing.T) {
+		claims := auth.Claims{
+			Role:             auth.RoleAdmin,
+			RegisteredClaims: jwt.RegisteredClaims{Subject: "mallory", ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour))},
+		}
+		token, err := jwt.NewWithClaims(jwt.SigningMethodNone, claims).SignedString(jwt.UnsafeAllowNoneSignatureType)
+		require.NoError(t, err)
+
+		_, err = newAuthenticator(t).AuthenticateToken(token)
+
+		assert.ErrorIs(t, err, auth.ErrInvalidCredentials)
+	})
+
+	t.Run("rejects unknown role", func(t *testing.T) {
+		claims := auth.Claims{
+			Role:             "director",
+			RegisteredClaims: jwt.RegisteredClaims{Subject: "jane", ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour))},
+		}
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("test-secret"))
+		require.NoError(t, err)
+
+		_, err = newAuthenticator(t).AuthenticateToken(token)
+
+		assert.ErrorIs(t, err, auth.ErrInvalidCredentials)
+	})
+
+	t.Run("field cat token needs cat id", func(t *testing.T) {
+		_, err := newAuthenticator(t).IssueToken(auth.Principal{Subject: "tom", Role: auth.RoleFieldCat}, time.Hour)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestAuthenticator_APIKey(t *testing.T) {
+	t.Run("known key", func(t *testing.T) {
+		principal, err := newAuthenticator(t).AuthenticateAPIKey("reporting-key")
+
+		assert.NoError(t, err)
+		assert.Equal(t, &auth.Principal{Subject: "reporting", Role: auth.RoleHandler}, principal)
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		principal, err := newAuthenticator(t).AuthenticateAPIKey("guess")
+
+		assert.ErrorIs(t, err, auth.ErrInvalidCredentials)
+		assert.Nil(t, principal)
+	})
+}
@@ -1,7 +1,7 @@
 package database
 
 import (
-	"spy-cat-agency/internal/models"
+	"spy-cat-agency/migrations"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -19,10 +19,12 @@ func Connect(databaseURL string) (*gorm.DB, error) {
 	return db, nil
 }
 
-func Migrate(db *gorm.DB) error {
-	return db.AutoMigrate(
-		&models.Cat{},
-		&models.Mission{},
-		&models.Target{},
-	)
+// Migrate applies the pending embedded migrations.
+func Migrate(db *gorm.DB) ([]Migration, error) {
+	all, err := LoadMigrations(migrations.FS)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewMigrator(db, all).Up()
 }
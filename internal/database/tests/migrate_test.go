@@ -0,0 +1,100 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"spy-cat-agency/internal/database"
+	"spy-cat-agency/migrations"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	t.Run("pairs and orders files by version", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"010_later.up.sql":   {Data: []byte("CREATE TABLE b ();")},
+			"010_later.down.sql": {Data: []byte("DROP TABLE b;")},
+			"002_first.up.sql":   {Data: []byte("CREATE TABLE a ();")},
+			"002_first.down.sql": {Data: []byte("DROP TABLE a;")},
+			"migrations.go":      {Data: []byte("package migrations")},
+			"README":             {Data: []byte("not a migration")},
+		}
+
+		result, err := database.LoadMigrations(fsys)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []database.Migration{
+			{Version: 2, Name: "first", Up: "CREATE TABLE a ();", Down: "DROP TABLE a;"},
+			{Version: 10, Name: "later", Up: "CREATE TABLE b ();", Down: "DROP TABLE b;"},
+		}, result)
+	})
+
+	t.Run("missing down file", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"001_first.up.sql": {Data: []byte("CREATE TABLE a ();")},
+		}
+
+		_, err := database.LoadMigrations(fsys)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("version used twice", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"001_first.up.sql":   {Data: []byte("CREATE TABLE a ();")},
+			"001_first.down.sql": {Data: []byte("DROP TABLE a;")},
+			"001_other.up.sql":   {Data: []byte("CREATE TABLE b ();")},
+			"001_other.down.sql": {Data: []byte("DROP TABLE b;")},
+		}
+
+		_, err := database.LoadMigrations(fsys)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("malformed file name", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"first.sql": {Data: []byte("CREATE TABLE a ();")},
+		}
+
+		_, err := database.LoadMigrations(fsys)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("embedded migrations are complete and sequential", func(t *testing.T) {
+		result, err := database.LoadMigrations(migrations.FS)
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, result)
+		for i, migration := range result {
+			assert.Equal(t, uint(i+1), migration.Version, migration.String())
+		}
+	})
+}
+
+func TestCreateMigration(t *testing.T) {
+	t.Run("writes the next version", func(t *testing.T) {
+		dir := t.TempDir()
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "001_first.up.sql"), []byte("SELECT 1;"), 0o644))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "001_first.down.sql"), []byte("SELECT 1;"), 0o644))
+
+		up, down, err := database.CreateMigration(dir, "Add Cat Nicknames")
+
+		assert.NoError(t, err)
+		assert.Equal(t, filepath.Join(dir, "002_add_cat_nicknames.up.sql"), up)
+		assert.Equal(t, filepath.Join(dir, "002_add_cat_nicknames.down.sql"), down)
+
+		result, err := database.LoadMigrations(os.DirFS(dir))
+		assert.NoError(t, err)
+		assert.Len(t, result, 2)
+	})
+
+	t.Run("rejects name without letters or digits", func(t *testing.T) {
+		_, _, err := database.CreateMigration(t.TempDir(), "--")
+
+		assert.Error(t, err)
+	})
+}
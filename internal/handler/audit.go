@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"net/http"
+	"spy-cat-agency/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetAuditLogs retrieves a page of audit log entries
+// @Summary Get audit log
+// @Description Get a paginated list of changes made to cats, missions and targets, newest first. Filter by entity and by a created_at range (RFC 3339 timestamps, "to" is exclusive).
+// @Tags Audit
+// @Accept json
+// @Produce json
+// @Param filter query models.AuditFilter false "Filters, sorting and pagination"
+// @Success 200 {object} models.Page[models.AuditLog]
+// @Failure 400 {object} middleware.Problem
+// @Failure 401 {object} middleware.Problem
+// @Failure 403 {object} middleware.Problem
+// @Failure 500 {object} middleware.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /audit [get]
+func (h *Handler) GetAuditLogs(c *gin.Context) {
+	var filter models.AuditFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	entries, err := h.auditService.GetAll(c.Request.Context(), filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
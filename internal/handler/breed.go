@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetBreeds retrieves the breed catalog
+// @Summary Get all breeds
+// @Description Get the catalog of cat breeds accepted when creating cats, as provided by TheCatAPI
+// @Tags Breeds
+// @Accept json
+// @Produce json
+// @Success 200 {array} catapi.CatAPIBreed
+// @Failure 401 {object} middleware.Problem
+// @Failure 500 {object} middleware.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /breeds [get]
+func (h *Handler) GetBreeds(c *gin.Context) {
+	breeds, err := h.breedService.GetAll()
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, breeds)
+}
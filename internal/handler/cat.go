@@ -11,14 +11,18 @@ import (
 
 // CreateCat creates a new cat
 // @Summary Create a new cat
-// @Description Create a new cat with breed validation using TheCatAPI
+// @Description Create a new cat with breed validation using TheCatAPI. The breed may be given by name or id and is stored in its canonical form.
 // @Tags Cats
 // @Accept json
 // @Produce json
 // @Param dto body models.CreateCatDTO true "Cat data"
 // @Success 201 {object} models.Cat
-// @Failure 400 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} middleware.Problem
+// @Failure 401 {object} middleware.Problem
+// @Failure 403 {object} middleware.Problem
+// @Failure 500 {object} middleware.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /cats [post]
 func (h *Handler) CreateCat(c *gin.Context) {
 	var dto models.CreateCatDTO
@@ -27,7 +31,7 @@ func (h *Handler) CreateCat(c *gin.Context) {
 		return
 	}
 
-	cat, err := h.catService.Create(&dto)
+	cat, err := h.catService.Create(c.Request.Context(), &dto)
 	if err != nil {
 		c.Error(err)
 		return
@@ -36,17 +40,29 @@ func (h *Handler) CreateCat(c *gin.Context) {
 	c.JSON(http.StatusCreated, cat)
 }
 
-// GetCats retrieves all cats
+// GetCats retrieves a page of cats
 // @Summary Get all cats
-// @Description Get a list of all cats with their missions
+// @Description Get a paginated list of cats with their missions. Sort accepts a comma separated list of fields (id, name, breed, years_experience, salary, created_at), prefix with "-" for descending order.
 // @Tags Cats
 // @Accept json
 // @Produce json
-// @Success 200 {array} models.Cat
-// @Failure 500 {object} map[string]string
+// @Param filter query models.CatFilter false "Filters, sorting and pagination"
+// @Success 200 {object} models.Page[models.Cat]
+// @Failure 400 {object} middleware.Problem
+// @Failure 401 {object} middleware.Problem
+// @Failure 403 {object} middleware.Problem
+// @Failure 500 {object} middleware.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /cats [get]
 func (h *Handler) GetCats(c *gin.Context) {
-	cats, err := h.catService.GetAll()
+	var filter models.CatFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	cats, err := h.catService.GetAll(c.Request.Context(), filter)
 	if err != nil {
 		c.Error(err)
 		return
@@ -63,18 +79,22 @@ func (h *Handler) GetCats(c *gin.Context) {
 // @Produce json
 // @Param id path int true "Cat ID"
 // @Success 200 {object} models.Cat
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} middleware.Problem
+// @Failure 401 {object} middleware.Problem
+// @Failure 403 {object} middleware.Problem
+// @Failure 404 {object} middleware.Problem
+// @Failure 500 {object} middleware.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /cats/{id} [get]
 func (h *Handler) GetCat(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.Error(custerr.NewBadRequestErr("invalid id"))
+		c.Error(custerr.NewBadRequestErr("invalid id").WithCode(models.ErrCodeInvalidID))
 		return
 	}
 
-	cat, err := h.catService.GetByID(uint(id))
+	cat, err := h.catService.GetByID(c.Request.Context(), uint(id))
 	if err != nil {
 		c.Error(err)
 		return
@@ -85,21 +105,25 @@ func (h *Handler) GetCat(c *gin.Context) {
 
 // UpdateCat updates a cat's salary
 // @Summary Update cat salary
-// @Description Update a cat's salary (only salary can be modified)
+// @Description Update a cat's salary (only salary can be modified). The change is added to the cat's salary history, effective now unless effective_from backdates it to after the latest change.
 // @Tags Cats
 // @Accept json
 // @Produce json
 // @Param id path int true "Cat ID"
 // @Param dto body models.UpdateCatDTO true "Update data"
 // @Success 200 {object} map[string]string
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} middleware.Problem
+// @Failure 401 {object} middleware.Problem
+// @Failure 403 {object} middleware.Problem
+// @Failure 404 {object} middleware.Problem
+// @Failure 500 {object} middleware.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /cats/{id} [patch]
 func (h *Handler) UpdateCat(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.Error(custerr.NewBadRequestErr("invalid id"))
+		c.Error(custerr.NewBadRequestErr("invalid id").WithCode(models.ErrCodeInvalidID))
 		return
 	}
 
@@ -109,7 +133,7 @@ func (h *Handler) UpdateCat(c *gin.Context) {
 		return
 	}
 
-	cat, err := h.catService.Update(uint(id), dto)
+	cat, err := h.catService.Update(c.Request.Context(), uint(id), dto)
 	if err != nil {
 		c.Error(err)
 		return
@@ -118,26 +142,69 @@ func (h *Handler) UpdateCat(c *gin.Context) {
 	c.JSON(http.StatusOK, cat)
 }
 
+// GetCatSalaries retrieves a cat's salary history
+// @Summary Get cat salary history
+// @Description Every salary the cat has had and the moment it took effect, oldest first
+// @Tags Cats
+// @Produce json
+// @Param id path int true "Cat ID"
+// @Success 200 {array} models.CatSalary
+// @Failure 400 {object} middleware.Problem
+// @Failure 401 {object} middleware.Problem
+// @Failure 403 {object} middleware.Problem
+// @Failure 404 {object} middleware.Problem
+// @Failure 500 {object} middleware.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /cats/{id}/salaries [get]
+func (h *Handler) GetCatSalaries(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(custerr.NewBadRequestErr("invalid id").WithCode(models.ErrCodeInvalidID))
+		return
+	}
+
+	salaries, err := h.catService.GetSalaries(c.Request.Context(), uint(id))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, salaries)
+}
+
 // DeleteCat removes a cat
 // @Summary Delete cat
-// @Description Delete a cat (only if not on active mission)
+// @Description Soft delete a cat. A cat on an active mission is only deleted with force=true, which unassigns it from the mission first. Deleted cats can be restored.
 // @Tags Cats
 // @Accept json
 // @Produce json
 // @Param id path int true "Cat ID"
-// @Success 200 {object} map[string]string
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Param query query models.DeleteCatQuery false "Options"
+// @Success 204
+// @Failure 400 {object} middleware.Problem
+// @Failure 401 {object} middleware.Problem
+// @Failure 403 {object} middleware.Problem
+// @Failure 404 {object} middleware.Problem
+// @Failure 409 {object} middleware.Problem
+// @Failure 500 {object} middleware.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /cats/{id} [delete]
 func (h *Handler) DeleteCat(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.Error(custerr.NewBadRequestErr("invalid id"))
+		c.Error(custerr.NewBadRequestErr("invalid id").WithCode(models.ErrCodeInvalidID))
+		return
+	}
+
+	var query models.DeleteCatQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(custerr.NewBadRequestErr("invalid force flag").WithCode(models.ErrCodeValidationFailed))
 		return
 	}
 
-	if err := h.catService.Delete(uint(id)); err != nil {
+	if err := h.catService.Delete(c.Request.Context(), uint(id), query.Force); err != nil {
 		c.Error(err)
 		return
 	}
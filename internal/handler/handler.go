@@ -5,13 +5,54 @@ import (
 )
 
 type Handler struct {
-	catService     CatService
-	missionService MissionService
+	catService      CatService
+	missionService  MissionService
+	breedService    BreedService
+	auditService    AuditService
+	meService       MeService
+	trashService    TrashService
+	transferService TransferService
+	statsService    StatsService
+	payrollService  PayrollService
+}
+
+// Services are the services behind the handlers.
+type Services struct {
+	Cat      CatService
+	Mission  MissionService
+	Breed    BreedService
+	Audit    AuditService
+	Me       MeService
+	Trash    TrashService
+	Transfer TransferService
+	Stats    StatsService
+	Payroll  PayrollService
 }
 
 func New(services *service.Service) *Handler {
+	return NewWithServices(Services{
+		Cat:      services.Cat,
+		Mission:  services.Mission,
+		Breed:    services.Breed,
+		Audit:    services.Audit,
+		Me:       services.Me,
+		Trash:    services.Trash,
+		Transfer: services.Transfer,
+		Stats:    services.Stats,
+		Payroll:  services.Payroll,
+	})
+}
+
+func NewWithServices(services Services) *Handler {
 	return &Handler{
-		catService:     services.Cat,
-		missionService: services.Mission,
+		catService:      services.Cat,
+		missionService:  services.Mission,
+		breedService:    services.Breed,
+		auditService:    services.Audit,
+		meService:       services.Me,
+		trashService:    services.Trash,
+		transferService: services.Transfer,
+		statsService:    services.Stats,
+		payrollService:  services.Payroll,
 	}
 }
@@ -1,23 +1,77 @@
 package handler
 
-import "spy-cat-agency/internal/models"
+import (
+	"context"
+	"io"
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/pkg/catapi"
+)
 
 type CatService interface {
-	Create(dto *models.CreateCatDTO) (*models.Cat, error)
-	GetAll() ([]models.Cat, error)
-	GetByID(id uint) (*models.Cat, error)
-	Update(id uint, dto models.UpdateCatDTO) (*models.Cat, error)
-	Delete(id uint) error
+	Create(ctx context.Context, dto *models.CreateCatDTO) (*models.Cat, error)
+	GetAll(ctx context.Context, filter models.CatFilter) (*models.Page[models.Cat], error)
+	GetByID(ctx context.Context, id uint) (*models.Cat, error)
+	Update(ctx context.Context, id uint, dto models.UpdateCatDTO) (*models.Cat, error)
+	Delete(ctx context.Context, id uint, force bool) error
+	GetSalaries(ctx context.Context, id uint) ([]models.CatSalary, error)
 }
 
 type MissionService interface {
-	Create(dto models.CreateMissionDTO) (*models.Mission, error)
-	GetAll() ([]models.Mission, error)
-	GetByID(id uint) (*models.Mission, error)
-	Update(id uint, dto models.UpdateMissionDTO) (*models.Mission, error)
-	Delete(id uint) error
-	AssignCat(missionID, catID uint) (*models.Mission, error)
-	CreateTarget(missionID uint, dto models.CreateTargetDTO) (*models.Mission, error)
-	UpdateTarget(missionID, targetID uint, dto models.UpdateTargetDTO) (*models.Mission, error)
-	DeleteTarget(missionID, targetID uint) (*models.Mission, error)
+	Create(ctx context.Context, dto models.CreateMissionDTO) (*models.Mission, error)
+	GetAll(ctx context.Context, filter models.MissionFilter) (*models.Page[models.Mission], error)
+	GetByID(ctx context.Context, id uint) (*models.Mission, error)
+	Update(ctx context.Context, id uint, dto models.UpdateMissionDTO) (*models.Mission, error)
+	Delete(ctx context.Context, id uint) error
+	AssignCat(ctx context.Context, missionID, catID uint) (*models.Mission, error)
+	UnassignCat(ctx context.Context, missionID uint, dto models.MissionAssignmentDTO) (*models.Mission, error)
+	ReassignCat(ctx context.Context, missionID, catID uint, dto models.MissionAssignmentDTO) (*models.Mission, error)
+	GetAssignments(ctx context.Context, missionID uint) ([]models.MissionAssignment, error)
+	CreateTarget(ctx context.Context, missionID uint, dto models.CreateTargetDTO) (*models.Mission, error)
+	UpdateTarget(ctx context.Context, missionID, targetID uint, dto models.UpdateTargetDTO) (*models.Mission, error)
+	DeleteTarget(ctx context.Context, missionID, targetID uint) (*models.Mission, error)
+	GetTargetRevisions(ctx context.Context, missionID, targetID uint) ([]models.TargetNoteRevision, error)
+	GetTargetRevision(ctx context.Context, missionID, targetID, revisionID uint) (*models.TargetNoteRevision, error)
+	DiffTargetRevisions(ctx context.Context, missionID, targetID, fromID, toID uint) (*models.TargetNotesDiff, error)
+}
+
+type BreedService interface {
+	GetAll() ([]catapi.CatAPIBreed, error)
+}
+
+type AuditService interface {
+	GetAll(ctx context.Context, filter models.AuditFilter) (*models.Page[models.AuditLog], error)
+}
+
+type MeService interface {
+	GetProfile(ctx context.Context) (*models.CatProfile, error)
+	GetActiveMission(ctx context.Context) (*models.Mission, error)
+	GetMissions(ctx context.Context, filter models.CatMissionFilter) (*models.Page[models.Mission], error)
+	UpdateTarget(ctx context.Context, targetID uint, dto models.UpdateTargetDTO) (*models.Mission, error)
+}
+
+type TrashService interface {
+	GetDeletedCats(ctx context.Context, query models.PageQuery) (*models.Page[models.Cat], error)
+	GetDeletedMissions(ctx context.Context, query models.PageQuery) (*models.Page[models.Mission], error)
+	GetDeletedTargets(ctx context.Context, query models.PageQuery) (*models.Page[models.Target], error)
+	RestoreCat(ctx context.Context, id uint) (*models.Cat, error)
+	RestoreMission(ctx context.Context, id uint) (*models.Mission, error)
+	RestoreTarget(ctx context.Context, id uint) (*models.Target, error)
+}
+
+type TransferService interface {
+	ImportCats(ctx context.Context, format models.TransferFormat, r io.Reader) (*models.ImportResult, error)
+	ExportCats(ctx context.Context, filter models.CatExportFilter) ([]byte, error)
+	ImportMissions(ctx context.Context, format models.TransferFormat, r io.Reader) (*models.ImportResult, error)
+	ExportMissions(ctx context.Context, filter models.MissionExportFilter) ([]byte, error)
+}
+
+type StatsService interface {
+	GetOverview(ctx context.Context, filter models.StatsFilter) (*models.StatsOverview, error)
+	GetCatCompletion(ctx context.Context, filter models.StatsFilter) ([]models.CatCompletionStats, error)
+	GetTargetsByCountry(ctx context.Context, filter models.StatsFilter) ([]models.CountryStats, error)
+	GetIdleCats(ctx context.Context, filter models.StatsFilter) ([]models.IdleCat, error)
+}
+
+type PayrollService interface {
+	GetReport(ctx context.Context, query models.PayrollQuery) (*models.PayrollReport, error)
 }
@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"net/http"
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/pkg/custerr"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetMe retrieves the profile of the calling cat
+// @Summary Get own profile
+// @Description Get the profile of the authenticated field cat
+// @Tags Me
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.CatProfile
+// @Failure 401 {object} middleware.Problem
+// @Failure 403 {object} middleware.Problem
+// @Failure 404 {object} middleware.Problem
+// @Failure 500 {object} middleware.Problem
+// @Security BearerAuth
+// @Router /me [get]
+func (h *Handler) GetMe(c *gin.Context) {
+	profile, err := h.meService.GetProfile(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+// GetMyMission retrieves the active mission of the calling cat
+// @Summary Get own active mission
+// @Description Get the mission currently assigned to the authenticated field cat
+// @Tags Me
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.Mission
+// @Failure 401 {object} middleware.Problem
+// @Failure 403 {object} middleware.Problem
+// @Failure 404 {object} middleware.Problem
+// @Failure 500 {object} middleware.Problem
+// @Security BearerAuth
+// @Router /me/mission [get]
+func (h *Handler) GetMyMission(c *gin.Context) {
+	mission, err := h.meService.GetActiveMission(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mission)
+}
+
+// GetMyMissions retrieves the mission history of the calling cat
+// @Summary Get own missions
+// @Description Get a paginated list of missions assigned to the authenticated field cat, including completed ones
+// @Tags Me
+// @Accept json
+// @Produce json
+// @Param filter query models.CatMissionFilter false "Filters, sorting and pagination"
+// @Success 200 {object} models.Page[models.Mission]
+// @Failure 400 {object} middleware.Problem
+// @Failure 401 {object} middleware.Problem
+// @Failure 403 {object} middleware.Problem
+// @Failure 500 {object} middleware.Problem
+// @Security BearerAuth
+// @Router /me/missions [get]
+func (h *Handler) GetMyMissions(c *gin.Context) {
+	var filter models.CatMissionFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	missions, err := h.meService.GetMissions(c.Request.Context(), filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, missions)
+}
+
+// UpdateMyTarget updates a target of the calling cat's active mission
+// @Summary Update own target
+// @Description Update notes or status of a target on the authenticated field cat's active mission. The same status rules apply as for the mission target endpoint.
+// @Tags Me
+// @Accept json
+// @Produce json
+// @Param target_id path int true "Target ID"
+// @Param dto body models.UpdateTargetDTO true "Update data"
+// @Success 200 {object} models.Mission
+// @Failure 400 {object} middleware.Problem
+// @Failure 401 {object} middleware.Problem
+// @Failure 403 {object} middleware.Problem
+// @Failure 404 {object} middleware.Problem
+// @Failure 409 {object} middleware.Problem
+// @Failure 500 {object} middleware.Problem
+// @Security BearerAuth
+// @Router /me/mission/targets/{target_id} [patch]
+func (h *Handler) UpdateMyTarget(c *gin.Context) {
+	targetID, err := strconv.ParseUint(c.Param("target_id"), 10, 32)
+	if err != nil {
+		c.Error(custerr.NewBadRequestErr("invalid target id").WithCode(models.ErrCodeInvalidID))
+		return
+	}
+
+	var dto models.UpdateTargetDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	mission, err := h.meService.UpdateTarget(c.Request.Context(), uint(targetID), dto)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mission)
+}
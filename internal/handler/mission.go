@@ -17,7 +17,11 @@ import (
 // @Produce json
 // @Param dto body models.CreateMissionDTO true "Mission data"
 // @Success 201 {object} models.Mission
-// @Failure 400 {object} map[string]string
+// @Failure 400 {object} middleware.Problem
+// @Failure 401 {object} middleware.Problem
+// @Failure 403 {object} middleware.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /missions [post]
 func (h *Handler) CreateMission(c *gin.Context) {
 	var dto models.CreateMissionDTO
@@ -26,7 +30,7 @@ func (h *Handler) CreateMission(c *gin.Context) {
 		return
 	}
 
-	mission, err := h.missionService.Create(dto)
+	mission, err := h.missionService.Create(c.Request.Context(), dto)
 	if err != nil {
 		c.Error(err)
 		return
@@ -35,17 +39,29 @@ func (h *Handler) CreateMission(c *gin.Context) {
 	c.JSON(http.StatusCreated, mission)
 }
 
-// GetMissions retrieves all missions
+// GetMissions retrieves a page of missions
 // @Summary Get all missions
-// @Description Get a list of all missions with cats and targets
+// @Description Get a paginated list of missions with cats and targets. Sort accepts a comma separated list of fields (id, cat_id, complete, created_at, updated_at), prefix with "-" for descending order.
 // @Tags Missions
 // @Accept json
 // @Produce json
-// @Success 200 {array} models.Mission
-// @Failure 500 {object} map[string]string
+// @Param filter query models.MissionFilter false "Filters, sorting and pagination"
+// @Success 200 {object} models.Page[models.Mission]
+// @Failure 400 {object} middleware.Problem
+// @Failure 401 {object} middleware.Problem
+// @Failure 403 {object} middleware.Problem
+// @Failure 500 {object} middleware.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /missions [get]
 func (h *Handler) GetMissions(c *gin.Context) {
-	missions, err := h.missionService.GetAll()
+	var filter models.MissionFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	missions, err := h.missionService.GetAll(c.Request.Context(), filter)
 	if err != nil {
 		c.Error(err)
 		return
@@ -62,18 +78,22 @@ func (h *Handler) GetMissions(c *gin.Context) {
 // @Produce json
 // @Param id path int true "Mission ID"
 // @Success 200 {object} models.Mission
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} middleware.Problem
+// @Failure 401 {object} middleware.Problem
+// @Failure 403 {object} middleware.Problem
+// @Failure 404 {object} middleware.Problem
+// @Failure 500 {object} middleware.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /missions/{id} [get]
 func (h *Handler) GetMission(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.Error(custerr.NewBadRequestErr("invalid id"))
+		c.Error(custerr.NewBadRequestErr("invalid id").WithCode(models.ErrCodeInvalidID))
 		return
 	}
 
-	mission, err := h.missionService.GetByID(uint(id))
+	mission, err := h.missionService.GetByID(c.Request.Context(), uint(id))
 	if err != nil {
 		c.Error(err)
 		return
@@ -84,22 +104,26 @@ func (h *Handler) GetMission(c *gin.Context) {
 
 // UpdateMission updates mission status
 // @Summary Update mission status
-// @Description Update mission completion status
+// @Description Update mission completion status. A mission can only be completed manually once all of its targets are terminal, unless "force" is set. Reopening a completed mission whose targets are all terminal also requires "force", as it would never complete again on its own. Reopening a mission fails if its cat already has another active mission.
 // @Tags Missions
 // @Accept json
 // @Produce json
 // @Param id path int true "Mission ID"
 // @Param dto body models.UpdateMissionDTO true "Update data"
 // @Success 200 {object} map[string]string
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 409 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} middleware.Problem
+// @Failure 401 {object} middleware.Problem
+// @Failure 403 {object} middleware.Problem
+// @Failure 404 {object} middleware.Problem
+// @Failure 409 {object} middleware.Problem
+// @Failure 500 {object} middleware.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /missions/{id} [patch]
 func (h *Handler) UpdateMission(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		c.Error(custerr.NewBadRequestErr("invalid id").WithCode(models.ErrCodeInvalidID))
 		return
 	}
 
@@ -109,7 +133,7 @@ func (h *Handler) UpdateMission(c *gin.Context) {
 		return
 	}
 
-	mission, err := h.missionService.Update(uint(id), dto)
+	mission, err := h.missionService.Update(c.Request.Context(), uint(id), dto)
 	if err != nil {
 		c.Error(err)
 		return
@@ -126,19 +150,23 @@ func (h *Handler) UpdateMission(c *gin.Context) {
 // @Produce json
 // @Param id path int true "Mission ID"
 // @Success 200
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 409 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} middleware.Problem
+// @Failure 401 {object} middleware.Problem
+// @Failure 403 {object} middleware.Problem
+// @Failure 404 {object} middleware.Problem
+// @Failure 409 {object} middleware.Problem
+// @Failure 500 {object} middleware.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /missions/{id} [delete]
 func (h *Handler) DeleteMission(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.Error(custerr.NewBadRequestErr("invalid id"))
+		c.Error(custerr.NewBadRequestErr("invalid id").WithCode(models.ErrCodeInvalidID))
 		return
 	}
 
-	if err := h.missionService.Delete(uint(id)); err != nil {
+	if err := h.missionService.Delete(c.Request.Context(), uint(id)); err != nil {
 		c.Error(err)
 		return
 	}
@@ -148,32 +176,36 @@ func (h *Handler) DeleteMission(c *gin.Context) {
 
 // AssignCatToMission assigns a cat to a mission
 // @Summary Assign cat to mission
-// @Description Assign a spy cat to a mission (cat can only have one active mission)
+// @Description Assign a spy cat to a mission without a cat (cat can only have one active mission)
 // @Tags Missions
 // @Accept json
 // @Produce json
 // @Param id path int true "Mission ID"
 // @Param cat_id path int true "Cat ID"
 // @Success 200 {object} map[string]string
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 409 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} middleware.Problem
+// @Failure 401 {object} middleware.Problem
+// @Failure 403 {object} middleware.Problem
+// @Failure 404 {object} middleware.Problem
+// @Failure 409 {object} middleware.Problem
+// @Failure 500 {object} middleware.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /missions/{id}/assign/{cat_id} [patch]
 func (h *Handler) AssignCatToMission(c *gin.Context) {
 	missionID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.Error(custerr.NewBadRequestErr("invalid mission id"))
+		c.Error(custerr.NewBadRequestErr("invalid mission id").WithCode(models.ErrCodeInvalidID))
 		return
 	}
 
 	catID, err := strconv.ParseUint(c.Param("cat_id"), 10, 32)
 	if err != nil {
-		c.Error(custerr.NewBadRequestErr("invalid cat id"))
+		c.Error(custerr.NewBadRequestErr("invalid cat id").WithCode(models.ErrCodeInvalidID))
 		return
 	}
 
-	mission, err := h.missionService.AssignCat(uint(missionID), uint(catID))
+	mission, err := h.missionService.AssignCat(c.Request.Context(), uint(missionID), uint(catID))
 	if err != nil {
 		c.Error(err)
 		return
@@ -182,6 +214,125 @@ func (h *Handler) AssignCatToMission(c *gin.Context) {
 	c.JSON(http.StatusOK, mission)
 }
 
+// UnassignCatFromMission removes the cat from a mission
+// @Summary Unassign cat from mission
+// @Description Remove the cat from an active mission, recording the reason
+// @Tags Missions
+// @Accept json
+// @Produce json
+// @Param id path int true "Mission ID"
+// @Param dto body models.MissionAssignmentDTO true "Reason"
+// @Success 200 {object} models.Mission
+// @Failure 400 {object} middleware.Problem
+// @Failure 401 {object} middleware.Problem
+// @Failure 403 {object} middleware.Problem
+// @Failure 404 {object} middleware.Problem
+// @Failure 409 {object} middleware.Problem
+// @Failure 500 {object} middleware.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /missions/{id}/unassign [patch]
+func (h *Handler) UnassignCatFromMission(c *gin.Context) {
+	missionID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(custerr.NewBadRequestErr("invalid mission id").WithCode(models.ErrCodeInvalidID))
+		return
+	}
+
+	var dto models.MissionAssignmentDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	mission, err := h.missionService.UnassignCat(c.Request.Context(), uint(missionID), dto)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mission)
+}
+
+// ReassignMission hands a mission over to another cat
+// @Summary Reassign mission
+// @Description Replace the cat of an active mission with another cat in one step, recording the reason. The new cat must not have an active mission.
+// @Tags Missions
+// @Accept json
+// @Produce json
+// @Param id path int true "Mission ID"
+// @Param cat_id path int true "New cat ID"
+// @Param dto body models.MissionAssignmentDTO true "Reason"
+// @Success 200 {object} models.Mission
+// @Failure 400 {object} middleware.Problem
+// @Failure 401 {object} middleware.Problem
+// @Failure 403 {object} middleware.Problem
+// @Failure 404 {object} middleware.Problem
+// @Failure 409 {object} middleware.Problem
+// @Failure 500 {object} middleware.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /missions/{id}/reassign/{cat_id} [patch]
+func (h *Handler) ReassignMission(c *gin.Context) {
+	missionID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(custerr.NewBadRequestErr("invalid mission id").WithCode(models.ErrCodeInvalidID))
+		return
+	}
+
+	catID, err := strconv.ParseUint(c.Param("cat_id"), 10, 32)
+	if err != nil {
+		c.Error(custerr.NewBadRequestErr("invalid cat id").WithCode(models.ErrCodeInvalidID))
+		return
+	}
+
+	var dto models.MissionAssignmentDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	mission, err := h.missionService.ReassignCat(c.Request.Context(), uint(missionID), uint(catID), dto)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mission)
+}
+
+// GetMissionAssignments lists the assignment history of a mission
+// @Summary Get mission assignments
+// @Description Get every assignment, unassignment and reassignment of a mission, oldest first
+// @Tags Missions
+// @Accept json
+// @Produce json
+// @Param id path int true "Mission ID"
+// @Success 200 {array} models.MissionAssignment
+// @Failure 400 {object} middleware.Problem
+// @Failure 401 {object} middleware.Problem
+// @Failure 403 {object} middleware.Problem
+// @Failure 404 {object} middleware.Problem
+// @Failure 500 {object} middleware.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /missions/{id}/assignments [get]
+func (h *Handler) GetMissionAssignments(c *gin.Context) {
+	missionID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(custerr.NewBadRequestErr("invalid mission id").WithCode(models.ErrCodeInvalidID))
+		return
+	}
+
+	assignments, err := h.missionService.GetAssignments(c.Request.Context(), uint(missionID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, assignments)
+}
+
 // CreateTarget adds a target to a mission
 // @Summary Add target to mission
 // @Description Add a new target to an existing mission (max 3 targets per mission)
@@ -191,15 +342,19 @@ func (h *Handler) AssignCatToMission(c *gin.Context) {
 // @Param id path int true "Mission ID"
 // @Param dto body models.CreateTargetDTO true "Target data"
 // @Success 201 {object} map[string]string
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 409 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} middleware.Problem
+// @Failure 401 {object} middleware.Problem
+// @Failure 403 {object} middleware.Problem
+// @Failure 404 {object} middleware.Problem
+// @Failure 409 {object} middleware.Problem
+// @Failure 500 {object} middleware.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /missions/{id}/targets [post]
 func (h *Handler) CreateTarget(c *gin.Context) {
 	missionID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.Error(custerr.NewBadRequestErr("invalid mission id"))
+		c.Error(custerr.NewBadRequestErr("invalid mission id").WithCode(models.ErrCodeInvalidID))
 		return
 	}
 
@@ -209,7 +364,7 @@ func (h *Handler) CreateTarget(c *gin.Context) {
 		return
 	}
 
-	mission, err := h.missionService.CreateTarget(uint(missionID), dto)
+	mission, err := h.missionService.CreateTarget(c.Request.Context(), uint(missionID), dto)
 	if err != nil {
 		c.Error(err)
 		return
@@ -220,7 +375,7 @@ func (h *Handler) CreateTarget(c *gin.Context) {
 
 // UpdateTarget updates a target
 // @Summary Update target
-// @Description Update target notes or completion status (cannot update if completed)
+// @Description Update target notes or status. Allowed status changes: pending -> under_surveillance|escaped|neutralized, under_surveillance -> compromised|escaped|neutralized, compromised -> under_surveillance|escaped|neutralized. Escaped and neutralized are terminal; terminal targets cannot be updated and the mission completes once all its targets are terminal. "complete": true is a shorthand for neutralized.
 // @Tags Missions
 // @Accept json
 // @Produce json
@@ -228,21 +383,25 @@ func (h *Handler) CreateTarget(c *gin.Context) {
 // @Param target_id path int true "Target ID"
 // @Param dto body models.UpdateTargetDTO true "Update data"
 // @Success 200 {object} map[string]string
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 409 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} middleware.Problem
+// @Failure 401 {object} middleware.Problem
+// @Failure 403 {object} middleware.Problem
+// @Failure 404 {object} middleware.Problem "Mission or target not found, also when the target belongs to another mission"
+// @Failure 409 {object} middleware.Problem
+// @Failure 500 {object} middleware.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /missions/{id}/targets/{target_id} [patch]
 func (h *Handler) UpdateTarget(c *gin.Context) {
 	missionID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.Error(custerr.NewBadRequestErr("invalid mission id"))
+		c.Error(custerr.NewBadRequestErr("invalid mission id").WithCode(models.ErrCodeInvalidID))
 		return
 	}
 
 	targetID, err := strconv.ParseUint(c.Param("target_id"), 10, 32)
 	if err != nil {
-		c.Error(custerr.NewBadRequestErr("invalid target id"))
+		c.Error(custerr.NewBadRequestErr("invalid target id").WithCode(models.ErrCodeInvalidID))
 		return
 	}
 
@@ -252,7 +411,7 @@ func (h *Handler) UpdateTarget(c *gin.Context) {
 		return
 	}
 
-	mission, err := h.missionService.UpdateTarget(uint(missionID), uint(targetID), dto)
+	mission, err := h.missionService.UpdateTarget(c.Request.Context(), uint(missionID), uint(targetID), dto)
 	if err != nil {
 		c.Error(err)
 		return
@@ -270,25 +429,29 @@ func (h *Handler) UpdateTarget(c *gin.Context) {
 // @Param id path int true "Mission ID"
 // @Param target_id path int true "Target ID"
 // @Success 200 {object} map[string]string
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 409 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} middleware.Problem
+// @Failure 401 {object} middleware.Problem
+// @Failure 403 {object} middleware.Problem
+// @Failure 404 {object} middleware.Problem "Mission or target not found, also when the target belongs to another mission"
+// @Failure 409 {object} middleware.Problem
+// @Failure 500 {object} middleware.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /missions/{id}/targets/{target_id} [delete]
 func (h *Handler) DeleteTarget(c *gin.Context) {
 	missionID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.Error(custerr.NewBadRequestErr("invalid mission id"))
+		c.Error(custerr.NewBadRequestErr("invalid mission id").WithCode(models.ErrCodeInvalidID))
 		return
 	}
 
 	targetID, err := strconv.ParseUint(c.Param("target_id"), 10, 32)
 	if err != nil {
-		c.Error(custerr.NewBadRequestErr("invalid target id"))
+		c.Error(custerr.NewBadRequestErr("invalid target id").WithCode(models.ErrCodeInvalidID))
 		return
 	}
 
-	mission, err := h.missionService.DeleteTarget(uint(missionID), uint(targetID))
+	mission, err := h.missionService.DeleteTarget(c.Request.Context(), uint(missionID), uint(targetID))
 	if err != nil {
 		c.Error(err)
 		return
@@ -296,3 +459,134 @@ func (h *Handler) DeleteTarget(c *gin.Context) {
 
 	c.JSON(http.StatusOK, mission)
 }
+
+// GetTargetRevisions lists the notes revisions of a target
+// @Summary Get target notes revisions
+// @Description Get every revision of a target's notes, oldest first
+// @Tags Missions
+// @Accept json
+// @Produce json
+// @Param id path int true "Mission ID"
+// @Param target_id path int true "Target ID"
+// @Success 200 {array} models.TargetNoteRevision
+// @Failure 400 {object} middleware.Problem
+// @Failure 401 {object} middleware.Problem
+// @Failure 403 {object} middleware.Problem
+// @Failure 404 {object} middleware.Problem "Mission or target not found, also when the target belongs to another mission"
+// @Failure 500 {object} middleware.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /missions/{id}/targets/{target_id}/revisions [get]
+func (h *Handler) GetTargetRevisions(c *gin.Context) {
+	missionID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(custerr.NewBadRequestErr("invalid mission id").WithCode(models.ErrCodeInvalidID))
+		return
+	}
+
+	targetID, err := strconv.ParseUint(c.Param("target_id"), 10, 32)
+	if err != nil {
+		c.Error(custerr.NewBadRequestErr("invalid target id").WithCode(models.ErrCodeInvalidID))
+		return
+	}
+
+	revisions, err := h.missionService.GetTargetRevisions(c.Request.Context(), uint(missionID), uint(targetID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, revisions)
+}
+
+// GetTargetRevision retrieves a single notes revision of a target
+// @Summary Get target notes revision
+// @Description Get a single revision of a target's notes
+// @Tags Missions
+// @Accept json
+// @Produce json
+// @Param id path int true "Mission ID"
+// @Param target_id path int true "Target ID"
+// @Param revision_id path int true "Revision ID"
+// @Success 200 {object} models.TargetNoteRevision
+// @Failure 400 {object} middleware.Problem
+// @Failure 401 {object} middleware.Problem
+// @Failure 403 {object} middleware.Problem
+// @Failure 404 {object} middleware.Problem "Mission or target not found, also when the target belongs to another mission"
+// @Failure 500 {object} middleware.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /missions/{id}/targets/{target_id}/revisions/{revision_id} [get]
+func (h *Handler) GetTargetRevision(c *gin.Context) {
+	missionID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(custerr.NewBadRequestErr("invalid mission id").WithCode(models.ErrCodeInvalidID))
+		return
+	}
+
+	targetID, err := strconv.ParseUint(c.Param("target_id"), 10, 32)
+	if err != nil {
+		c.Error(custerr.NewBadRequestErr("invalid target id").WithCode(models.ErrCodeInvalidID))
+		return
+	}
+
+	revisionID, err := strconv.ParseUint(c.Param("revision_id"), 10, 32)
+	if err != nil {
+		c.Error(custerr.NewBadRequestErr("invalid revision id").WithCode(models.ErrCodeInvalidID))
+		return
+	}
+
+	revision, err := h.missionService.GetTargetRevision(c.Request.Context(), uint(missionID), uint(targetID), uint(revisionID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, revision)
+}
+
+// DiffTargetRevisions compares two notes revisions of a target
+// @Summary Diff target notes revisions
+// @Description Get a line by line diff turning the notes of revision "from" into those of revision "to"
+// @Tags Missions
+// @Accept json
+// @Produce json
+// @Param id path int true "Mission ID"
+// @Param target_id path int true "Target ID"
+// @Param query query models.TargetNotesDiffQuery true "Revisions to compare"
+// @Success 200 {object} models.TargetNotesDiff
+// @Failure 400 {object} middleware.Problem
+// @Failure 401 {object} middleware.Problem
+// @Failure 403 {object} middleware.Problem
+// @Failure 404 {object} middleware.Problem "Mission or target not found, also when the target belongs to another mission"
+// @Failure 500 {object} middleware.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /missions/{id}/targets/{target_id}/revisions/diff [get]
+func (h *Handler) DiffTargetRevisions(c *gin.Context) {
+	missionID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(custerr.NewBadRequestErr("invalid mission id").WithCode(models.ErrCodeInvalidID))
+		return
+	}
+
+	targetID, err := strconv.ParseUint(c.Param("target_id"), 10, 32)
+	if err != nil {
+		c.Error(custerr.NewBadRequestErr("invalid target id").WithCode(models.ErrCodeInvalidID))
+		return
+	}
+
+	var query models.TargetNotesDiffQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	diff, err := h.missionService.DiffTargetRevisions(c.Request.Context(), uint(missionID), uint(targetID), query.From, query.To)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
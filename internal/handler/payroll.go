@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"net/http"
+	"spy-cat-agency/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetPayroll retrieves what cats were owed over a period
+// @Summary Get payroll report
+// @Description What every cat was owed from one day to another, both inclusive (YYYY-MM-DD, UTC). Annual salaries are prorated per day over 365 days, split into periods at every salary change. Deleted cats are paid up to the day before their deletion.
+// @Tags Payroll
+// @Produce json
+// @Param query query models.PayrollQuery true "Period"
+// @Success 200 {object} models.PayrollReport
+// @Failure 400 {object} middleware.Problem
+// @Failure 401 {object} middleware.Problem
+// @Failure 403 {object} middleware.Problem
+// @Failure 500 {object} middleware.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /payroll [get]
+func (h *Handler) GetPayroll(c *gin.Context) {
+	var query models.PayrollQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	report, err := h.payrollService.GetReport(c.Request.Context(), query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"spy-cat-agency/internal/auth"
+	"spy-cat-agency/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Routes registers the API on api, which must authenticate requests. The
+// roles allowed are checked per route.
+func (h *Handler) Routes(api *gin.RouterGroup) {
+	// field cats only see their own data through /me
+	staff := middleware.RequireRole(auth.RoleAdmin, auth.RoleHandler)
+	admin := middleware.RequireRole(auth.RoleAdmin)
+
+	cats := api.Group("/cats", staff)
+	cats.POST("", h.CreateCat)
+	cats.GET("", h.GetCats)
+	cats.POST("/import", h.ImportCats)
+	cats.GET("/export", h.ExportCats)
+	cats.GET("/:id", h.GetCat)
+	cats.GET("/:id/salaries", h.GetCatSalaries)
+	cats.PATCH("/:id", admin, h.UpdateCat)
+	cats.DELETE("/:id", admin, h.DeleteCat)
+
+	api.GET("/breeds", h.GetBreeds)
+	api.GET("/audit", admin, h.GetAuditLogs)
+	api.GET("/payroll", admin, h.GetPayroll)
+
+	missions := api.Group("/missions", staff)
+	missions.POST("", h.CreateMission)
+	missions.GET("", h.GetMissions)
+	missions.POST("/import", h.ImportMissions)
+	missions.GET("/export", h.ExportMissions)
+	missions.GET("/:id", h.GetMission)
+	missions.PATCH("/:id", h.UpdateMission)
+	missions.DELETE("/:id", h.DeleteMission)
+	missions.PATCH("/:id/assign/:cat_id", h.AssignCatToMission)
+	missions.PATCH("/:id/unassign", h.UnassignCatFromMission)
+	missions.PATCH("/:id/reassign/:cat_id", h.ReassignMission)
+	missions.GET("/:id/assignments", h.GetMissionAssignments)
+	missions.POST("/:id/targets", h.CreateTarget)
+	missions.PATCH("/:id/targets/:target_id", h.UpdateTarget)
+	missions.DELETE("/:id/targets/:target_id", h.DeleteTarget)
+	missions.GET("/:id/targets/:target_id/revisions", h.GetTargetRevisions)
+	missions.GET("/:id/targets/:target_id/revisions/diff", h.DiffTargetRevisions)
+	missions.GET("/:id/targets/:target_id/revisions/:revision_id", h.GetTargetRevision)
+
+	stats := api.Group("/stats", staff)
+	stats.GET("", h.GetStats)
+	stats.GET("/cats", h.GetCatStats)
+	stats.GET("/countries", h.GetCountryStats)
+	stats.GET("/idle-cats", h.GetIdleCats)
+
+	trash := api.Group("/trash", admin)
+	trash.GET("/cats", h.GetDeletedCats)
+	trash.GET("/missions", h.GetDeletedMissions)
+	trash.GET("/targets", h.GetDeletedTargets)
+	trash.POST("/cats/:id/restore", h.RestoreCat)
+	trash.POST("/missions/:id/restore", h.RestoreMission)
+	trash.POST("/targets/:id/restore", h.RestoreTarget)
+
+	me := api.Group("/me", middleware.RequireRole(auth.RoleFieldCat))
+	me.GET("", h.GetMe)
+	me.GET("/mission", h.GetMyMission)
+	me.GET("/missions", h.GetMyMissions)
+	me.PATCH("/mission/targets/:target_id", h.UpdateMyTarget)
+}
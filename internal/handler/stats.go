@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"net/http"
+	"spy-cat-agency/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetStats retrieves the agency overview
+// @Summary Get agency statistics
+// @Description Payroll over all cats, open and complete missions with their average number of targets and completion rate, and the number of idle cats. from and to (RFC3339, to exclusive) only count cats and missions whose date_field lies in the range.
+// @Tags Stats
+// @Produce json
+// @Param filter query models.StatsFilter false "Date range"
+// @Success 200 {object} models.StatsOverview
+// @Failure 400 {object} middleware.Problem
+// @Failure 401 {object} middleware.Problem
+// @Failure 403 {object} middleware.Problem
+// @Failure 500 {object} middleware.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /stats [get]
+func (h *Handler) GetStats(c *gin.Context) {
+	var filter models.StatsFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	stats, err := h.statsService.GetOverview(c.Request.Context(), filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// GetCatStats retrieves mission completion per cat
+// @Summary Get completion rate per cat
+// @Description Missions each cat was ever assigned to, including those it was reassigned off or unassigned from, and how many of them it completed itself, best performers first. The date range applies to the missions.
+// @Tags Stats
+// @Produce json
+// @Param filter query models.StatsFilter false "Date range"
+// @Success 200 {array} models.CatCompletionStats
+// @Failure 400 {object} middleware.Problem
+// @Failure 401 {object} middleware.Problem
+// @Failure 403 {object} middleware.Problem
+// @Failure 500 {object} middleware.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /stats/cats [get]
+func (h *Handler) GetCatStats(c *gin.Context) {
+	var filter models.StatsFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	stats, err := h.statsService.GetCatCompletion(c.Request.Context(), filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// GetCountryStats retrieves targets per country
+// @Summary Get targets per country
+// @Description Number of targets and completed targets per country, most targeted first. The date range applies to the targets.
+// @Tags Stats
+// @Produce json
+// @Param filter query models.StatsFilter false "Date range"
+// @Success 200 {array} models.CountryStats
+// @Failure 400 {object} middleware.Problem
+// @Failure 401 {object} middleware.Problem
+// @Failure 403 {object} middleware.Problem
+// @Failure 500 {object} middleware.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /stats/countries [get]
+func (h *Handler) GetCountryStats(c *gin.Context) {
+	var filter models.StatsFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	stats, err := h.statsService.GetTargetsByCountry(c.Request.Context(), filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// GetIdleCats retrieves cats without an active mission
+// @Summary Get idle cats
+// @Description Cats without an active mission and when their latest mission last changed. The date range applies to the cats.
+// @Tags Stats
+// @Produce json
+// @Param filter query models.StatsFilter false "Date range"
+// @Success 200 {array} models.IdleCat
+// @Failure 400 {object} middleware.Problem
+// @Failure 401 {object} middleware.Problem
+// @Failure 403 {object} middleware.Problem
+// @Failure 500 {object} middleware.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /stats/idle-cats [get]
+func (h *Handler) GetIdleCats(c *gin.Context) {
+	var filter models.StatsFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	cats, err := h.statsService.GetIdleCats(c.Request.Context(), filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, cats)
+}
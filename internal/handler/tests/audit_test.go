@@ -0,0 +1,58 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"spy-cat-agency/internal/auth"
+	"spy-cat-agency/internal/handler"
+	"spy-cat-agency/internal/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAuditService struct {
+	mock.Mock
+}
+
+func (m *MockAuditService) GetAll(ctx context.Context, filter models.AuditFilter) (*models.Page[models.AuditLog], error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Page[models.AuditLog]), args.Error(1)
+}
+
+func TestGetAuditLogs(t *testing.T) {
+	t.Run("page of entries", func(t *testing.T) {
+		auditService := new(MockAuditService)
+		r := newRouter(handler.Services{Audit: auditService})
+		entityID := uint(1)
+		from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		filter := models.AuditFilter{EntityType: models.AuditEntityCat, EntityID: &entityID, From: &from}
+		entries := []models.AuditLog{{ID: 1, Actor: "admin", Action: models.AuditActionCreate, EntityType: models.AuditEntityCat, EntityID: 1}}
+		auditService.On("GetAll", mock.Anything, mock.MatchedBy(func(f models.AuditFilter) bool {
+			return f.EntityType == filter.EntityType && *f.EntityID == entityID && f.From.Equal(from)
+		})).Return(models.NewPage(entries, filter.PageQuery, 1), nil)
+
+		w := serve(t, r, auth.RoleAdmin, http.MethodGet, "/audit?entity_type=cat&entity_id=1&from=2024-01-01T00:00:00Z", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		body := decode[map[string]any](t, w)
+		items := body["items"].([]any)
+		assert.Len(t, items, 1)
+		assert.Equal(t, "create", items[0].(map[string]any)["action"])
+		auditService.AssertExpectations(t)
+	})
+
+	t.Run("unknown entity type", func(t *testing.T) {
+		r := newRouter(handler.Services{Audit: new(MockAuditService)})
+
+		w := serve(t, r, auth.RoleAdmin, http.MethodGet, "/audit?entity_type=dog", "")
+
+		problem := assertProblem(t, w, http.StatusBadRequest, "VALIDATION_FAILED")
+		assert.Equal(t, "entity_type must be one of cat, mission, target", problem["detail"])
+	})
+}
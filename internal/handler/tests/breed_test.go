@@ -0,0 +1,51 @@
+package tests
+
+import (
+	"errors"
+	"net/http"
+	"spy-cat-agency/internal/auth"
+	"spy-cat-agency/internal/handler"
+	"spy-cat-agency/pkg/catapi"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockBreedService struct {
+	mock.Mock
+}
+
+func (m *MockBreedService) GetAll() ([]catapi.CatAPIBreed, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]catapi.CatAPIBreed), args.Error(1)
+}
+
+func TestGetBreeds(t *testing.T) {
+	t.Run("open to field cats", func(t *testing.T) {
+		breedService := new(MockBreedService)
+		r := newRouter(handler.Services{Breed: breedService})
+		breedService.On("GetAll").Return([]catapi.CatAPIBreed{{ID: "siam", Name: "Siamese"}}, nil)
+
+		w := serve(t, r, auth.RoleFieldCat, http.MethodGet, "/breeds", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		body := decode[[]map[string]any](t, w)
+		require.Len(t, body, 1)
+		assert.Equal(t, map[string]any{"id": "siam", "name": "Siamese"}, body[0])
+	})
+
+	t.Run("catalog unavailable", func(t *testing.T) {
+		breedService := new(MockBreedService)
+		r := newRouter(handler.Services{Breed: breedService})
+		breedService.On("GetAll").Return(nil, errors.New("catapi: 503"))
+
+		w := serve(t, r, auth.RoleHandler, http.MethodGet, "/breeds", "")
+
+		assertProblem(t, w, http.StatusInternalServerError, "INTERNAL")
+	})
+}
@@ -0,0 +1,319 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"spy-cat-agency/internal/auth"
+	"spy-cat-agency/internal/handler"
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/pkg/custerr"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockCatService struct {
+	mock.Mock
+}
+
+func (m *MockCatService) Create(ctx context.Context, dto *models.CreateCatDTO) (*models.Cat, error) {
+	args := m.Called(ctx, dto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Cat), args.Error(1)
+}
+
+func (m *MockCatService) GetAll(ctx context.Context, filter models.CatFilter) (*models.Page[models.Cat], error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Page[models.Cat]), args.Error(1)
+}
+
+func (m *MockCatService) GetByID(ctx context.Context, id uint) (*models.Cat, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Cat), args.Error(1)
+}
+
+func (m *MockCatService) Update(ctx context.Context, id uint, dto models.UpdateCatDTO) (*models.Cat, error) {
+	args := m.Called(ctx, id, dto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Cat), args.Error(1)
+}
+
+func (m *MockCatService) Delete(ctx context.Context, id uint, force bool) error {
+	args := m.Called(ctx, id, force)
+	return args.Error(0)
+}
+
+func (m *MockCatService) GetSalaries(ctx context.Context, id uint) ([]models.CatSalary, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.CatSalary), args.Error(1)
+}
+
+func newCat() *models.Cat {
+	return &models.Cat{
+		ID:           1,
+		CreateCatDTO: models.CreateCatDTO{Name: "Whiskers", YearsExperience: 5, Breed: "Siamese", Salary: 5000},
+		BreedID:      "siam",
+	}
+}
+
+func TestCreateCat(t *testing.T) {
+	t.Run("created", func(t *testing.T) {
+		catService := new(MockCatService)
+		r := newRouter(handler.Services{Cat: catService})
+		dto := &models.CreateCatDTO{Name: "Whiskers", YearsExperience: 5, Breed: "siam", Salary: 5000}
+		catService.On("Create", mock.Anything, dto).Return(newCat(), nil)
+
+		w := serve(t, r, auth.RoleHandler, http.MethodPost, "/cats", `{"name": "Whiskers", "years_experience": 5, "breed": "siam", "salary": 5000}`)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		body := decode[map[string]any](t, w)
+		assert.Equal(t, float64(1), body["id"])
+		assert.Equal(t, "Whiskers", body["name"])
+		assert.Equal(t, "Siamese", body["breed"])
+		assert.Equal(t, "siam", body["breed_id"])
+		assert.NotContains(t, body, "mission")
+		catService.AssertExpectations(t)
+	})
+
+	t.Run("validation failed", func(t *testing.T) {
+		catService := new(MockCatService)
+		r := newRouter(handler.Services{Cat: catService})
+
+		w := serve(t, r, auth.RoleHandler, http.MethodPost, "/cats", `{"name": "Whiskers", "salary": -1}`)
+
+		problem := assertProblem(t, w, http.StatusBadRequest, "VALIDATION_FAILED")
+		assert.Len(t, problem["errors"], 3)
+		catService.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("invalid breed with suggestions", func(t *testing.T) {
+		catService := new(MockCatService)
+		r := newRouter(handler.Services{Cat: catService})
+		catService.On("Create", mock.Anything, mock.Anything).Return(nil,
+			custerr.NewBadRequestErrWithDetails("invalid cat breed", map[string]any{"suggestions": []string{"Siamese"}}).WithCode(models.ErrCodeInvalidBreed))
+
+		w := serve(t, r, auth.RoleHandler, http.MethodPost, "/cats", `{"name": "Whiskers", "years_experience": 5, "breed": "Siamse", "salary": 5000}`)
+
+		problem := assertProblem(t, w, http.StatusBadRequest, "INVALID_CAT_BREED")
+		assert.Equal(t, []any{"Siamese"}, problem["suggestions"])
+	})
+}
+
+func TestGetCats(t *testing.T) {
+	t.Run("page of cats", func(t *testing.T) {
+		catService := new(MockCatService)
+		r := newRouter(handler.Services{Cat: catService})
+		minSalary := 1000.0
+		filter := models.CatFilter{PageQuery: models.PageQuery{Page: 2, PerPage: 1, Sort: "-salary"}, Breed: "siam", MinSalary: &minSalary}
+		catService.On("GetAll", mock.Anything, filter).Return(models.NewPage([]models.Cat{*newCat()}, filter.PageQuery, 3), nil)
+
+		w := serve(t, r, auth.RoleHandler, http.MethodGet, "/cats?page=2&per_page=1&sort=-salary&breed=siam&min_salary=1000", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		body := decode[map[string]any](t, w)
+		assert.Len(t, body["items"], 1)
+		assert.Equal(t, map[string]any{"page": float64(2), "per_page": float64(1), "total": float64(3), "total_pages": float64(3)}, body["meta"])
+		catService.AssertExpectations(t)
+	})
+
+	t.Run("invalid page size", func(t *testing.T) {
+		r := newRouter(handler.Services{Cat: new(MockCatService)})
+
+		w := serve(t, r, auth.RoleHandler, http.MethodGet, "/cats?per_page=500", "")
+
+		problem := assertProblem(t, w, http.StatusBadRequest, "VALIDATION_FAILED")
+		assert.Equal(t, "per_page must be at most 100", problem["detail"])
+	})
+
+	t.Run("invalid sort", func(t *testing.T) {
+		catService := new(MockCatService)
+		r := newRouter(handler.Services{Cat: catService})
+		catService.On("GetAll", mock.Anything, mock.Anything).Return(nil, custerr.NewBadRequestErr("cannot sort by \"password\"").WithCode(models.ErrCodeInvalidSort))
+
+		w := serve(t, r, auth.RoleHandler, http.MethodGet, "/cats?sort=password", "")
+
+		assertProblem(t, w, http.StatusBadRequest, "INVALID_SORT")
+	})
+}
+
+func TestGetCat(t *testing.T) {
+	t.Run("found", func(t *testing.T) {
+		catService := new(MockCatService)
+		r := newRouter(handler.Services{Cat: catService})
+		cat := newCat()
+		cat.Mission = &models.Mission{ID: 2, CatID: &cat.ID, Targets: []models.Target{{ID: 4, MissionID: 2, Name: "Target Alpha"}}}
+		catService.On("GetByID", mock.Anything, uint(1)).Return(cat, nil)
+
+		w := serve(t, r, auth.RoleHandler, http.MethodGet, "/cats/1", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		body := decode[map[string]any](t, w)
+		mission := body["mission"].(map[string]any)
+		assert.Equal(t, float64(2), mission["id"])
+		assert.Len(t, mission["targets"], 1)
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		catService := new(MockCatService)
+		r := newRouter(handler.Services{Cat: catService})
+
+		w := serve(t, r, auth.RoleHandler, http.MethodGet, "/cats/abc", "")
+
+		problem := assertProblem(t, w, http.StatusBadRequest, "INVALID_ID")
+		assert.Equal(t, "invalid id", problem["detail"])
+		catService.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		catService := new(MockCatService)
+		r := newRouter(handler.Services{Cat: catService})
+		catService.On("GetByID", mock.Anything, uint(9)).Return(nil, custerr.NewNotFoundErr("no cat with id \"9\""))
+
+		w := serve(t, r, auth.RoleHandler, http.MethodGet, "/cats/9", "")
+
+		problem := assertProblem(t, w, http.StatusNotFound, "NOT_FOUND")
+		assert.Equal(t, "no cat with id \"9\"", problem["detail"])
+		assert.Equal(t, "/api/v1/cats/9", problem["instance"])
+	})
+
+	t.Run("unexpected error is hidden", func(t *testing.T) {
+		catService := new(MockCatService)
+		r := newRouter(handler.Services{Cat: catService})
+		catService.On("GetByID", mock.Anything, uint(1)).Return(nil, errors.New("connection refused"))
+
+		w := serve(t, r, auth.RoleHandler, http.MethodGet, "/cats/1", "")
+
+		problem := assertProblem(t, w, http.StatusInternalServerError, "INTERNAL")
+		assert.Equal(t, "internal server error", problem["detail"])
+	})
+}
+
+func TestGetCatSalaries(t *testing.T) {
+	t.Run("salary history", func(t *testing.T) {
+		catService := new(MockCatService)
+		r := newRouter(handler.Services{Cat: catService})
+		effectiveFrom := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		catService.On("GetSalaries", mock.Anything, uint(1)).Return([]models.CatSalary{{ID: 1, CatID: 1, Salary: 5000, EffectiveFrom: effectiveFrom, Actor: "admin"}}, nil)
+
+		w := serve(t, r, auth.RoleHandler, http.MethodGet, "/cats/1/salaries", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		body := decode[[]map[string]any](t, w)
+		require.Len(t, body, 1)
+		assert.Equal(t, float64(5000), body[0]["salary"])
+		assert.Equal(t, "2024-01-01T00:00:00Z", body[0]["effective_from"])
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		r := newRouter(handler.Services{Cat: new(MockCatService)})
+
+		w := serve(t, r, auth.RoleHandler, http.MethodGet, "/cats/-1/salaries", "")
+
+		assertProblem(t, w, http.StatusBadRequest, "INVALID_ID")
+	})
+}
+
+func TestUpdateCat(t *testing.T) {
+	t.Run("updated", func(t *testing.T) {
+		catService := new(MockCatService)
+		r := newRouter(handler.Services{Cat: catService})
+		effectiveFrom := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+		cat := newCat()
+		cat.Salary = 6000
+		catService.On("Update", mock.Anything, uint(1), models.UpdateCatDTO{Salary: 6000, EffectiveFrom: &effectiveFrom}).Return(cat, nil)
+
+		w := serve(t, r, auth.RoleAdmin, http.MethodPatch, "/cats/1", `{"salary": 6000, "effective_from": "2024-03-01T00:00:00Z"}`)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, float64(6000), decode[map[string]any](t, w)["salary"])
+		catService.AssertExpectations(t)
+	})
+
+	t.Run("malformed body", func(t *testing.T) {
+		r := newRouter(handler.Services{Cat: new(MockCatService)})
+
+		w := serve(t, r, auth.RoleAdmin, http.MethodPatch, "/cats/1", `{"salary": `)
+
+		assertProblem(t, w, http.StatusBadRequest, "MALFORMED_REQUEST")
+	})
+
+	t.Run("wrong type", func(t *testing.T) {
+		r := newRouter(handler.Services{Cat: new(MockCatService)})
+
+		w := serve(t, r, auth.RoleAdmin, http.MethodPatch, "/cats/1", `{"salary": "lots"}`)
+
+		problem := assertProblem(t, w, http.StatusBadRequest, "VALIDATION_FAILED")
+		assert.Equal(t, "salary must be a float64", problem["detail"])
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		r := newRouter(handler.Services{Cat: new(MockCatService)})
+
+		w := serve(t, r, auth.RoleAdmin, http.MethodPatch, "/cats/x", `{"salary": 6000}`)
+
+		assertProblem(t, w, http.StatusBadRequest, "INVALID_ID")
+	})
+}
+
+func TestDeleteCat(t *testing.T) {
+	t.Run("no content", func(t *testing.T) {
+		catService := new(MockCatService)
+		r := newRouter(handler.Services{Cat: catService})
+		catService.On("Delete", mock.Anything, uint(1), false).Return(nil)
+
+		w := serve(t, r, auth.RoleAdmin, http.MethodDelete, "/cats/1", "")
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.Empty(t, w.Body.String())
+		catService.AssertExpectations(t)
+	})
+
+	t.Run("forced", func(t *testing.T) {
+		catService := new(MockCatService)
+		r := newRouter(handler.Services{Cat: catService})
+		catService.On("Delete", mock.Anything, uint(1), true).Return(nil)
+
+		w := serve(t, r, auth.RoleAdmin, http.MethodDelete, "/cats/1?force=true", "")
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		catService.AssertExpectations(t)
+	})
+
+	t.Run("invalid force flag", func(t *testing.T) {
+		r := newRouter(handler.Services{Cat: new(MockCatService)})
+
+		w := serve(t, r, auth.RoleAdmin, http.MethodDelete, "/cats/1?force=maybe", "")
+
+		problem := assertProblem(t, w, http.StatusBadRequest, "VALIDATION_FAILED")
+		assert.Equal(t, "invalid force flag", problem["detail"])
+	})
+
+	t.Run("cat on active mission", func(t *testing.T) {
+		catService := new(MockCatService)
+		r := newRouter(handler.Services{Cat: catService})
+		catService.On("Delete", mock.Anything, uint(1), false).Return(
+			custerr.NewConflictErr("cat is on an active mission").WithCode(models.ErrCodeCatOnActiveMission).WithMeta("mission_id", 2))
+
+		w := serve(t, r, auth.RoleAdmin, http.MethodDelete, "/cats/1", "")
+
+		problem := assertProblem(t, w, http.StatusConflict, "CAT_ON_ACTIVE_MISSION")
+		assert.Equal(t, float64(2), problem["mission_id"])
+	})
+}
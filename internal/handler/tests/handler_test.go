@@ -0,0 +1,132 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"spy-cat-agency/config"
+	"spy-cat-agency/internal/auth"
+	"spy-cat-agency/internal/handler"
+	"spy-cat-agency/internal/middleware"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fieldCatID is the cat behind requests made as a field cat.
+const fieldCatID = uint(3)
+
+var authenticator *auth.Authenticator
+
+func init() {
+	gin.SetMode(gin.TestMode)
+
+	var err error
+	authenticator, err = auth.NewAuthenticator(&config.Config{JWTSecret: "test-secret"})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// newRouter serves the API the way the application does, with services
+// replaced by the given fakes.
+func newRouter(services handler.Services) *gin.Engine {
+	r := gin.New()
+	r.Use(middleware.RequestID(), middleware.ErrorHandler())
+
+	api := r.Group("/api/v1")
+	api.Use(middleware.Auth(authenticator))
+	handler.NewWithServices(services).Routes(api)
+	return r
+}
+
+// serve sends a JSON request authenticated as a principal with role.
+func serve(t *testing.T, r *gin.Engine, role auth.Role, method, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(method, "/api/v1"+path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if role != "" {
+		req.Header.Set("Authorization", "Bearer "+token(t, role))
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func token(t *testing.T, role auth.Role) string {
+	t.Helper()
+
+	principal := auth.Principal{Subject: string(role), Role: role}
+	if role == auth.RoleFieldCat {
+		catID := fieldCatID
+		principal.CatID = &catID
+	}
+	token, err := authenticator.IssueToken(principal, time.Hour)
+	require.NoError(t, err)
+	return token
+}
+
+// decode unmarshals the response body into a generic JSON value.
+func decode[T any](t *testing.T, w *httptest.ResponseRecorder) T {
+	t.Helper()
+
+	var body T
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body), w.Body.String())
+	return body
+}
+
+// assertProblem checks that w is a problem+json response with status and
+// code, and returns its members.
+func assertProblem(t *testing.T, w *httptest.ResponseRecorder, status int, code string) map[string]any {
+	t.Helper()
+
+	assert.Equal(t, status, w.Code, w.Body.String())
+	assert.Equal(t, middleware.ProblemContentType, w.Header().Get("Content-Type"))
+	problem := decode[map[string]any](t, w)
+	assert.Equal(t, code, problem["code"])
+	assert.Equal(t, w.Header().Get(middleware.RequestIDHeader), problem["request_id"])
+	return problem
+}
+
+func TestAccess(t *testing.T) {
+	r := newRouter(handler.Services{})
+
+	tests := []struct {
+		name   string
+		role   auth.Role
+		method string
+		path   string
+		status int
+		code   string
+	}{
+		{"missing credentials", "", http.MethodGet, "/cats", http.StatusUnauthorized, "MISSING_CREDENTIALS"},
+		{"field cat on staff route", auth.RoleFieldCat, http.MethodGet, "/missions", http.StatusForbidden, "FORBIDDEN"},
+		{"handler on admin route", auth.RoleHandler, http.MethodDelete, "/cats/1", http.StatusForbidden, "FORBIDDEN"},
+		{"handler on trash", auth.RoleHandler, http.MethodGet, "/trash/cats", http.StatusForbidden, "FORBIDDEN"},
+		{"handler on payroll", auth.RoleHandler, http.MethodGet, "/payroll", http.StatusForbidden, "FORBIDDEN"},
+		{"admin on me", auth.RoleAdmin, http.MethodGet, "/me", http.StatusForbidden, "FORBIDDEN"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(t, r, tt.role, tt.method, tt.path, "")
+
+			assertProblem(t, w, tt.status, tt.code)
+		})
+	}
+
+	t.Run("invalid token", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/cats", nil)
+		req.Header.Set("Authorization", "Bearer not-a-token")
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+
+		assertProblem(t, w, http.StatusUnauthorized, "INVALID_CREDENTIALS")
+		assert.Equal(t, "Bearer", w.Header().Get("WWW-Authenticate"))
+	})
+}
@@ -0,0 +1,148 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"spy-cat-agency/internal/auth"
+	"spy-cat-agency/internal/handler"
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/pkg/custerr"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockMeService struct {
+	mock.Mock
+}
+
+func (m *MockMeService) GetProfile(ctx context.Context) (*models.CatProfile, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CatProfile), args.Error(1)
+}
+
+func (m *MockMeService) GetActiveMission(ctx context.Context) (*models.Mission, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Mission), args.Error(1)
+}
+
+func (m *MockMeService) GetMissions(ctx context.Context, filter models.CatMissionFilter) (*models.Page[models.Mission], error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Page[models.Mission]), args.Error(1)
+}
+
+func (m *MockMeService) UpdateTarget(ctx context.Context, targetID uint, dto models.UpdateTargetDTO) (*models.Mission, error) {
+	args := m.Called(ctx, targetID, dto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Mission), args.Error(1)
+}
+
+// asFieldCat matches a context carrying the field cat making requests.
+var asFieldCat = mock.MatchedBy(func(ctx context.Context) bool {
+	principal, ok := auth.FromContext(ctx)
+	return ok && principal.CatID != nil && *principal.CatID == fieldCatID
+})
+
+func TestGetMe(t *testing.T) {
+	meService := new(MockMeService)
+	r := newRouter(handler.Services{Me: meService})
+	meService.On("GetProfile", asFieldCat).Return(&models.CatProfile{ID: fieldCatID, Name: "Whiskers", YearsExperience: 5, Breed: "Siamese", BreedID: "siam"}, nil)
+
+	w := serve(t, r, auth.RoleFieldCat, http.MethodGet, "/me", "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	body := decode[map[string]any](t, w)
+	assert.Equal(t, "Whiskers", body["name"])
+	assert.NotContains(t, body, "salary")
+	meService.AssertExpectations(t)
+}
+
+func TestGetMyMission(t *testing.T) {
+	t.Run("active mission", func(t *testing.T) {
+		meService := new(MockMeService)
+		r := newRouter(handler.Services{Me: meService})
+		meService.On("GetActiveMission", asFieldCat).Return(newMission(), nil)
+
+		w := serve(t, r, auth.RoleFieldCat, http.MethodGet, "/me/mission", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, float64(1), decode[map[string]any](t, w)["id"])
+	})
+
+	t.Run("no active mission", func(t *testing.T) {
+		meService := new(MockMeService)
+		r := newRouter(handler.Services{Me: meService})
+		meService.On("GetActiveMission", asFieldCat).Return(nil,
+			custerr.NewNotFoundErr("no active mission").WithCode(models.ErrCodeNoActiveMission))
+
+		w := serve(t, r, auth.RoleFieldCat, http.MethodGet, "/me/mission", "")
+
+		assertProblem(t, w, http.StatusNotFound, "NO_ACTIVE_MISSION")
+	})
+}
+
+func TestGetMyMissions(t *testing.T) {
+	meService := new(MockMeService)
+	r := newRouter(handler.Services{Me: meService})
+	complete := true
+	filter := models.CatMissionFilter{Complete: &complete}
+	meService.On("GetMissions", asFieldCat, filter).Return(models.NewPage([]models.Mission{*newMission()}, filter.PageQuery, 1), nil)
+
+	w := serve(t, r, auth.RoleFieldCat, http.MethodGet, "/me/missions?complete=true", "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Len(t, decode[map[string]any](t, w)["items"], 1)
+	meService.AssertExpectations(t)
+}
+
+func TestUpdateMyTarget(t *testing.T) {
+	t.Run("notes", func(t *testing.T) {
+		meService := new(MockMeService)
+		r := newRouter(handler.Services{Me: meService})
+		notes := "Seen at the docks"
+		meService.On("UpdateTarget", asFieldCat, uint(4), models.UpdateTargetDTO{Notes: &notes}).Return(newMission(), nil)
+
+		w := serve(t, r, auth.RoleFieldCat, http.MethodPatch, "/me/mission/targets/4", `{"notes":"Seen at the docks"}`)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		meService.AssertExpectations(t)
+	})
+
+	t.Run("target of another mission", func(t *testing.T) {
+		meService := new(MockMeService)
+		r := newRouter(handler.Services{Me: meService})
+		meService.On("UpdateTarget", asFieldCat, uint(9), mock.Anything).Return(nil, custerr.NewNotFoundErr("target not found"))
+
+		w := serve(t, r, auth.RoleFieldCat, http.MethodPatch, "/me/mission/targets/9", `{"complete":true}`)
+
+		assertProblem(t, w, http.StatusNotFound, "NOT_FOUND")
+	})
+
+	t.Run("unknown status", func(t *testing.T) {
+		r := newRouter(handler.Services{Me: new(MockMeService)})
+
+		w := serve(t, r, auth.RoleFieldCat, http.MethodPatch, "/me/mission/targets/4", `{"status":"asleep"}`)
+
+		assertProblem(t, w, http.StatusBadRequest, "VALIDATION_FAILED")
+	})
+
+	t.Run("invalid target id", func(t *testing.T) {
+		r := newRouter(handler.Services{Me: new(MockMeService)})
+
+		w := serve(t, r, auth.RoleFieldCat, http.MethodPatch, "/me/mission/targets/x", `{}`)
+
+		assertProblem(t, w, http.StatusBadRequest, "INVALID_ID")
+	})
+}
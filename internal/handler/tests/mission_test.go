@@ -0,0 +1,583 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"spy-cat-agency/internal/auth"
+	"spy-cat-agency/internal/handler"
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/pkg/custerr"
+	"spy-cat-agency/pkg/textdiff"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockMissionService struct {
+	mock.Mock
+}
+
+func (m *MockMissionService) Create(ctx context.Context, dto models.CreateMissionDTO) (*models.Mission, error) {
+	args := m.Called(ctx, dto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Mission), args.Error(1)
+}
+
+func (m *MockMissionService) GetAll(ctx context.Context, filter models.MissionFilter) (*models.Page[models.Mission], error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Page[models.Mission]), args.Error(1)
+}
+
+func (m *MockMissionService) GetByID(ctx context.Context, id uint) (*models.Mission, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Mission), args.Error(1)
+}
+
+func (m *MockMissionService) Update(ctx context.Context, id uint, dto models.UpdateMissionDTO) (*models.Mission, error) {
+	args := m.Called(ctx, id, dto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Mission), args.Error(1)
+}
+
+func (m *MockMissionService) Delete(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockMissionService) AssignCat(ctx context.Context, missionID, catID uint) (*models.Mission, error) {
+	args := m.Called(ctx, missionID, catID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Mission), args.Error(1)
+}
+
+func (m *MockMissionService) UnassignCat(ctx context.Context, missionID uint, dto models.MissionAssignmentDTO) (*models.Mission, error) {
+	args := m.Called(ctx, missionID, dto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Mission), args.Error(1)
+}
+
+func (m *MockMissionService) ReassignCat(ctx context.Context, missionID, catID uint, dto models.MissionAssignmentDTO) (*models.Mission, error) {
+	args := m.Called(ctx, missionID, catID, dto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Mission), args.Error(1)
+}
+
+func (m *MockMissionService) GetAssignments(ctx context.Context, missionID uint) ([]models.MissionAssignment, error) {
+	args := m.Called(ctx, missionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.MissionAssignment), args.Error(1)
+}
+
+func (m *MockMissionService) CreateTarget(ctx context.Context, missionID uint, dto models.CreateTargetDTO) (*models.Mission, error) {
+	args := m.Called(ctx, missionID, dto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Mission), args.Error(1)
+}
+
+func (m *MockMissionService) UpdateTarget(ctx context.Context, missionID, targetID uint, dto models.UpdateTargetDTO) (*models.Mission, error) {
+	args := m.Called(ctx, missionID, targetID, dto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Mission), args.Error(1)
+}
+
+func (m *MockMissionService) DeleteTarget(ctx context.Context, missionID, targetID uint) (*models.Mission, error) {
+	args := m.Called(ctx, missionID, targetID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Mission), args.Error(1)
+}
+
+func (m *MockMissionService) GetTargetRevisions(ctx context.Context, missionID, targetID uint) ([]models.TargetNoteRevision, error) {
+	args := m.Called(ctx, missionID, targetID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.TargetNoteRevision), args.Error(1)
+}
+
+func (m *MockMissionService) GetTargetRevision(ctx context.Context, missionID, targetID, revisionID uint) (*models.TargetNoteRevision, error) {
+	args := m.Called(ctx, missionID, targetID, revisionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TargetNoteRevision), args.Error(1)
+}
+
+func (m *MockMissionService) DiffTargetRevisions(ctx context.Context, missionID, targetID, fromID, toID uint) (*models.TargetNotesDiff, error) {
+	args := m.Called(ctx, missionID, targetID, fromID, toID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TargetNotesDiff), args.Error(1)
+}
+
+func newMission() *models.Mission {
+	catID := uint(1)
+	return &models.Mission{
+		ID:    1,
+		CatID: &catID,
+		Targets: []models.Target{
+			{ID: 1, MissionID: 1, Name: "Target Alpha", Country: "France", Status: models.TargetStatusPending},
+		},
+	}
+}
+
+func TestCreateMission(t *testing.T) {
+	t.Run("created", func(t *testing.T) {
+		missionService := new(MockMissionService)
+		r := newRouter(handler.Services{Mission: missionService})
+		dto := models.CreateMissionDTO{Targets: []models.CreateTargetDTO{{Name: "Target Alpha", Country: "France"}}}
+		missionService.On("Create", mock.Anything, dto).Return(newMission(), nil)
+
+		w := serve(t, r, auth.RoleHandler, http.MethodPost, "/missions", `{"targets": [{"name": "Target Alpha", "country": "France"}]}`)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		body := decode[map[string]any](t, w)
+		assert.Equal(t, float64(1), body["cat_id"])
+		assert.Equal(t, false, body["complete"])
+		targets := body["targets"].([]any)
+		require.Len(t, targets, 1)
+		assert.Equal(t, "pending", targets[0].(map[string]any)["status"])
+		missionService.AssertExpectations(t)
+	})
+
+	t.Run("too many targets", func(t *testing.T) {
+		missionService := new(MockMissionService)
+		r := newRouter(handler.Services{Mission: missionService})
+		target := `{"name": "Target", "country": "France"}`
+
+		w := serve(t, r, auth.RoleHandler, http.MethodPost, "/missions", `{"targets": [`+target+`,`+target+`,`+target+`,`+target+`]}`)
+
+		problem := assertProblem(t, w, http.StatusBadRequest, "VALIDATION_FAILED")
+		assert.Equal(t, "targets must have at most 3 items", problem["detail"])
+		missionService.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestGetMissions(t *testing.T) {
+	t.Run("page of missions", func(t *testing.T) {
+		missionService := new(MockMissionService)
+		r := newRouter(handler.Services{Mission: missionService})
+		complete := false
+		filter := models.MissionFilter{Complete: &complete, Country: "France"}
+		missionService.On("GetAll", mock.Anything, filter).Return(models.NewPage([]models.Mission{*newMission()}, filter.PageQuery, 1), nil)
+
+		w := serve(t, r, auth.RoleHandler, http.MethodGet, "/missions?complete=false&country=France", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		body := decode[map[string]any](t, w)
+		assert.Len(t, body["items"], 1)
+		assert.Equal(t, float64(1), body["meta"].(map[string]any)["total"])
+		missionService.AssertExpectations(t)
+	})
+
+	t.Run("invalid filter", func(t *testing.T) {
+		r := newRouter(handler.Services{Mission: new(MockMissionService)})
+
+		w := serve(t, r, auth.RoleHandler, http.MethodGet, "/missions?cat_id=abc", "")
+
+		assertProblem(t, w, http.StatusBadRequest, "MALFORMED_REQUEST")
+	})
+}
+
+func TestGetMission(t *testing.T) {
+	t.Run("found", func(t *testing.T) {
+		missionService := new(MockMissionService)
+		r := newRouter(handler.Services{Mission: missionService})
+		missionService.On("GetByID", mock.Anything, uint(1)).Return(newMission(), nil)
+
+		w := serve(t, r, auth.RoleHandler, http.MethodGet, "/missions/1", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, float64(1), decode[map[string]any](t, w)["id"])
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		r := newRouter(handler.Services{Mission: new(MockMissionService)})
+
+		w := serve(t, r, auth.RoleHandler, http.MethodGet, "/missions/abc", "")
+
+		assertProblem(t, w, http.StatusBadRequest, "INVALID_ID")
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		missionService := new(MockMissionService)
+		r := newRouter(handler.Services{Mission: missionService})
+		missionService.On("GetByID", mock.Anything, uint(9)).Return(nil, custerr.NewNotFoundErr("no mission with id \"9\""))
+
+		w := serve(t, r, auth.RoleHandler, http.MethodGet, "/missions/9", "")
+
+		assertProblem(t, w, http.StatusNotFound, "NOT_FOUND")
+	})
+}
+
+func TestUpdateMission(t *testing.T) {
+	t.Run("completed", func(t *testing.T) {
+		missionService := new(MockMissionService)
+		r := newRouter(handler.Services{Mission: missionService})
+		complete := true
+		mission := newMission()
+		mission.Complete = true
+		missionService.On("Update", mock.Anything, uint(1), models.UpdateMissionDTO{Complete: &complete, Force: true}).Return(mission, nil)
+
+		w := serve(t, r, auth.RoleHandler, http.MethodPatch, "/missions/1", `{"complete": true, "force": true}`)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, true, decode[map[string]any](t, w)["complete"])
+		missionService.AssertExpectations(t)
+	})
+
+	t.Run("invalid id goes through the error handler", func(t *testing.T) {
+		r := newRouter(handler.Services{Mission: new(MockMissionService)})
+
+		w := serve(t, r, auth.RoleHandler, http.MethodPatch, "/missions/abc", `{"complete": true}`)
+
+		problem := assertProblem(t, w, http.StatusBadRequest, "INVALID_ID")
+		assert.Equal(t, "invalid id", problem["detail"])
+	})
+
+	t.Run("open targets", func(t *testing.T) {
+		missionService := new(MockMissionService)
+		r := newRouter(handler.Services{Mission: missionService})
+		missionService.On("Update", mock.Anything, uint(1), mock.Anything).Return(nil,
+			custerr.NewConflictErr("mission has targets that are not finished").WithCode(models.ErrCodeMissionTargetsOpen))
+
+		w := serve(t, r, auth.RoleHandler, http.MethodPatch, "/missions/1", `{"complete": true}`)
+
+		assertProblem(t, w, http.StatusConflict, "MISSION_TARGETS_OPEN")
+	})
+}
+
+func TestDeleteMission(t *testing.T) {
+	t.Run("ok without body", func(t *testing.T) {
+		missionService := new(MockMissionService)
+		r := newRouter(handler.Services{Mission: missionService})
+		missionService.On("Delete", mock.Anything, uint(1)).Return(nil)
+
+		w := serve(t, r, auth.RoleHandler, http.MethodDelete, "/missions/1", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Body.String())
+		missionService.AssertExpectations(t)
+	})
+
+	t.Run("assigned mission", func(t *testing.T) {
+		missionService := new(MockMissionService)
+		r := newRouter(handler.Services{Mission: missionService})
+		missionService.On("Delete", mock.Anything, uint(1)).Return(
+			custerr.NewConflictErr("cannot delete mission assigned to a cat").WithCode(models.ErrCodeMissionAssigned))
+
+		w := serve(t, r, auth.RoleHandler, http.MethodDelete, "/missions/1", "")
+
+		assertProblem(t, w, http.StatusConflict, "MISSION_ASSIGNED")
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		r := newRouter(handler.Services{Mission: new(MockMissionService)})
+
+		w := serve(t, r, auth.RoleHandler, http.MethodDelete, "/missions/0x1", "")
+
+		assertProblem(t, w, http.StatusBadRequest, "INVALID_ID")
+	})
+}
+
+func TestAssignCatToMission(t *testing.T) {
+	t.Run("assigned", func(t *testing.T) {
+		missionService := new(MockMissionService)
+		r := newRouter(handler.Services{Mission: missionService})
+		missionService.On("AssignCat", mock.Anything, uint(1), uint(2)).Return(newMission(), nil)
+
+		w := serve(t, r, auth.RoleHandler, http.MethodPatch, "/missions/1/assign/2", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		missionService.AssertExpectations(t)
+	})
+
+	t.Run("invalid cat id", func(t *testing.T) {
+		r := newRouter(handler.Services{Mission: new(MockMissionService)})
+
+		w := serve(t, r, auth.RoleHandler, http.MethodPatch, "/missions/1/assign/tom", "")
+
+		problem := assertProblem(t, w, http.StatusBadRequest, "INVALID_ID")
+		assert.Equal(t, "invalid cat id", problem["detail"])
+	})
+
+	t.Run("cat already on a mission", func(t *testing.T) {
+		missionService := new(MockMissionService)
+		r := newRouter(handler.Services{Mission: missionService})
+		missionService.On("AssignCat", mock.Anything, uint(1), uint(2)).Return(nil,
+			custerr.NewConflictErr("cat already has an active mission").WithCode(models.ErrCodeCatAlreadyOnMission))
+
+		w := serve(t, r, auth.RoleHandler, http.MethodPatch, "/missions/1/assign/2", "")
+
+		assertProblem(t, w, http.StatusConflict, "CAT_ALREADY_ON_MISSION")
+	})
+}
+
+func TestUnassignCatFromMission(t *testing.T) {
+	t.Run("unassigned", func(t *testing.T) {
+		missionService := new(MockMissionService)
+		r := newRouter(handler.Services{Mission: missionService})
+		mission := newMission()
+		mission.CatID = nil
+		missionService.On("UnassignCat", mock.Anything, uint(1), models.MissionAssignmentDTO{Reason: "injured"}).Return(mission, nil)
+
+		w := serve(t, r, auth.RoleHandler, http.MethodPatch, "/missions/1/unassign", `{"reason": "injured"}`)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Nil(t, decode[map[string]any](t, w)["cat_id"])
+		missionService.AssertExpectations(t)
+	})
+
+	t.Run("reason required", func(t *testing.T) {
+		r := newRouter(handler.Services{Mission: new(MockMissionService)})
+
+		w := serve(t, r, auth.RoleHandler, http.MethodPatch, "/missions/1/unassign", `{}`)
+
+		problem := assertProblem(t, w, http.StatusBadRequest, "VALIDATION_FAILED")
+		assert.Equal(t, "reason is required", problem["detail"])
+	})
+}
+
+func TestReassignMission(t *testing.T) {
+	t.Run("reassigned", func(t *testing.T) {
+		missionService := new(MockMissionService)
+		r := newRouter(handler.Services{Mission: missionService})
+		missionService.On("ReassignCat", mock.Anything, uint(1), uint(2), models.MissionAssignmentDTO{Reason: "rotation"}).Return(newMission(), nil)
+
+		w := serve(t, r, auth.RoleHandler, http.MethodPatch, "/missions/1/reassign/2", `{"reason": "rotation"}`)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		missionService.AssertExpectations(t)
+	})
+
+	t.Run("invalid mission id", func(t *testing.T) {
+		r := newRouter(handler.Services{Mission: new(MockMissionService)})
+
+		w := serve(t, r, auth.RoleHandler, http.MethodPatch, "/missions/x/reassign/2", `{"reason": "rotation"}`)
+
+		problem := assertProblem(t, w, http.StatusBadRequest, "INVALID_ID")
+		assert.Equal(t, "invalid mission id", problem["detail"])
+	})
+
+	t.Run("unassigned mission", func(t *testing.T) {
+		missionService := new(MockMissionService)
+		r := newRouter(handler.Services{Mission: missionService})
+		missionService.On("ReassignCat", mock.Anything, uint(1), uint(2), mock.Anything).Return(nil,
+			custerr.NewConflictErr("mission has no cat to reassign").WithCode(models.ErrCodeMissionUnassigned))
+
+		w := serve(t, r, auth.RoleHandler, http.MethodPatch, "/missions/1/reassign/2", `{"reason": "rotation"}`)
+
+		assertProblem(t, w, http.StatusConflict, "MISSION_UNASSIGNED")
+	})
+}
+
+func TestGetMissionAssignments(t *testing.T) {
+	missionService := new(MockMissionService)
+	r := newRouter(handler.Services{Mission: missionService})
+	catID := uint(2)
+	missionService.On("GetAssignments", mock.Anything, uint(1)).Return([]models.MissionAssignment{{ID: 1, MissionID: 1, ToCatID: &catID, Actor: "admin"}}, nil)
+
+	w := serve(t, r, auth.RoleHandler, http.MethodGet, "/missions/1/assignments", "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	body := decode[[]map[string]any](t, w)
+	require.Len(t, body, 1)
+	assert.Nil(t, body[0]["from_cat_id"])
+	assert.Equal(t, float64(2), body[0]["to_cat_id"])
+}
+
+func TestCreateTarget(t *testing.T) {
+	t.Run("created", func(t *testing.T) {
+		missionService := new(MockMissionService)
+		r := newRouter(handler.Services{Mission: missionService})
+		missionService.On("CreateTarget", mock.Anything, uint(1), models.CreateTargetDTO{Name: "Target Beta", Country: "Japan"}).Return(newMission(), nil)
+
+		w := serve(t, r, auth.RoleHandler, http.MethodPost, "/missions/1/targets", `{"name": "Target Beta", "country": "Japan"}`)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		missionService.AssertExpectations(t)
+	})
+
+	t.Run("target limit", func(t *testing.T) {
+		missionService := new(MockMissionService)
+		r := newRouter(handler.Services{Mission: missionService})
+		missionService.On("CreateTarget", mock.Anything, uint(1), mock.Anything).Return(nil,
+			custerr.NewConflictErr("mission already has 3 targets").WithCode(models.ErrCodeMissionTargetLimit))
+
+		w := serve(t, r, auth.RoleHandler, http.MethodPost, "/missions/1/targets", `{"name": "Target Beta", "country": "Japan"}`)
+
+		assertProblem(t, w, http.StatusConflict, "MISSION_TARGET_LIMIT")
+	})
+
+	t.Run("country required", func(t *testing.T) {
+		r := newRouter(handler.Services{Mission: new(MockMissionService)})
+
+		w := serve(t, r, auth.RoleHandler, http.MethodPost, "/missions/1/targets", `{"name": "Target Beta"}`)
+
+		problem := assertProblem(t, w, http.StatusBadRequest, "VALIDATION_FAILED")
+		assert.Equal(t, []any{map[string]any{"field": "country", "rule": "required", "message": "country is required"}}, problem["errors"])
+	})
+}
+
+func TestUpdateTarget(t *testing.T) {
+	t.Run("updated", func(t *testing.T) {
+		missionService := new(MockMissionService)
+		r := newRouter(handler.Services{Mission: missionService})
+		status := models.TargetStatusUnderSurveillance
+		missionService.On("UpdateTarget", mock.Anything, uint(1), uint(2), models.UpdateTargetDTO{Status: &status}).Return(newMission(), nil)
+
+		w := serve(t, r, auth.RoleHandler, http.MethodPatch, "/missions/1/targets/2", `{"status": "under_surveillance"}`)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		missionService.AssertExpectations(t)
+	})
+
+	t.Run("unknown status", func(t *testing.T) {
+		r := newRouter(handler.Services{Mission: new(MockMissionService)})
+
+		w := serve(t, r, auth.RoleHandler, http.MethodPatch, "/missions/1/targets/2", `{"status": "lost"}`)
+
+		problem := assertProblem(t, w, http.StatusBadRequest, "VALIDATION_FAILED")
+		assert.Equal(t, "status must be one of pending, under_surveillance, compromised, escaped, neutralized", problem["detail"])
+	})
+
+	t.Run("invalid target id", func(t *testing.T) {
+		r := newRouter(handler.Services{Mission: new(MockMissionService)})
+
+		w := serve(t, r, auth.RoleHandler, http.MethodPatch, "/missions/1/targets/abc", `{}`)
+
+		problem := assertProblem(t, w, http.StatusBadRequest, "INVALID_ID")
+		assert.Equal(t, "invalid target id", problem["detail"])
+	})
+
+	t.Run("target of another mission", func(t *testing.T) {
+		missionService := new(MockMissionService)
+		r := newRouter(handler.Services{Mission: missionService})
+		missionService.On("UpdateTarget", mock.Anything, uint(1), uint(9), mock.Anything).Return(nil,
+			custerr.NewNotFoundErr("no target with id \"9\" in mission \"1\""))
+
+		w := serve(t, r, auth.RoleHandler, http.MethodPatch, "/missions/1/targets/9", `{"notes": "seen"}`)
+
+		assertProblem(t, w, http.StatusNotFound, "NOT_FOUND")
+	})
+
+	t.Run("invalid transition", func(t *testing.T) {
+		missionService := new(MockMissionService)
+		r := newRouter(handler.Services{Mission: missionService})
+		missionService.On("UpdateTarget", mock.Anything, uint(1), uint(2), mock.Anything).Return(nil,
+			custerr.NewConflictErr("cannot move target from escaped to pending").WithCode(models.ErrCodeInvalidStatusTransition))
+
+		w := serve(t, r, auth.RoleHandler, http.MethodPatch, "/missions/1/targets/2", `{"status": "pending"}`)
+
+		assertProblem(t, w, http.StatusConflict, "INVALID_STATUS_TRANSITION")
+	})
+}
+
+func TestDeleteTarget(t *testing.T) {
+	t.Run("returns the mission", func(t *testing.T) {
+		missionService := new(MockMissionService)
+		r := newRouter(handler.Services{Mission: missionService})
+		missionService.On("DeleteTarget", mock.Anything, uint(1), uint(2)).Return(newMission(), nil)
+
+		w := serve(t, r, auth.RoleHandler, http.MethodDelete, "/missions/1/targets/2", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Len(t, decode[map[string]any](t, w)["targets"], 1)
+	})
+
+	t.Run("completed target", func(t *testing.T) {
+		missionService := new(MockMissionService)
+		r := newRouter(handler.Services{Mission: missionService})
+		missionService.On("DeleteTarget", mock.Anything, uint(1), uint(2)).Return(nil,
+			custerr.NewConflictErr("cannot delete a finished target").WithCode(models.ErrCodeTargetTerminal))
+
+		w := serve(t, r, auth.RoleHandler, http.MethodDelete, "/missions/1/targets/2", "")
+
+		assertProblem(t, w, http.StatusConflict, "TARGET_TERMINAL")
+	})
+}
+
+func TestTargetRevisions(t *testing.T) {
+	t.Run("list", func(t *testing.T) {
+		missionService := new(MockMissionService)
+		r := newRouter(handler.Services{Mission: missionService})
+		missionService.On("GetTargetRevisions", mock.Anything, uint(1), uint(2)).Return([]models.TargetNoteRevision{{ID: 1, TargetID: 2, Author: "whiskers", Notes: "seen"}}, nil)
+
+		w := serve(t, r, auth.RoleHandler, http.MethodGet, "/missions/1/targets/2/revisions", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		body := decode[[]map[string]any](t, w)
+		require.Len(t, body, 1)
+		assert.Equal(t, "whiskers", body[0]["author"])
+	})
+
+	t.Run("one revision", func(t *testing.T) {
+		missionService := new(MockMissionService)
+		r := newRouter(handler.Services{Mission: missionService})
+		missionService.On("GetTargetRevision", mock.Anything, uint(1), uint(2), uint(3)).Return(&models.TargetNoteRevision{ID: 3, TargetID: 2, Notes: "seen"}, nil)
+
+		w := serve(t, r, auth.RoleHandler, http.MethodGet, "/missions/1/targets/2/revisions/3", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, float64(3), decode[map[string]any](t, w)["id"])
+	})
+
+	t.Run("invalid revision id", func(t *testing.T) {
+		r := newRouter(handler.Services{Mission: new(MockMissionService)})
+
+		w := serve(t, r, auth.RoleHandler, http.MethodGet, "/missions/1/targets/2/revisions/latest", "")
+
+		problem := assertProblem(t, w, http.StatusBadRequest, "INVALID_ID")
+		assert.Equal(t, "invalid revision id", problem["detail"])
+	})
+
+	t.Run("diff", func(t *testing.T) {
+		missionService := new(MockMissionService)
+		r := newRouter(handler.Services{Mission: missionService})
+		diff := &models.TargetNotesDiff{TargetID: 2, From: 3, To: 4, Lines: []textdiff.Line{}}
+		missionService.On("DiffTargetRevisions", mock.Anything, uint(1), uint(2), uint(3), uint(4)).Return(diff, nil)
+
+		w := serve(t, r, auth.RoleHandler, http.MethodGet, "/missions/1/targets/2/revisions/diff?from=3&to=4", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		body := decode[map[string]any](t, w)
+		assert.Equal(t, float64(3), body["from"])
+		assert.Equal(t, float64(4), body["to"])
+		missionService.AssertExpectations(t)
+	})
+
+	t.Run("diff needs both revisions", func(t *testing.T) {
+		r := newRouter(handler.Services{Mission: new(MockMissionService)})
+
+		w := serve(t, r, auth.RoleHandler, http.MethodGet, "/missions/1/targets/2/revisions/diff?from=3", "")
+
+		problem := assertProblem(t, w, http.StatusBadRequest, "VALIDATION_FAILED")
+		assert.Equal(t, "to is required", problem["detail"])
+	})
+}
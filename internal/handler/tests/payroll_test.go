@@ -0,0 +1,65 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"spy-cat-agency/internal/auth"
+	"spy-cat-agency/internal/handler"
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/pkg/custerr"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPayrollService struct {
+	mock.Mock
+}
+
+func (m *MockPayrollService) GetReport(ctx context.Context, query models.PayrollQuery) (*models.PayrollReport, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PayrollReport), args.Error(1)
+}
+
+func TestGetPayroll(t *testing.T) {
+	t.Run("report", func(t *testing.T) {
+		payrollService := new(MockPayrollService)
+		r := newRouter(handler.Services{Payroll: payrollService})
+		from, to := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+		report := &models.PayrollReport{From: "2024-01-01", To: "2024-01-31", Days: 31, Total: 424.66, Cats: []models.CatPayroll{}}
+		payrollService.On("GetReport", mock.Anything, models.PayrollQuery{From: &from, To: &to}).Return(report, nil)
+
+		w := serve(t, r, auth.RoleAdmin, http.MethodGet, "/payroll?from=2024-01-01&to=2024-01-31", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		body := decode[map[string]any](t, w)
+		assert.Equal(t, float64(31), body["days"])
+		assert.Equal(t, []any{}, body["cats"])
+		payrollService.AssertExpectations(t)
+	})
+
+	t.Run("period required", func(t *testing.T) {
+		r := newRouter(handler.Services{Payroll: new(MockPayrollService)})
+
+		w := serve(t, r, auth.RoleAdmin, http.MethodGet, "/payroll?from=2024-01-01", "")
+
+		problem := assertProblem(t, w, http.StatusBadRequest, "VALIDATION_FAILED")
+		assert.Equal(t, "to is required", problem["detail"])
+	})
+
+	t.Run("reversed period", func(t *testing.T) {
+		payrollService := new(MockPayrollService)
+		r := newRouter(handler.Services{Payroll: payrollService})
+		payrollService.On("GetReport", mock.Anything, mock.Anything).Return(nil,
+			custerr.NewBadRequestErr("from must not be after to").WithCode(models.ErrCodeInvalidDateRange))
+
+		w := serve(t, r, auth.RoleAdmin, http.MethodGet, "/payroll?from=2024-02-01&to=2024-01-01", "")
+
+		assertProblem(t, w, http.StatusBadRequest, "INVALID_DATE_RANGE")
+	})
+}
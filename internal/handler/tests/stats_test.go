@@ -0,0 +1,118 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"spy-cat-agency/internal/auth"
+	"spy-cat-agency/internal/handler"
+	"spy-cat-agency/internal/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockStatsService struct {
+	mock.Mock
+}
+
+func (m *MockStatsService) GetOverview(ctx context.Context, filter models.StatsFilter) (*models.StatsOverview, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.StatsOverview), args.Error(1)
+}
+
+func (m *MockStatsService) GetCatCompletion(ctx context.Context, filter models.StatsFilter) ([]models.CatCompletionStats, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.CatCompletionStats), args.Error(1)
+}
+
+func (m *MockStatsService) GetTargetsByCountry(ctx context.Context, filter models.StatsFilter) ([]models.CountryStats, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.CountryStats), args.Error(1)
+}
+
+func (m *MockStatsService) GetIdleCats(ctx context.Context, filter models.StatsFilter) ([]models.IdleCat, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.IdleCat), args.Error(1)
+}
+
+func TestGetStats(t *testing.T) {
+	t.Run("overview", func(t *testing.T) {
+		statsService := new(MockStatsService)
+		r := newRouter(handler.Services{Stats: statsService})
+		statsService.On("GetOverview", mock.Anything, models.StatsFilter{DateField: "updated_at"}).Return(&models.StatsOverview{
+			Payroll:  models.PayrollStats{Cats: 2, Total: 4000, Average: 2000},
+			Missions: models.MissionStats{Total: 3, Open: 2, Complete: 1},
+			IdleCats: 1,
+		}, nil)
+
+		w := serve(t, r, auth.RoleHandler, http.MethodGet, "/stats?date_field=updated_at", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		body := decode[map[string]any](t, w)
+		assert.Equal(t, float64(1), body["idle_cats"])
+		assert.Equal(t, float64(4000), body["payroll"].(map[string]any)["total"])
+		assert.Equal(t, float64(2), body["missions"].(map[string]any)["open"])
+		statsService.AssertExpectations(t)
+	})
+
+	t.Run("unknown date field", func(t *testing.T) {
+		r := newRouter(handler.Services{Stats: new(MockStatsService)})
+
+		w := serve(t, r, auth.RoleHandler, http.MethodGet, "/stats?date_field=deleted_at", "")
+
+		problem := assertProblem(t, w, http.StatusBadRequest, "VALIDATION_FAILED")
+		assert.Equal(t, "date_field must be one of created_at, updated_at", problem["detail"])
+	})
+}
+
+func TestGetCatStats(t *testing.T) {
+	statsService := new(MockStatsService)
+	r := newRouter(handler.Services{Stats: statsService})
+	statsService.On("GetCatCompletion", mock.Anything, models.StatsFilter{}).Return([]models.CatCompletionStats{{CatID: 1, Name: "Whiskers", Missions: 2, Completed: 1, CompletionRate: 0.5}}, nil)
+
+	w := serve(t, r, auth.RoleHandler, http.MethodGet, "/stats/cats", "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	body := decode[[]map[string]any](t, w)
+	require.Len(t, body, 1)
+	assert.Equal(t, 0.5, body[0]["completion_rate"])
+}
+
+func TestGetCountryStats(t *testing.T) {
+	statsService := new(MockStatsService)
+	r := newRouter(handler.Services{Stats: statsService})
+	statsService.On("GetTargetsByCountry", mock.Anything, models.StatsFilter{}).Return([]models.CountryStats{{Country: "France", Targets: 2, Complete: 1}}, nil)
+
+	w := serve(t, r, auth.RoleHandler, http.MethodGet, "/stats/countries", "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, []map[string]any{{"country": "France", "targets": float64(2), "complete": float64(1)}}, decode[[]map[string]any](t, w))
+}
+
+func TestGetIdleCats(t *testing.T) {
+	statsService := new(MockStatsService)
+	r := newRouter(handler.Services{Stats: statsService})
+	statsService.On("GetIdleCats", mock.Anything, models.StatsFilter{}).Return([]models.IdleCat{{ID: 2, Name: "Shadow"}}, nil)
+
+	w := serve(t, r, auth.RoleAdmin, http.MethodGet, "/stats/idle-cats", "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	body := decode[[]map[string]any](t, w)
+	require.Len(t, body, 1)
+	assert.Contains(t, body[0], "last_mission_at")
+	assert.Nil(t, body[0]["last_mission_at"])
+}
@@ -0,0 +1,180 @@
+package tests
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"spy-cat-agency/internal/auth"
+	"spy-cat-agency/internal/handler"
+	"spy-cat-agency/internal/models"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockTransferService struct {
+	mock.Mock
+}
+
+func (m *MockTransferService) ImportCats(ctx context.Context, format models.TransferFormat, r io.Reader) (*models.ImportResult, error) {
+	args := m.Called(ctx, format, r)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ImportResult), args.Error(1)
+}
+
+func (m *MockTransferService) ExportCats(ctx context.Context, filter models.CatExportFilter) ([]byte, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *MockTransferService) ImportMissions(ctx context.Context, format models.TransferFormat, r io.Reader) (*models.ImportResult, error) {
+	args := m.Called(ctx, format, r)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ImportResult), args.Error(1)
+}
+
+func (m *MockTransferService) ExportMissions(ctx context.Context, filter models.MissionExportFilter) ([]byte, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+// readAll checks the body the service was handed.
+func readAll(t *testing.T, want string) func(mock.Arguments) {
+	return func(args mock.Arguments) {
+		data, err := io.ReadAll(args.Get(2).(io.Reader))
+		require.NoError(t, err)
+		assert.Equal(t, want, string(data))
+	}
+}
+
+func TestImportCats(t *testing.T) {
+	t.Run("json", func(t *testing.T) {
+		transferService := new(MockTransferService)
+		r := newRouter(handler.Services{Transfer: transferService})
+		body := `[{"name":"Whiskers","years_experience":5,"breed_id":"siam","salary":2000}]`
+		transferService.On("ImportCats", mock.Anything, models.TransferFormatJSON, mock.Anything).
+			Run(readAll(t, body)).
+			Return(&models.ImportResult{Created: 1, IDs: []uint{1}}, nil)
+
+		w := serve(t, r, auth.RoleHandler, http.MethodPost, "/cats/import", body)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, map[string]any{"created": float64(1), "ids": []any{float64(1)}}, decode[map[string]any](t, w))
+		transferService.AssertExpectations(t)
+	})
+
+	t.Run("format from content type", func(t *testing.T) {
+		transferService := new(MockTransferService)
+		r := newRouter(handler.Services{Transfer: transferService})
+		body := "name,years_experience,breed_id,salary\nWhiskers,5,siam,2000\n"
+		transferService.On("ImportCats", mock.Anything, models.TransferFormatCSV, mock.Anything).
+			Run(readAll(t, body)).
+			Return(&models.ImportResult{Created: 1, IDs: []uint{1}}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/cats/import", strings.NewReader(body))
+		req.Header.Set("Content-Type", "text/csv")
+		req.Header.Set("Authorization", "Bearer "+token(t, auth.RoleHandler))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		transferService.AssertExpectations(t)
+	})
+
+	t.Run("body too large", func(t *testing.T) {
+		transferService := new(MockTransferService)
+		r := newRouter(handler.Services{Transfer: transferService})
+		transferService.On("ImportCats", mock.Anything, models.TransferFormatJSON, mock.Anything).
+			Run(func(args mock.Arguments) {
+				_, err := io.ReadAll(args.Get(2).(io.Reader))
+				var maxBytesErr *http.MaxBytesError
+				assert.ErrorAs(t, err, &maxBytesErr)
+			}).
+			Return(nil, &http.MaxBytesError{Limit: 10 << 20})
+
+		w := serve(t, r, auth.RoleHandler, http.MethodPost, "/cats/import", strings.Repeat(" ", 10<<20+1))
+
+		assertProblem(t, w, http.StatusRequestEntityTooLarge, "PAYLOAD_TOO_LARGE")
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		r := newRouter(handler.Services{Transfer: new(MockTransferService)})
+
+		w := serve(t, r, auth.RoleHandler, http.MethodPost, "/cats/import?format=xml", "")
+
+		problem := assertProblem(t, w, http.StatusBadRequest, "VALIDATION_FAILED")
+		assert.Equal(t, "format must be one of json, csv", problem["detail"])
+	})
+}
+
+func TestExportCats(t *testing.T) {
+	t.Run("csv", func(t *testing.T) {
+		transferService := new(MockTransferService)
+		r := newRouter(handler.Services{Transfer: transferService})
+		data := "id,name\n1,Whiskers\n"
+		filter := models.CatExportFilter{TransferQuery: models.TransferQuery{Format: models.TransferFormatCSV}, Breed: "Siamese"}
+		transferService.On("ExportCats", mock.Anything, filter).Return([]byte(data), nil)
+
+		w := serve(t, r, auth.RoleHandler, http.MethodGet, "/cats/export?format=csv&breed=Siamese", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Equal(t, `attachment; filename="cats.csv"`, w.Header().Get("Content-Disposition"))
+		assert.Equal(t, data, w.Body.String())
+	})
+
+	t.Run("json by default", func(t *testing.T) {
+		transferService := new(MockTransferService)
+		r := newRouter(handler.Services{Transfer: transferService})
+		transferService.On("ExportCats", mock.Anything, models.CatExportFilter{}).Return([]byte("[]"), nil)
+
+		w := serve(t, r, auth.RoleHandler, http.MethodGet, "/cats/export", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Equal(t, `attachment; filename="cats.json"`, w.Header().Get("Content-Disposition"))
+	})
+}
+
+func TestImportMissions(t *testing.T) {
+	transferService := new(MockTransferService)
+	r := newRouter(handler.Services{Transfer: transferService})
+	body := `[{"targets":[{"name":"Target Alpha","country":"France"}]}]`
+	transferService.On("ImportMissions", mock.Anything, models.TransferFormatJSON, mock.Anything).
+		Run(readAll(t, body)).
+		Return(&models.ImportResult{Created: 1, IDs: []uint{7}}, nil)
+
+	w := serve(t, r, auth.RoleHandler, http.MethodPost, "/missions/import?format=json", body)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, []any{float64(7)}, decode[map[string]any](t, w)["ids"])
+	transferService.AssertExpectations(t)
+}
+
+func TestExportMissions(t *testing.T) {
+	transferService := new(MockTransferService)
+	r := newRouter(handler.Services{Transfer: transferService})
+	catID := uint(1)
+	filter := models.MissionExportFilter{CatID: &catID, Country: "France"}
+	transferService.On("ExportMissions", mock.Anything, filter).Return([]byte("[]"), nil)
+
+	w := serve(t, r, auth.RoleHandler, http.MethodGet, "/missions/export?cat_id=1&country=France", "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `attachment; filename="missions.json"`, w.Header().Get("Content-Disposition"))
+	assert.Equal(t, "[]", w.Body.String())
+}
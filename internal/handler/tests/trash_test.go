@@ -0,0 +1,177 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"spy-cat-agency/internal/auth"
+	"spy-cat-agency/internal/handler"
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/pkg/custerr"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTrashService struct {
+	mock.Mock
+}
+
+func (m *MockTrashService) GetDeletedCats(ctx context.Context, query models.PageQuery) (*models.Page[models.Cat], error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Page[models.Cat]), args.Error(1)
+}
+
+func (m *MockTrashService) GetDeletedMissions(ctx context.Context, query models.PageQuery) (*models.Page[models.Mission], error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Page[models.Mission]), args.Error(1)
+}
+
+func (m *MockTrashService) GetDeletedTargets(ctx context.Context, query models.PageQuery) (*models.Page[models.Target], error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Page[models.Target]), args.Error(1)
+}
+
+func (m *MockTrashService) RestoreCat(ctx context.Context, id uint) (*models.Cat, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Cat), args.Error(1)
+}
+
+func (m *MockTrashService) RestoreMission(ctx context.Context, id uint) (*models.Mission, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Mission), args.Error(1)
+}
+
+func (m *MockTrashService) RestoreTarget(ctx context.Context, id uint) (*models.Target, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Target), args.Error(1)
+}
+
+func TestGetDeleted(t *testing.T) {
+	query := models.PageQuery{Page: 1, PerPage: 10, Sort: "-deleted_at"}
+
+	t.Run("cats", func(t *testing.T) {
+		trashService := new(MockTrashService)
+		r := newRouter(handler.Services{Trash: trashService})
+		trashService.On("GetDeletedCats", mock.Anything, query).Return(models.NewPage([]models.Cat{*newCat()}, query, 1), nil)
+
+		w := serve(t, r, auth.RoleAdmin, http.MethodGet, "/trash/cats?page=1&per_page=10&sort=-deleted_at", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Len(t, decode[map[string]any](t, w)["items"], 1)
+		trashService.AssertExpectations(t)
+	})
+
+	t.Run("missions", func(t *testing.T) {
+		trashService := new(MockTrashService)
+		r := newRouter(handler.Services{Trash: trashService})
+		trashService.On("GetDeletedMissions", mock.Anything, models.PageQuery{}).Return(models.NewPage([]models.Mission{}, models.PageQuery{}, 0), nil)
+
+		w := serve(t, r, auth.RoleAdmin, http.MethodGet, "/trash/missions", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, []any{}, decode[map[string]any](t, w)["items"])
+	})
+
+	t.Run("targets", func(t *testing.T) {
+		trashService := new(MockTrashService)
+		r := newRouter(handler.Services{Trash: trashService})
+		trashService.On("GetDeletedTargets", mock.Anything, models.PageQuery{}).Return(models.NewPage([]models.Target{{ID: 4, MissionID: 1}}, models.PageQuery{}, 1), nil)
+
+		w := serve(t, r, auth.RoleAdmin, http.MethodGet, "/trash/targets", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Len(t, decode[map[string]any](t, w)["items"], 1)
+	})
+
+	t.Run("per_page too large", func(t *testing.T) {
+		r := newRouter(handler.Services{Trash: new(MockTrashService)})
+
+		w := serve(t, r, auth.RoleAdmin, http.MethodGet, "/trash/cats?per_page=500", "")
+
+		problem := assertProblem(t, w, http.StatusBadRequest, "VALIDATION_FAILED")
+		assert.Equal(t, "per_page must be at most 100", problem["detail"])
+	})
+}
+
+func TestRestore(t *testing.T) {
+	t.Run("cat", func(t *testing.T) {
+		trashService := new(MockTrashService)
+		r := newRouter(handler.Services{Trash: trashService})
+		trashService.On("RestoreCat", mock.Anything, uint(1)).Return(newCat(), nil)
+
+		w := serve(t, r, auth.RoleAdmin, http.MethodPost, "/trash/cats/1/restore", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "Whiskers", decode[map[string]any](t, w)["name"])
+	})
+
+	t.Run("mission", func(t *testing.T) {
+		trashService := new(MockTrashService)
+		r := newRouter(handler.Services{Trash: trashService})
+		trashService.On("RestoreMission", mock.Anything, uint(1)).Return(newMission(), nil)
+
+		w := serve(t, r, auth.RoleAdmin, http.MethodPost, "/trash/missions/1/restore", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, float64(1), decode[map[string]any](t, w)["id"])
+	})
+
+	t.Run("target", func(t *testing.T) {
+		trashService := new(MockTrashService)
+		r := newRouter(handler.Services{Trash: trashService})
+		trashService.On("RestoreTarget", mock.Anything, uint(4)).Return(&models.Target{ID: 4, MissionID: 1, Name: "Target Alpha"}, nil)
+
+		w := serve(t, r, auth.RoleAdmin, http.MethodPost, "/trash/targets/4/restore", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, float64(1), decode[map[string]any](t, w)["mission_id"])
+	})
+
+	t.Run("parent still deleted", func(t *testing.T) {
+		trashService := new(MockTrashService)
+		r := newRouter(handler.Services{Trash: trashService})
+		trashService.On("RestoreTarget", mock.Anything, uint(4)).Return(nil,
+			custerr.NewConflictErr("restore mission \"1\" first").WithCode(models.ErrCodeParentDeleted))
+
+		w := serve(t, r, auth.RoleAdmin, http.MethodPost, "/trash/targets/4/restore", "")
+
+		assertProblem(t, w, http.StatusConflict, "PARENT_DELETED")
+	})
+
+	t.Run("not in trash", func(t *testing.T) {
+		trashService := new(MockTrashService)
+		r := newRouter(handler.Services{Trash: trashService})
+		trashService.On("RestoreCat", mock.Anything, uint(2)).Return(nil, custerr.NewNotFoundErr("no deleted cat with id \"2\""))
+
+		w := serve(t, r, auth.RoleAdmin, http.MethodPost, "/trash/cats/2/restore", "")
+
+		assertProblem(t, w, http.StatusNotFound, "NOT_FOUND")
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		r := newRouter(handler.Services{Trash: new(MockTrashService)})
+
+		w := serve(t, r, auth.RoleAdmin, http.MethodPost, "/trash/missions/one/restore", "")
+
+		assertProblem(t, w, http.StatusBadRequest, "INVALID_ID")
+	})
+}
@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"spy-cat-agency/internal/models"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxImportBytes bounds import bodies, which are read as a whole.
+const maxImportBytes = 10 << 20
+
+// ImportCats creates cats in bulk
+// @Summary Import cats
+// @Description Create cats from a JSON array or a CSV file with the columns name, years_experience, breed and salary. Every row is validated first, breeds against TheCatAPI, and all cats are created in one transaction. If any row is invalid nothing is imported and the errors list every invalid row (array index from 1 for JSON, line number for CSV).
+// @Tags Cats
+// @Accept json
+// @Accept plain
+// @Produce json
+// @Param format query string false "File format, defaults to csv for text/csv bodies and json otherwise" Enums(json, csv)
+// @Param body body []models.CreateCatDTO true "Cats as JSON or CSV"
+// @Success 201 {object} models.ImportResult
+// @Failure 400 {object} middleware.Problem
+// @Failure 401 {object} middleware.Problem
+// @Failure 403 {object} middleware.Problem
+// @Failure 413 {object} middleware.Problem
+// @Failure 500 {object} middleware.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /cats/import [post]
+func (h *Handler) ImportCats(c *gin.Context) {
+	var query models.TransferQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, maxImportBytes)
+	result, err := h.transferService.ImportCats(c.Request.Context(), importFormat(c, query), body)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// ExportCats downloads cats in bulk
+// @Summary Export cats
+// @Description Download cats in the format accepted by the import, as JSON or CSV
+// @Tags Cats
+// @Produce json
+// @Produce plain
+// @Param filter query models.CatExportFilter false "Format and filters"
+// @Success 200 {array} models.CreateCatDTO
+// @Failure 400 {object} middleware.Problem
+// @Failure 401 {object} middleware.Problem
+// @Failure 403 {object} middleware.Problem
+// @Failure 500 {object} middleware.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /cats/export [get]
+func (h *Handler) ExportCats(c *gin.Context) {
+	var filter models.CatExportFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	data, err := h.transferService.ExportCats(c.Request.Context(), filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	sendExport(c, "cats", filter.Format, data)
+}
+
+// ImportMissions creates missions in bulk
+// @Summary Import missions
+// @Description Create open missions with their targets from a JSON array or a CSV file with the columns mission, cat_id, target_name, target_country and target_notes, one line per target grouped by the mission column. Missions with a cat_id are assigned to that cat, which must exist and be free. Every row is validated first and all missions are created in one transaction. If any row is invalid nothing is imported and the errors list every invalid row.
+// @Tags Missions
+// @Accept json
+// @Accept plain
+// @Produce json
+// @Param format query string false "File format, defaults to csv for text/csv bodies and json otherwise" Enums(json, csv)
+// @Param body body []models.MissionRecord true "Missions as JSON or CSV"
+// @Success 201 {object} models.ImportResult
+// @Failure 400 {object} middleware.Problem
+// @Failure 401 {object} middleware.Problem
+// @Failure 403 {object} middleware.Problem
+// @Failure 409 {object} middleware.Problem
+// @Failure 413 {object} middleware.Problem
+// @Failure 500 {object} middleware.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /missions/import [post]
+func (h *Handler) ImportMissions(c *gin.Context) {
+	var query models.TransferQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, maxImportBytes)
+	result, err := h.transferService.ImportMissions(c.Request.Context(), importFormat(c, query), body)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// ExportMissions downloads missions in bulk
+// @Summary Export missions
+// @Description Download missions with their targets in the format accepted by the import, as JSON or CSV. Completed missions are exported without their cat, since imported missions are open. Filter with complete=false to export only open missions.
+// @Tags Missions
+// @Produce json
+// @Produce plain
+// @Param filter query models.MissionExportFilter false "Format and filters"
+// @Success 200 {array} models.MissionRecord
+// @Failure 400 {object} middleware.Problem
+// @Failure 401 {object} middleware.Problem
+// @Failure 403 {object} middleware.Problem
+// @Failure 500 {object} middleware.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /missions/export [get]
+func (h *Handler) ExportMissions(c *gin.Context) {
+	var filter models.MissionExportFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	data, err := h.transferService.ExportMissions(c.Request.Context(), filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	sendExport(c, "missions", filter.Format, data)
+}
+
+// importFormat falls back to the request content type when no format is
+// given.
+func importFormat(c *gin.Context, query models.TransferQuery) models.TransferFormat {
+	if query.Format != "" {
+		return query.Format
+	}
+	if strings.Contains(c.ContentType(), "csv") {
+		return models.TransferFormatCSV
+	}
+	return models.TransferFormatJSON
+}
+
+func sendExport(c *gin.Context, name string, format models.TransferFormat, data []byte) {
+	if format == "" {
+		format = models.TransferFormatJSON
+	}
+
+	contentType := "application/json; charset=utf-8"
+	if format == models.TransferFormatCSV {
+		contentType = "text/csv; charset=utf-8"
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name+"."+string(format)))
+	c.Data(http.StatusOK, contentType, data)
+}
@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"net/http"
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/pkg/custerr"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetDeletedCats retrieves soft deleted cats
+// @Summary Get deleted cats
+// @Description Get a paginated list of deleted cats that can be restored, most recently deleted first
+// @Tags Trash
+// @Accept json
+// @Produce json
+// @Param query query models.PageQuery false "Sorting and pagination"
+// @Success 200 {object} models.Page[models.Cat]
+// @Failure 400 {object} middleware.Problem
+// @Failure 401 {object} middleware.Problem
+// @Failure 403 {object} middleware.Problem
+// @Failure 500 {object} middleware.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /trash/cats [get]
+func (h *Handler) GetDeletedCats(c *gin.Context) {
+	var query models.PageQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	cats, err := h.trashService.GetDeletedCats(c.Request.Context(), query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, cats)
+}
+
+// GetDeletedMissions retrieves soft deleted missions
+// @Summary Get deleted missions
+// @Description Get a paginated list of deleted missions that can be restored, most recently deleted first
+// @Tags Trash
+// @Accept json
+// @Produce json
+// @Param query query models.PageQuery false "Sorting and pagination"
+// @Success 200 {object} models.Page[models.Mission]
+// @Failure 400 {object} middleware.Problem
+// @Failure 401 {object} middleware.Problem
+// @Failure 403 {object} middleware.Problem
+// @Failure 500 {object} middleware.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /trash/missions [get]
+func (h *Handler) GetDeletedMissions(c *gin.Context) {
+	var query models.PageQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	missions, err := h.trashService.GetDeletedMissions(c.Request.Context(), query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, missions)
+}
+
+// GetDeletedTargets retrieves soft deleted targets
+// @Summary Get deleted targets
+// @Description Get a paginated list of deleted targets that can be restored, most recently deleted first
+// @Tags Trash
+// @Accept json
+// @Produce json
+// @Param query query models.PageQuery false "Sorting and pagination"
+// @Success 200 {object} models.Page[models.Target]
+// @Failure 400 {object} middleware.Problem
+// @Failure 401 {object} middleware.Problem
+// @Failure 403 {object} middleware.Problem
+// @Failure 500 {object} middleware.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /trash/targets [get]
+func (h *Handler) GetDeletedTargets(c *gin.Context) {
+	var query models.PageQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	targets, err := h.trashService.GetDeletedTargets(c.Request.Context(), query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, targets)
+}
+
+// RestoreCat brings back a deleted cat
+// @Summary Restore cat
+// @Description Restore a deleted cat. Missions it was unassigned from stay unassigned.
+// @Tags Trash
+// @Accept json
+// @Produce json
+// @Param id path int true "Cat ID"
+// @Success 200 {object} models.Cat
+// @Failure 400 {object} middleware.Problem
+// @Failure 401 {object} middleware.Problem
+// @Failure 403 {object} middleware.Problem
+// @Failure 404 {object} middleware.Problem
+// @Failure 409 {object} middleware.Problem
+// @Failure 500 {object} middleware.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /trash/cats/{id}/restore [post]
+func (h *Handler) RestoreCat(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(custerr.NewBadRequestErr("invalid id").WithCode(models.ErrCodeInvalidID))
+		return
+	}
+
+	cat, err := h.trashService.RestoreCat(c.Request.Context(), uint(id))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, cat)
+}
+
+// RestoreMission brings back a deleted mission
+// @Summary Restore mission
+// @Description Restore a deleted mission. It must still have 1-3 targets, and an open mission's cat must exist and have no other active mission.
+// @Tags Trash
+// @Accept json
+// @Produce json
+// @Param id path int true "Mission ID"
+// @Success 200 {object} models.Mission
+// @Failure 400 {object} middleware.Problem
+// @Failure 401 {object} middleware.Problem
+// @Failure 403 {object} middleware.Problem
+// @Failure 404 {object} middleware.Problem
+// @Failure 409 {object} middleware.Problem
+// @Failure 500 {object} middleware.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /trash/missions/{id}/restore [post]
+func (h *Handler) RestoreMission(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(custerr.NewBadRequestErr("invalid id").WithCode(models.ErrCodeInvalidID))
+		return
+	}
+
+	mission, err := h.trashService.RestoreMission(c.Request.Context(), uint(id))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mission)
+}
+
+// RestoreTarget brings back a deleted target
+// @Summary Restore target
+// @Description Restore a deleted target. Its mission must exist, be open and have fewer than 3 targets.
+// @Tags Trash
+// @Accept json
+// @Produce json
+// @Param id path int true "Target ID"
+// @Success 200 {object} models.Target
+// @Failure 400 {object} middleware.Problem
+// @Failure 401 {object} middleware.Problem
+// @Failure 403 {object} middleware.Problem
+// @Failure 404 {object} middleware.Problem
+// @Failure 409 {object} middleware.Problem
+// @Failure 500 {object} middleware.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /trash/targets/{id}/restore [post]
+func (h *Handler) RestoreTarget(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(custerr.NewBadRequestErr("invalid id").WithCode(models.ErrCodeInvalidID))
+		return
+	}
+
+	target, err := h.trashService.RestoreTarget(c.Request.Context(), uint(id))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, target)
+}
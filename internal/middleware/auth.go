@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"spy-cat-agency/internal/actor"
+	"spy-cat-agency/internal/auth"
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/pkg/custerr"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const APIKeyHeader = "X-API-Key"
+
+// Auth authenticates the request with a bearer token or an API key and
+// attributes it to the authenticated principal.
+func Auth(authenticator *auth.Authenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var (
+			principal *auth.Principal
+			err       error
+		)
+		if token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer "); ok {
+			principal, err = authenticator.AuthenticateToken(token)
+		} else if key := c.GetHeader(APIKeyHeader); key != "" {
+			principal, err = authenticator.AuthenticateAPIKey(key)
+		} else {
+			err = custerr.NewUnauthorizedErr("missing credentials").WithCode(models.ErrCodeMissingCredentials)
+		}
+		if err == auth.ErrInvalidCredentials {
+			err = custerr.NewUnauthorizedErr("invalid credentials").WithCode(models.ErrCodeInvalidCredentials)
+		}
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		ctx := auth.WithPrincipal(c.Request.Context(), principal)
+		c.Request = c.Request.WithContext(actor.WithName(ctx, principal.Subject))
+		c.Next()
+	}
+}
+
+// RequireRole rejects requests from principals without one of roles.
+func RequireRole(roles ...auth.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := auth.FromContext(c.Request.Context())
+		if !ok || !principal.HasRole(roles...) {
+			c.Error(custerr.NewForbiddenErr("insufficient permissions"))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -1,43 +1,145 @@
 package middleware
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"log"
 	"net/http"
 	"reflect"
+	"spy-cat-agency/internal/models"
 	"spy-cat-agency/pkg/custerr"
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
 )
 
+const ProblemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 error response. Code identifies the error for
+// clients, Detail is meant for humans and may change.
+type Problem struct {
+	Type      string       `json:"type" example:"about:blank"`
+	Title     string       `json:"title" example:"Conflict"`
+	Status    int          `json:"status" example:"409"`
+	Detail    string       `json:"detail" example:"cat already has an active mission"`
+	Instance  string       `json:"instance" example:"/api/v1/missions/1/assign/2"`
+	Code      custerr.Code `json:"code" swaggertype:"string" example:"CAT_ALREADY_ON_MISSION"`
+	RequestID string       `json:"request_id" example:"4bf92f3577b34da6a3ce929d0e0e4736"`
+	// Errors lists every invalid field of a VALIDATION_FAILED request
+	Errors []FieldError `json:"errors,omitempty"`
+	// Extensions are rule specific members, e.g. breed suggestions, added
+	// next to the standard ones
+	Extensions map[string]any `json:"-"`
+}
+
+type FieldError struct {
+	Field   string `json:"field" example:"targets[0].name"`
+	Rule    string `json:"rule" example:"required"`
+	Message string `json:"message" example:"targets[0].name is required"`
+}
+
+func (p Problem) MarshalJSON() ([]byte, error) {
+	type problem Problem
+	data, err := json.Marshal(problem(p))
+	if err != nil || len(p.Extensions) == 0 {
+		return data, err
+	}
+
+	members := make(map[string]any, len(p.Extensions))
+	for key, value := range p.Extensions {
+		members[key] = value
+	}
+	// the standard members win over extensions of the same name
+	if err := json.Unmarshal(data, &members); err != nil {
+		return nil, err
+	}
+	return json.Marshal(members)
+}
+
 func messageForTag(fe validator.FieldError) string {
+	field := fieldPath(fe)
 	switch fe.Tag() {
 	case "required":
-		return fmt.Sprintf("%s is required", fe.Namespace())
+		return fmt.Sprintf("%s is required", field)
+	case "oneof":
+		return fmt.Sprintf("%s must be one of %s", field, strings.ReplaceAll(fe.Param(), " ", ", "))
 	case "min":
-		switch fe.Type().Kind() {
+		switch kindOf(fe) {
 		case reflect.String:
-			return fmt.Sprintf("%s must be at least %s characters long", fe.Namespace(), fe.Param())
-		case reflect.Int:
-			return fmt.Sprintf("%s must be at least %s", fe.Namespace(), fe.Param())
+			return fmt.Sprintf("%s must be at least %s characters long", field, fe.Param())
+		case reflect.Int, reflect.Float64:
+			return fmt.Sprintf("%s must be at least %s", field, fe.Param())
 		case reflect.Slice:
-			return fmt.Sprintf("%s must have at least %s items", fe.Namespace(), fe.Param())
+			return fmt.Sprintf("%s must have at least %s items", field, fe.Param())
 		}
 	case "max":
-		switch fe.Type().Kind() {
+		switch kindOf(fe) {
 		case reflect.String:
-			return fmt.Sprintf("%s must be at most %s characters long", fe.Namespace(), fe.Param())
-		case reflect.Int:
-			return fmt.Sprintf("%s must be at most %s", fe.Namespace(), fe.Param())
+			return fmt.Sprintf("%s must be at most %s characters long", field, fe.Param())
+		case reflect.Int, reflect.Float64:
+			return fmt.Sprintf("%s must be at most %s", field, fe.Param())
 		case reflect.Slice:
-			return fmt.Sprintf("%s must have at most %s items", fe.Namespace(), fe.Param())
+			return fmt.Sprintf("%s must have at most %s items", field, fe.Param())
 		}
 	}
-	return fe.Error()
+	return fmt.Sprintf("%s is invalid", field)
 }
 
+// kindOf folds the sized numeric kinds into Int and Float64.
+func kindOf(fe validator.FieldError) reflect.Kind {
+	switch kind := fe.Kind(); kind {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return reflect.Int
+	case reflect.Float32:
+		return reflect.Float64
+	default:
+		return kind
+	}
+}
+
+// fieldPath names a field the way the client sent it, e.g. targets[0].name.
+// The namespace starts with the bound struct and goes through embedded
+// structs, whose Go names are the only segments that are not lower case.
+func fieldPath(fe validator.FieldError) string {
+	segments := strings.Split(fe.Namespace(), ".")
+	if len(segments) < 2 {
+		return fe.Field()
+	}
+	var path []string
+	for _, segment := range segments[1 : len(segments)-1] {
+		if segment != "" && strings.ToLower(segment[:1]) == segment[:1] {
+			path = append(path, segment)
+		}
+	}
+	return strings.Join(append(path, fe.Field()), ".")
+}
+
+// jsonFieldName reports fields by their json or form name.
+func jsonFieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "form"} {
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+// ErrorHandler renders the last error of a request as an RFC 7807
+// problem+json response.
 func ErrorHandler() gin.HandlerFunc {
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(jsonFieldName)
+	}
+
 	return func(c *gin.Context) {
 		c.Next()
 
@@ -46,25 +148,98 @@ func ErrorHandler() gin.HandlerFunc {
 		}
 
 		err := c.Errors.Last()
-		switch err.Type {
-		case gin.ErrorTypeBind:
-			validationErrors := err.Err.(validator.ValidationErrors)
-			errs := make([]string, len(validationErrors))
-			for i, fieldErr := range validationErrors {
-				errs[i] = messageForTag(fieldErr)
-			}
-			c.JSON(http.StatusBadRequest, gin.H{"error": strings.Join(errs, ", ")})
-		default:
-			switch custErr := err.Err.(type) {
-			case custerr.BadRequestErr:
-				c.JSON(http.StatusBadRequest, gin.H{"error": custErr.Error()})
-			case custerr.NotFoundErr:
-				c.JSON(http.StatusNotFound, gin.H{"error": custErr.Error()})
-			case custerr.ConflictErr:
-				c.JSON(http.StatusConflict, gin.H{"error": custErr.Error()})
-			default:
-				c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
-			}
+		var problem Problem
+		if err.Type == gin.ErrorTypeBind {
+			problem = bindProblem(err.Err)
+		} else {
+			problem = errorProblem(c, err.Err)
+		}
+
+		problem.Type = "about:blank"
+		problem.Title = http.StatusText(problem.Status)
+		problem.Instance = c.Request.URL.Path
+		problem.RequestID = GetRequestID(c)
+
+		c.Header("Content-Type", ProblemContentType)
+		c.JSON(problem.Status, problem)
+	}
+}
+
+// bindProblem describes why the request could not be bound to its DTO.
+func bindProblem(err error) Problem {
+	var (
+		validationErrs validator.ValidationErrors
+		typeErr        *json.UnmarshalTypeError
+		syntaxErr      *json.SyntaxError
+		maxBytesErr    *http.MaxBytesError
+	)
+	switch {
+	case errors.As(err, &validationErrs):
+		problem := Problem{Status: http.StatusBadRequest, Code: models.ErrCodeValidationFailed}
+		messages := make([]string, len(validationErrs))
+		for i, fieldErr := range validationErrs {
+			messages[i] = messageForTag(fieldErr)
+			problem.Errors = append(problem.Errors, FieldError{Field: fieldPath(fieldErr), Rule: fieldErr.Tag(), Message: messages[i]})
+		}
+		problem.Detail = strings.Join(messages, ", ")
+		return problem
+	case errors.As(err, &typeErr):
+		field := typeErr.Field
+		if field == "" {
+			field = "request body"
+		}
+		message := fmt.Sprintf("%s must be a %s", field, typeErr.Type)
+		return Problem{
+			Status: http.StatusBadRequest,
+			Code:   models.ErrCodeValidationFailed,
+			Detail: message,
+			Errors: []FieldError{{Field: typeErr.Field, Rule: "type", Message: message}},
 		}
+	case errors.As(err, &maxBytesErr):
+		return Problem{
+			Status: http.StatusRequestEntityTooLarge,
+			Code:   models.ErrCodePayloadTooLarge,
+			Detail: fmt.Sprintf("request body is larger than %d bytes", maxBytesErr.Limit),
+		}
+	case errors.As(err, &syntaxErr), errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return Problem{Status: http.StatusBadRequest, Code: models.ErrCodeMalformedRequest, Detail: "request body is not valid JSON"}
+	default:
+		return Problem{Status: http.StatusBadRequest, Code: models.ErrCodeMalformedRequest, Detail: err.Error()}
+	}
+}
+
+// errorProblem maps custerr types, also when wrapped, to their status and
+// returns their metadata as extension members. Anything else is an
+// unexpected failure whose details are logged rather than returned.
+func errorProblem(c *gin.Context, err error) Problem {
+	var (
+		badRequest   custerr.BadRequestErr
+		unauthorized custerr.UnauthorizedErr
+		forbidden    custerr.ForbiddenErr
+		notFound     custerr.NotFoundErr
+		conflict     custerr.ConflictErr
+		maxBytesErr  *http.MaxBytesError
+	)
+	switch {
+	case errors.As(err, &badRequest):
+		return clientProblem(http.StatusBadRequest, badRequest)
+	case errors.As(err, &unauthorized):
+		c.Header("WWW-Authenticate", "Bearer")
+		return clientProblem(http.StatusUnauthorized, unauthorized)
+	case errors.As(err, &forbidden):
+		return clientProblem(http.StatusForbidden, forbidden)
+	case errors.As(err, &notFound):
+		return clientProblem(http.StatusNotFound, notFound)
+	case errors.As(err, &conflict):
+		return clientProblem(http.StatusConflict, conflict)
+	case errors.As(err, &maxBytesErr):
+		return bindProblem(err)
 	}
+
+	log.Printf("request %s: %s %s: %v", GetRequestID(c), c.Request.Method, c.Request.URL.Path, err)
+	return Problem{Status: http.StatusInternalServerError, Code: custerr.CodeInternal, Detail: "internal server error"}
+}
+
+func clientProblem(status int, err custerr.Error) Problem {
+	return Problem{Status: status, Code: err.Code(), Detail: err.Error(), Extensions: err.Meta()}
 }
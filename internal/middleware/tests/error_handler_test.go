@@ -0,0 +1,180 @@
+package tests
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"spy-cat-agency/internal/middleware"
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/pkg/custerr"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+func newRouter(handler gin.HandlerFunc) *gin.Engine {
+	r := gin.New()
+	r.Use(middleware.RequestID(), middleware.ErrorHandler())
+	r.POST("/test", handler)
+	return r
+}
+
+func serve(t *testing.T, r *gin.Engine, body string, headers map[string]string) (*httptest.ResponseRecorder, map[string]any) {
+	req := httptest.NewRequest(http.MethodPost, "/test", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var problem map[string]any
+	if w.Body.Len() > 0 {
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	}
+	return w, problem
+}
+
+func bindCat(c *gin.Context) {
+	var dto models.CreateCatDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+	c.Status(http.StatusCreated)
+}
+
+func TestErrorHandler(t *testing.T) {
+	t.Run("business rule conflict has its code", func(t *testing.T) {
+		r := newRouter(func(c *gin.Context) {
+			c.Error(custerr.NewConflictErr("cat already has an active mission").WithCode(models.ErrCodeCatAlreadyOnMission))
+		})
+
+		w, problem := serve(t, r, "", nil)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Equal(t, middleware.ProblemContentType, w.Header().Get("Content-Type"))
+		assert.Equal(t, "about:blank", problem["type"])
+		assert.Equal(t, "Conflict", problem["title"])
+		assert.Equal(t, float64(http.StatusConflict), problem["status"])
+		assert.Equal(t, "cat already has an active mission", problem["detail"])
+		assert.Equal(t, "/test", problem["instance"])
+		assert.Equal(t, "CAT_ALREADY_ON_MISSION", problem["code"])
+		assert.Equal(t, w.Header().Get(middleware.RequestIDHeader), problem["request_id"])
+	})
+
+	t.Run("errors without a specific code get their type's code", func(t *testing.T) {
+		r := newRouter(func(c *gin.Context) {
+			c.Error(custerr.NewNotFoundErr("no cat with id \"1\""))
+		})
+
+		w, problem := serve(t, r, "", nil)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, "NOT_FOUND", problem["code"])
+	})
+
+	t.Run("bad request details become members", func(t *testing.T) {
+		r := newRouter(func(c *gin.Context) {
+			c.Error(custerr.NewBadRequestErrWithDetails("invalid cat breed", map[string]any{
+				"suggestions": []string{"Siamese"},
+				"code":        "overridden",
+			}).WithCode(models.ErrCodeInvalidBreed))
+		})
+
+		_, problem := serve(t, r, "", nil)
+
+		assert.Equal(t, []any{"Siamese"}, problem["suggestions"])
+		assert.Equal(t, "INVALID_CAT_BREED", problem["code"])
+	})
+
+	t.Run("wrapped errors keep their status and metadata", func(t *testing.T) {
+		r := newRouter(func(c *gin.Context) {
+			err := custerr.NewConflictErr("record already exists").WithCode(custerr.CodeDuplicate).WithMeta("constraint", "idx_cats_name")
+			c.Error(fmt.Errorf("creating cat: %w", err))
+		})
+
+		w, problem := serve(t, r, "", nil)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Equal(t, "DUPLICATE", problem["code"])
+		assert.Equal(t, "idx_cats_name", problem["constraint"])
+	})
+
+	t.Run("validation errors are listed per field", func(t *testing.T) {
+		r := newRouter(bindCat)
+
+		w, problem := serve(t, r, `{"name": "Whiskers", "years_experience": 2, "salary": -1}`, nil)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, "VALIDATION_FAILED", problem["code"])
+		assert.Equal(t, "breed is required, salary must be at least 0", problem["detail"])
+		assert.Equal(t, []any{
+			map[string]any{"field": "breed", "rule": "required", "message": "breed is required"},
+			map[string]any{"field": "salary", "rule": "min", "message": "salary must be at least 0"},
+		}, problem["errors"])
+	})
+
+	t.Run("malformed body does not panic", func(t *testing.T) {
+		r := newRouter(bindCat)
+
+		w, problem := serve(t, r, `{"name": `, nil)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, "MALFORMED_REQUEST", problem["code"])
+	})
+
+	t.Run("wrong JSON type is a validation error", func(t *testing.T) {
+		r := newRouter(bindCat)
+
+		w, problem := serve(t, r, `{"name": "Whiskers", "salary": "lots"}`, nil)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, "VALIDATION_FAILED", problem["code"])
+	})
+
+	t.Run("unexpected errors are hidden", func(t *testing.T) {
+		r := newRouter(func(c *gin.Context) {
+			c.Error(errors.New("pq: connection refused"))
+		})
+
+		w, problem := serve(t, r, "", nil)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, "INTERNAL", problem["code"])
+		assert.Equal(t, "internal server error", problem["detail"])
+	})
+}
+
+func TestRequestID(t *testing.T) {
+	t.Run("keeps client id", func(t *testing.T) {
+		r := newRouter(func(c *gin.Context) {
+			c.Error(custerr.NewForbiddenErr("insufficient permissions"))
+		})
+
+		w, problem := serve(t, r, "", map[string]string{middleware.RequestIDHeader: "trace-42"})
+
+		assert.Equal(t, "trace-42", w.Header().Get(middleware.RequestIDHeader))
+		assert.Equal(t, "trace-42", problem["request_id"])
+	})
+
+	t.Run("replaces unsafe client id", func(t *testing.T) {
+		r := newRouter(func(c *gin.Context) {
+			c.Status(http.StatusNoContent)
+		})
+
+		w, _ := serve(t, r, "", map[string]string{middleware.RequestIDHeader: "bad id\nX-Injected: 1"})
+
+		id := w.Header().Get(middleware.RequestIDHeader)
+		assert.Len(t, id, 32)
+	})
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type AuditAction string
+
+const (
+	AuditActionCreate  AuditAction = "create"
+	AuditActionUpdate  AuditAction = "update"
+	AuditActionDelete  AuditAction = "delete"
+	AuditActionRestore AuditAction = "restore"
+	AuditActionPurge   AuditAction = "purge"
+)
+
+const (
+	AuditEntityCat     = "cat"
+	AuditEntityMission = "mission"
+	AuditEntityTarget  = "target"
+)
+
+type AuditLog struct {
+	ID         uint            `json:"id" gorm:"primarykey"`
+	Actor      string          `json:"actor" gorm:"not null;index"`
+	Action     AuditAction     `json:"action" gorm:"not null"`
+	EntityType string          `json:"entity_type" gorm:"not null;index:idx_audit_logs_entity"`
+	EntityID   uint            `json:"entity_id" gorm:"not null;index:idx_audit_logs_entity"`
+	Before     json.RawMessage `json:"before,omitempty" gorm:"type:jsonb" swaggertype:"object"`
+	After      json.RawMessage `json:"after,omitempty" gorm:"type:jsonb" swaggertype:"object"`
+	CreatedAt  time.Time       `json:"created_at" gorm:"index"`
+}
+
+type AuditFilter struct {
+	PageQuery
+	EntityType string     `form:"entity_type" binding:"omitempty,oneof=cat mission target"`
+	EntityID   *uint      `form:"entity_id"`
+	Actor      string     `form:"actor"`
+	From       *time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To         *time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+}
@@ -9,11 +9,25 @@ import (
 type Cat struct {
 	ID uint `json:"id" gorm:"primarykey"`
 	CreateCatDTO
+	BreedID   string         `json:"breed_id" gorm:"index"`
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
 
-	Mission *Mission `json:"mission,omitempty" gorm:"foreignkey:CatID"`
+	// Mission is the open mission of the cat, if it has one
+	Mission  *Mission    `json:"mission,omitempty" gorm:"foreignkey:CatID"`
+	Salaries []CatSalary `json:"-" gorm:"foreignkey:CatID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+// CatSalary is one entry of a cat's salary timeline. Salaries are annual and
+// apply from the day of EffectiveFrom until the next entry takes over.
+type CatSalary struct {
+	ID            uint      `json:"id" gorm:"primarykey"`
+	CatID         uint      `json:"cat_id" gorm:"not null;index"`
+	Salary        float64   `json:"salary" gorm:"not null"`
+	EffectiveFrom time.Time `json:"effective_from" gorm:"not null"`
+	Actor         string    `json:"actor" gorm:"not null"`
+	CreatedAt     time.Time `json:"created_at"`
 }
 
 type CreateCatDTO struct {
@@ -25,4 +39,41 @@ type CreateCatDTO struct {
 
 type UpdateCatDTO struct {
 	Salary float64 `json:"salary" binding:"required,min=0"`
+	// EffectiveFrom backdates the change, defaults to now and cannot be in
+	// the future or before the cat's latest salary change
+	EffectiveFrom *time.Time `json:"effective_from"`
+}
+
+type DeleteCatQuery struct {
+	// Force unassigns the cat from its active mission instead of refusing
+	Force bool `form:"force"`
+}
+
+type CatFilter struct {
+	PageQuery
+	Breed            string   `form:"breed"`
+	MinExperience    *int     `form:"min_experience" binding:"omitempty,min=0"`
+	MaxExperience    *int     `form:"max_experience" binding:"omitempty,min=0"`
+	MinSalary        *float64 `form:"min_salary" binding:"omitempty,min=0"`
+	MaxSalary        *float64 `form:"max_salary" binding:"omitempty,min=0"`
+	HasActiveMission *bool    `form:"has_active_mission"`
+}
+
+// CatProfile is what a field cat sees of itself, without its salary.
+type CatProfile struct {
+	ID              uint   `json:"id"`
+	Name            string `json:"name"`
+	YearsExperience int    `json:"years_experience"`
+	Breed           string `json:"breed"`
+	BreedID         string `json:"breed_id"`
+}
+
+func NewCatProfile(cat *Cat) *CatProfile {
+	return &CatProfile{
+		ID:              cat.ID,
+		Name:            cat.Name,
+		YearsExperience: cat.YearsExperience,
+		Breed:           cat.Breed,
+		BreedID:         cat.BreedID,
+	}
 }
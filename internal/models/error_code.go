@@ -0,0 +1,38 @@
+package models
+
+import "spy-cat-agency/pkg/custerr"
+
+// Error codes of the agency's business rules, returned in the code field of
+// error responses. They are part of the API and must not change.
+const (
+	ErrCodeValidationFailed        custerr.Code = "VALIDATION_FAILED"
+	ErrCodeMalformedRequest        custerr.Code = "MALFORMED_REQUEST"
+	ErrCodePayloadTooLarge         custerr.Code = "PAYLOAD_TOO_LARGE"
+	ErrCodeInvalidID               custerr.Code = "INVALID_ID"
+	ErrCodeInvalidSort             custerr.Code = "INVALID_SORT"
+	ErrCodeInvalidDateRange        custerr.Code = "INVALID_DATE_RANGE"
+	ErrCodeInvalidBreed            custerr.Code = "INVALID_CAT_BREED"
+	ErrCodeInvalidEffectiveFrom    custerr.Code = "INVALID_EFFECTIVE_FROM"
+	ErrCodeInvalidImport           custerr.Code = "INVALID_IMPORT"
+	ErrCodeInvalidCredentials      custerr.Code = "INVALID_CREDENTIALS"
+	ErrCodeMissingCredentials      custerr.Code = "MISSING_CREDENTIALS"
+	ErrCodeNotOwnMission           custerr.Code = "NOT_OWN_MISSION"
+	ErrCodeNoActiveMission         custerr.Code = "NO_ACTIVE_MISSION"
+	ErrCodeCatAlreadyOnMission     custerr.Code = "CAT_ALREADY_ON_MISSION"
+	ErrCodeCatAlreadyAssigned      custerr.Code = "CAT_ALREADY_ASSIGNED"
+	ErrCodeCatOnActiveMission      custerr.Code = "CAT_ON_ACTIVE_MISSION"
+	ErrCodeMissionTargetLimit      custerr.Code = "MISSION_TARGET_LIMIT"
+	ErrCodeMissionCompleted        custerr.Code = "MISSION_COMPLETED"
+	ErrCodeMissionTargetsOpen      custerr.Code = "MISSION_TARGETS_OPEN"
+	ErrCodeMissionTargetsTerminal  custerr.Code = "MISSION_TARGETS_TERMINAL"
+	ErrCodeMissionAssigned         custerr.Code = "MISSION_ASSIGNED"
+	ErrCodeMissionUnassigned       custerr.Code = "MISSION_UNASSIGNED"
+	ErrCodeTargetTerminal          custerr.Code = "TARGET_TERMINAL"
+	ErrCodeInvalidStatusTransition custerr.Code = "INVALID_STATUS_TRANSITION"
+	ErrCodeParentDeleted           custerr.Code = "PARENT_DELETED"
+)
+
+// Deprecated: ErrCodeTargetNotInMission is no longer returned. A target
+// addressed through another mission is reported as NOT_FOUND (404), like any
+// other missing target. The code stays reserved.
+const ErrCodeTargetNotInMission custerr.Code = "TARGET_NOT_IN_MISSION"
@@ -8,14 +8,25 @@ import (
 
 type Mission struct {
 	ID        uint           `json:"id" gorm:"primarykey"`
-	CatID     *uint          `json:"cat_id" gorm:"index"`
+	CatID     *uint          `json:"cat_id" gorm:"index;uniqueIndex:idx_missions_active_cat_id,where:complete = false AND deleted_at IS NULL"`
 	Complete  bool           `json:"complete" gorm:"default:false"`
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
 
-	Cat     *Cat     `json:"cat,omitempty" gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
-	Targets []Target `json:"targets" gorm:"foreignkey:MissionID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Cat         *Cat                `json:"cat,omitempty" gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	Targets     []Target            `json:"targets" gorm:"foreignkey:MissionID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Assignments []MissionAssignment `json:"-" gorm:"foreignkey:MissionID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+type MissionAssignment struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	MissionID uint      `json:"mission_id" gorm:"not null;index"`
+	FromCatID *uint     `json:"from_cat_id"`
+	ToCatID   *uint     `json:"to_cat_id"`
+	Reason    string    `json:"reason"`
+	Actor     string    `json:"actor" gorm:"not null"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
 type CreateMissionDTO struct {
@@ -24,4 +35,23 @@ type CreateMissionDTO struct {
 
 type UpdateMissionDTO struct {
 	Complete *bool `json:"complete"`
+	// Force completes the mission even if some targets are not terminal yet,
+	// or reopens it even if all of them are
+	Force bool `json:"force"`
+}
+
+type MissionAssignmentDTO struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+type MissionFilter struct {
+	PageQuery
+	Complete *bool  `form:"complete"`
+	CatID    *uint  `form:"cat_id"`
+	Country  string `form:"country"`
+}
+
+type CatMissionFilter struct {
+	PageQuery
+	Complete *bool `form:"complete"`
 }
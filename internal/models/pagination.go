@@ -0,0 +1,62 @@
+package models
+
+const (
+	DefaultPerPage = 20
+	MaxPerPage     = 100
+)
+
+type PageQuery struct {
+	Page    int    `form:"page" binding:"omitempty,min=1"`
+	PerPage int    `form:"per_page" binding:"omitempty,min=1,max=100"`
+	Sort    string `form:"sort"`
+}
+
+func (q PageQuery) PageNumber() int {
+	if q.Page < 1 {
+		return 1
+	}
+	return q.Page
+}
+
+func (q PageQuery) Limit() int {
+	if q.PerPage < 1 {
+		return DefaultPerPage
+	}
+	if q.PerPage > MaxPerPage {
+		return MaxPerPage
+	}
+	return q.PerPage
+}
+
+func (q PageQuery) Offset() int {
+	return (q.PageNumber() - 1) * q.Limit()
+}
+
+type PageMeta struct {
+	Page       int   `json:"page"`
+	PerPage    int   `json:"per_page"`
+	Total      int64 `json:"total"`
+	TotalPages int   `json:"total_pages"`
+}
+
+type Page[T any] struct {
+	Items []T      `json:"items"`
+	Meta  PageMeta `json:"meta"`
+}
+
+func NewPage[T any](items []T, query PageQuery, total int64) *Page[T] {
+	if items == nil {
+		items = []T{}
+	}
+
+	perPage := query.Limit()
+	return &Page[T]{
+		Items: items,
+		Meta: PageMeta{
+			Page:       query.PageNumber(),
+			PerPage:    perPage,
+			Total:      total,
+			TotalPages: int((total + int64(perPage) - 1) / int64(perPage)),
+		},
+	}
+}
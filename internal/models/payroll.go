@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+// PayrollDateFormat is the day format payroll periods are given and
+// reported in.
+const PayrollDateFormat = "2006-01-02"
+
+type PayrollQuery struct {
+	// From and To are the first and last day of the period, both inclusive
+	From *time.Time `form:"from" binding:"required" time_format:"2006-01-02" time_utc:"1"`
+	To   *time.Time `form:"to" binding:"required" time_format:"2006-01-02" time_utc:"1"`
+}
+
+// PayrollReport is what every cat was owed over a period. Annual salaries
+// are prorated per day over a 365 day year.
+type PayrollReport struct {
+	From  string       `json:"from"`
+	To    string       `json:"to"`
+	Days  int          `json:"days"`
+	Total float64      `json:"total"`
+	Cats  []CatPayroll `json:"cats"`
+}
+
+type CatPayroll struct {
+	CatID   uint            `json:"cat_id"`
+	Name    string          `json:"name"`
+	Deleted bool            `json:"deleted"`
+	Days    int             `json:"days"`
+	Owed    float64         `json:"owed"`
+	Periods []PayrollPeriod `json:"periods"`
+}
+
+// PayrollPeriod is a stretch of days within the report paid at one salary.
+type PayrollPeriod struct {
+	From   string  `json:"from"`
+	To     string  `json:"to"`
+	Salary float64 `json:"salary"`
+	Days   int     `json:"days"`
+	Amount float64 `json:"amount"`
+}
@@ -0,0 +1,58 @@
+package models
+
+import "time"
+
+type StatsFilter struct {
+	From *time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To   *time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	// DateField is the timestamp from and to apply to, defaults to created_at
+	DateField string `form:"date_field" binding:"omitempty,oneof=created_at updated_at" enums:"created_at,updated_at"`
+}
+
+type PayrollStats struct {
+	Cats    int64   `json:"cats"`
+	Total   float64 `json:"total"`
+	Average float64 `json:"average"`
+}
+
+type MissionStats struct {
+	Total          int64   `json:"total"`
+	Open           int64   `json:"open"`
+	Complete       int64   `json:"complete"`
+	Unassigned     int64   `json:"unassigned"`
+	AverageTargets float64 `json:"average_targets"`
+	CompletionRate float64 `json:"completion_rate"`
+}
+
+type StatsOverview struct {
+	Payroll  PayrollStats `json:"payroll"`
+	Missions MissionStats `json:"missions"`
+	IdleCats int64        `json:"idle_cats"`
+}
+
+// CatCompletionStats counts the missions a cat was ever assigned to, and as
+// completed those it finished itself.
+type CatCompletionStats struct {
+	CatID          uint    `json:"cat_id"`
+	Name           string  `json:"name"`
+	Missions       int64   `json:"missions"`
+	Completed      int64   `json:"completed"`
+	CompletionRate float64 `json:"completion_rate"`
+}
+
+type CountryStats struct {
+	Country  string `json:"country"`
+	Targets  int64  `json:"targets"`
+	Complete int64  `json:"complete"`
+}
+
+// IdleCat is a cat without an active mission. LastMissionAt is when its
+// latest mission last changed, nil if it never had one.
+type IdleCat struct {
+	ID              uint       `json:"id"`
+	Name            string     `json:"name"`
+	Breed           string     `json:"breed"`
+	YearsExperience int        `json:"years_experience"`
+	Salary          float64    `json:"salary"`
+	LastMissionAt   *time.Time `json:"last_mission_at"`
+}
@@ -1,11 +1,47 @@
 package models
 
 import (
+	"slices"
+	"spy-cat-agency/pkg/textdiff"
 	"time"
 
 	"gorm.io/gorm"
 )
 
+type TargetStatus string
+
+const (
+	TargetStatusPending           TargetStatus = "pending"
+	TargetStatusUnderSurveillance TargetStatus = "under_surveillance"
+	TargetStatusCompromised       TargetStatus = "compromised"
+	TargetStatusEscaped           TargetStatus = "escaped"
+	TargetStatusNeutralized       TargetStatus = "neutralized"
+)
+
+// targetTransitions lists the statuses each status may move to. Escaped and
+// neutralized are terminal and have no outgoing transitions.
+var targetTransitions = map[TargetStatus][]TargetStatus{
+	TargetStatusPending:           {TargetStatusUnderSurveillance, TargetStatusEscaped, TargetStatusNeutralized},
+	TargetStatusUnderSurveillance: {TargetStatusCompromised, TargetStatusEscaped, TargetStatusNeutralized},
+	TargetStatusCompromised:       {TargetStatusUnderSurveillance, TargetStatusEscaped, TargetStatusNeutralized},
+	TargetStatusEscaped:           {},
+	TargetStatusNeutralized:       {},
+}
+
+func (s TargetStatus) IsValid() bool {
+	_, ok := targetTransitions[s]
+	return ok
+}
+
+func (s TargetStatus) IsTerminal() bool {
+	next, ok := targetTransitions[s]
+	return ok && len(next) == 0
+}
+
+func (s TargetStatus) CanTransitionTo(next TargetStatus) bool {
+	return slices.Contains(targetTransitions[s], next)
+}
+
 type Target struct {
 	ID        uint           `json:"id" gorm:"primarykey"`
 	MissionID uint           `json:"mission_id" gorm:"not null;index"`
@@ -13,11 +49,42 @@ type Target struct {
 	Country   string         `json:"country" gorm:"not null" binding:"required"`
 	Notes     string         `json:"notes"`
 	Complete  bool           `json:"complete" gorm:"default:false"`
+	Status    TargetStatus   `json:"status" gorm:"not null;default:pending"`
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
 
-	Mission Mission `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Mission     Mission              `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Transitions []TargetTransition   `json:"transitions,omitempty" gorm:"foreignkey:TargetID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Revisions   []TargetNoteRevision `json:"-" gorm:"foreignkey:TargetID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+type TargetTransition struct {
+	ID        uint         `json:"id" gorm:"primarykey"`
+	TargetID  uint         `json:"target_id" gorm:"not null;index"`
+	From      TargetStatus `json:"from" gorm:"column:from_status;not null"`
+	To        TargetStatus `json:"to" gorm:"column:to_status;not null"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+type TargetNoteRevision struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	TargetID  uint      `json:"target_id" gorm:"not null;index"`
+	Author    string    `json:"author" gorm:"not null"`
+	Notes     string    `json:"notes"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type TargetNotesDiff struct {
+	TargetID uint            `json:"target_id"`
+	From     uint            `json:"from"`
+	To       uint            `json:"to"`
+	Lines    []textdiff.Line `json:"lines"`
+}
+
+type TargetNotesDiffQuery struct {
+	From uint `form:"from" binding:"required"`
+	To   uint `form:"to" binding:"required"`
 }
 
 type CreateTargetDTO struct {
@@ -27,6 +94,8 @@ type CreateTargetDTO struct {
 }
 
 type UpdateTargetDTO struct {
-	Notes    *string `json:"notes"`
-	Complete *bool   `json:"complete"`
+	Notes  *string       `json:"notes"`
+	Status *TargetStatus `json:"status" binding:"omitempty,oneof=pending under_surveillance compromised escaped neutralized" enums:"pending,under_surveillance,compromised,escaped,neutralized"`
+	// Complete is a shorthand for moving the target to neutralized
+	Complete *bool `json:"complete"`
 }
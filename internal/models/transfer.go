@@ -0,0 +1,51 @@
+package models
+
+// TransferFormat is the file format of bulk imports and exports.
+type TransferFormat string
+
+const (
+	TransferFormatJSON TransferFormat = "json"
+	TransferFormatCSV  TransferFormat = "csv"
+)
+
+// MaxImportRows caps a single import, which runs in one transaction.
+const MaxImportRows = 1000
+
+type TransferQuery struct {
+	// Format defaults to the request content type for imports and to json
+	// for exports
+	Format TransferFormat `form:"format" binding:"omitempty,oneof=json csv" enums:"json,csv"`
+}
+
+type CatExportFilter struct {
+	TransferQuery
+	Breed            string `form:"breed"`
+	HasActiveMission *bool  `form:"has_active_mission"`
+}
+
+type MissionExportFilter struct {
+	TransferQuery
+	Complete *bool  `form:"complete"`
+	CatID    *uint  `form:"cat_id"`
+	Country  string `form:"country"`
+}
+
+// MissionRecord is a mission with its targets as imported and exported. An
+// imported mission is open and, if CatID is set, assigned to that cat.
+type MissionRecord struct {
+	CatID   *uint             `json:"cat_id,omitempty"`
+	Targets []CreateTargetDTO `json:"targets"`
+}
+
+// ImportRowError points at an invalid row, counted from 1 for JSON arrays
+// and as the line number for CSV files.
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+type ImportResult struct {
+	Created int    `json:"created"`
+	IDs     []uint `json:"ids"`
+}
@@ -0,0 +1,7 @@
+package models
+
+type PurgeResult struct {
+	Cats     int64 `json:"cats"`
+	Missions int64 `json:"missions"`
+	Targets  int64 `json:"targets"`
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/pkg/custerr"
+
+	"gorm.io/gorm"
+)
+
+type AuditRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditRepository(db *gorm.DB) *AuditRepository {
+	return &AuditRepository{db: db}
+}
+
+func (r *AuditRepository) Create(entry *models.AuditLog) error {
+	if err := r.db.Create(entry).Error; err != nil {
+		return custerr.Classify(err)
+	}
+	return nil
+}
+
+var auditSortColumns = map[string]string{
+	"id":         "id",
+	"actor":      "actor",
+	"created_at": "created_at",
+}
+
+func (r *AuditRepository) GetAll(filter models.AuditFilter) ([]models.AuditLog, int64, error) {
+	if filter.Sort == "" {
+		filter.Sort = "-created_at"
+	}
+	order, err := orderBy(filter.Sort, auditSortColumns, "audit_logs")
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var total int64
+	if err := r.db.Model(&models.AuditLog{}).Scopes(auditFilter(filter)).Count(&total).Error; err != nil {
+		return nil, 0, custerr.Classify(err)
+	}
+
+	var entries []models.AuditLog
+	err = r.db.Scopes(auditFilter(filter), paginate(filter.PageQuery)).
+		Clauses(order).
+		Find(&entries).Error
+	if err != nil {
+		return nil, 0, custerr.Classify(err)
+	}
+	return entries, total, nil
+}
+
+func auditFilter(filter models.AuditFilter) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if filter.EntityType != "" {
+			db = db.Where("audit_logs.entity_type = ?", filter.EntityType)
+		}
+		if filter.EntityID != nil {
+			db = db.Where("audit_logs.entity_id = ?", *filter.EntityID)
+		}
+		if filter.Actor != "" {
+			db = db.Where("audit_logs.actor = ?", filter.Actor)
+		}
+		if filter.From != nil {
+			db = db.Where("audit_logs.created_at >= ?", *filter.From)
+		}
+		if filter.To != nil {
+			db = db.Where("audit_logs.created_at < ?", *filter.To)
+		}
+		return db
+	}
+}
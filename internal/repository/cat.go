@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"spy-cat-agency/internal/models"
 	"spy-cat-agency/pkg/custerr"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type CatRepository struct {
@@ -18,34 +20,107 @@ func NewCatRepository(db *gorm.DB) *CatRepository {
 
 func (r *CatRepository) Create(cat *models.Cat) error {
 	if err := r.db.Create(cat).Error; err != nil {
-		return custerr.NewInternalErr(err)
+		return custerr.Classify(err)
 	}
 	return nil
 }
 
-func (r *CatRepository) GetAll() ([]models.Cat, error) {
+var catSortColumns = map[string]string{
+	"id":               "id",
+	"name":             "name",
+	"breed":            "breed",
+	"years_experience": "years_experience",
+	"salary":           "salary",
+	"created_at":       "created_at",
+}
+
+func (r *CatRepository) GetAll(filter models.CatFilter) ([]models.Cat, int64, error) {
+	order, err := orderBy(filter.Sort, catSortColumns, "cats")
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var total int64
+	if err := r.db.Model(&models.Cat{}).Scopes(catFilter(filter)).Count(&total).Error; err != nil {
+		return nil, 0, custerr.Classify(err)
+	}
+
 	var cats []models.Cat
-	if err := r.db.Preload("Mission.Targets").Find(&cats).Error; err != nil {
-		return nil, custerr.NewInternalErr(err)
+	err = r.db.Scopes(preloadActiveMission).
+		Scopes(catFilter(filter), paginate(filter.PageQuery)).
+		Clauses(order).
+		Find(&cats).Error
+	if err != nil {
+		return nil, 0, custerr.Classify(err)
+	}
+	return cats, total, nil
+}
+
+// preloadActiveMission loads the open mission of a cat with its targets. The
+// has-one relation matches every mission the cat ever had, so completed ones
+// are left out.
+func preloadActiveMission(db *gorm.DB) *gorm.DB {
+	return db.Preload("Mission", "complete = ?", false).Preload("Mission.Targets")
+}
+
+func catFilter(filter models.CatFilter) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if filter.Breed != "" {
+			db = db.Where("(LOWER(cats.breed_id) = LOWER(?) OR LOWER(cats.breed) = LOWER(?))", filter.Breed, filter.Breed)
+		}
+		if filter.MinExperience != nil {
+			db = db.Where("cats.years_experience >= ?", *filter.MinExperience)
+		}
+		if filter.MaxExperience != nil {
+			db = db.Where("cats.years_experience <= ?", *filter.MaxExperience)
+		}
+		if filter.MinSalary != nil {
+			db = db.Where("cats.salary >= ?", *filter.MinSalary)
+		}
+		if filter.MaxSalary != nil {
+			db = db.Where("cats.salary <= ?", *filter.MaxSalary)
+		}
+		if filter.HasActiveMission != nil {
+			activeMission := "EXISTS (SELECT 1 FROM missions WHERE missions.cat_id = cats.id AND missions.complete = false AND missions.deleted_at IS NULL)"
+			if *filter.HasActiveMission {
+				db = db.Where(activeMission)
+			} else {
+				db = db.Where("NOT " + activeMission)
+			}
+		}
+		return db
 	}
-	return cats, nil
 }
 
 func (r *CatRepository) GetByID(id uint) (*models.Cat, error) {
 	var cat models.Cat
-	err := r.db.Preload("Mission.Targets").First(&cat, id).Error
+	err := r.db.Scopes(preloadActiveMission).First(&cat, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, custerr.NewNotFoundErr(fmt.Sprintf("no cat with id \"%d\"", id)).WithCause(err)
+		}
+		return nil, custerr.Classify(err)
+	}
+	return &cat, nil
+}
+
+// GetByIDForUpdate loads a cat without associations and locks its row until
+// the surrounding transaction ends.
+func (r *CatRepository) GetByIDForUpdate(id uint) (*models.Cat, error) {
+	var cat models.Cat
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&cat, id).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, custerr.NewNotFoundErr(fmt.Sprintf("no cat with id \"%d\"", id))
+			return nil, custerr.NewNotFoundErr(fmt.Sprintf("no cat with id \"%d\"", id)).WithCause(err)
 		}
-		return nil, custerr.NewInternalErr(err)
+		return nil, custerr.Classify(err)
 	}
 	return &cat, nil
 }
 
 func (r *CatRepository) Update(cat *models.Cat) error {
 	if err := r.db.Save(cat).Error; err != nil {
-		return custerr.NewInternalErr(err)
+		return custerr.Classify(err)
 	}
 	return nil
 }
@@ -53,7 +128,7 @@ func (r *CatRepository) Update(cat *models.Cat) error {
 func (r *CatRepository) Delete(id uint) error {
 	res := r.db.Delete(&models.Cat{}, id)
 	if res.Error != nil {
-		return custerr.NewInternalErr(res.Error)
+		return custerr.Classify(res.Error)
 	}
 
 	if res.RowsAffected == 0 {
@@ -61,3 +136,54 @@ func (r *CatRepository) Delete(id uint) error {
 	}
 	return nil
 }
+
+func (r *CatRepository) GetDeleted(query models.PageQuery) ([]models.Cat, int64, error) {
+	return getDeleted[models.Cat](r.db, "cats", query)
+}
+
+func (r *CatRepository) GetDeletedByID(id uint) (*models.Cat, error) {
+	return getDeletedByID[models.Cat](r.db, "cats", "cat", id)
+}
+
+func (r *CatRepository) Restore(id uint) error {
+	return restore[models.Cat](r.db, "cats", "cat", id)
+}
+
+func (r *CatRepository) Purge(before time.Time) (int64, error) {
+	return purge[models.Cat](r.db, "cats", before)
+}
+
+func (r *CatRepository) CreateSalary(salary *models.CatSalary) error {
+	if err := r.db.Create(salary).Error; err != nil {
+		return custerr.Classify(err)
+	}
+	return nil
+}
+
+// GetSalaries returns a cat's salary timeline, oldest first.
+func (r *CatRepository) GetSalaries(catID uint) ([]models.CatSalary, error) {
+	salaries := []models.CatSalary{}
+	if err := r.db.Where("cat_id = ?", catID).Order("effective_from, id").Find(&salaries).Error; err != nil {
+		return nil, custerr.Classify(err)
+	}
+	return salaries, nil
+}
+
+// GetPayrollCats returns the cats employed at some point before end that
+// were not deleted before start, deleted ones included, with the salaries
+// that took effect before end.
+func (r *CatRepository) GetPayrollCats(start, end time.Time) ([]models.Cat, error) {
+	cats := []models.Cat{}
+	err := r.db.Unscoped().
+		Preload("Salaries", func(db *gorm.DB) *gorm.DB {
+			return db.Where("effective_from < ?", end).Order("effective_from, id")
+		}).
+		Where("cats.deleted_at IS NULL OR cats.deleted_at >= ?", start).
+		Where("EXISTS (SELECT 1 FROM cat_salaries s WHERE s.cat_id = cats.id AND s.effective_from < ?)", end).
+		Order("cats.id").
+		Find(&cats).Error
+	if err != nil {
+		return nil, custerr.Classify(err)
+	}
+	return cats, nil
+}
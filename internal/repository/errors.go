@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"errors"
+	"spy-cat-agency/pkg/custerr"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const activeCatMissionIndex = "idx_missions_active_cat_id"
+
+func isPgError(err error, code, constraint string) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	return pgErr.Code == code && (constraint == "" || pgErr.ConstraintName == constraint)
+}
+
+func isUniqueViolation(err error, constraint string) bool {
+	return isPgError(err, custerr.PgUniqueViolation, constraint)
+}
+
+func isForeignKeyViolation(err error) bool {
+	return isPgError(err, custerr.PgForeignKeyViolation, "")
+}
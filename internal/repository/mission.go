@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"spy-cat-agency/internal/models"
 	"spy-cat-agency/pkg/custerr"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type MissionRepository struct {
@@ -18,39 +20,97 @@ func NewMissionRepository(db *gorm.DB) *MissionRepository {
 
 func (r *MissionRepository) Create(mission *models.Mission) error {
 	if err := r.db.Create(mission).Error; err != nil {
-		return custerr.NewInternalErr(err)
+		return custerr.Classify(err)
 	}
 	return nil
 }
 
-func (r *MissionRepository) GetAll() ([]models.Mission, error) {
+var missionSortColumns = map[string]string{
+	"id":         "id",
+	"cat_id":     "cat_id",
+	"complete":   "complete",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+}
+
+func (r *MissionRepository) GetAll(filter models.MissionFilter) ([]models.Mission, int64, error) {
+	order, err := orderBy(filter.Sort, missionSortColumns, "missions")
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var total int64
+	if err := r.db.Model(&models.Mission{}).Scopes(missionFilter(filter)).Count(&total).Error; err != nil {
+		return nil, 0, custerr.Classify(err)
+	}
+
 	var missions []models.Mission
-	if err := r.db.Preload("Cat").Preload("Targets").Find(&missions).Error; err != nil {
-		return nil, custerr.NewInternalErr(err)
+	err = r.db.Preload("Cat").Preload("Targets").
+		Scopes(missionFilter(filter), paginate(filter.PageQuery)).
+		Clauses(order).
+		Find(&missions).Error
+	if err != nil {
+		return nil, 0, custerr.Classify(err)
+	}
+	return missions, total, nil
+}
+
+func missionFilter(filter models.MissionFilter) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if filter.Complete != nil {
+			db = db.Where("missions.complete = ?", *filter.Complete)
+		}
+		if filter.CatID != nil {
+			db = db.Where("missions.cat_id = ?", *filter.CatID)
+		}
+		if filter.Country != "" {
+			db = db.Where(
+				"EXISTS (SELECT 1 FROM targets WHERE targets.mission_id = missions.id AND targets.deleted_at IS NULL AND LOWER(targets.country) = LOWER(?))",
+				filter.Country,
+			)
+		}
+		return db
 	}
-	return missions, nil
 }
 
 func (r *MissionRepository) GetByID(id uint) (*models.Mission, error) {
 	var mission models.Mission
-	err := r.db.Preload("Cat").Preload("Targets").First(&mission, id).Error
+	err := r.db.Preload("Cat").Preload("Targets.Transitions").First(&mission, id).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, custerr.NewNotFoundErr(fmt.Sprintf("no mission with id \"%d\"", id))
+			return nil, custerr.NewNotFoundErr(fmt.Sprintf("no mission with id \"%d\"", id)).WithCause(err)
 		}
-		return nil, custerr.NewInternalErr(err)
+		return nil, custerr.Classify(err)
+	}
+	return &mission, nil
+}
+
+// GetByIDForUpdate loads a mission without associations and locks its row
+// until the surrounding transaction ends.
+func (r *MissionRepository) GetByIDForUpdate(id uint) (*models.Mission, error) {
+	var mission models.Mission
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&mission, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, custerr.NewNotFoundErr(fmt.Sprintf("no mission with id \"%d\"", id)).WithCause(err)
+		}
+		return nil, custerr.Classify(err)
 	}
 	return &mission, nil
 }
 
 func (r *MissionRepository) Update(mission *models.Mission) error {
-	res := r.db.Model(&models.Mission{}).Where("id = ?", mission.ID).Updates(mission)
+	res := r.db.Model(&models.Mission{}).Where("id = ?", mission.ID).
+		Select("cat_id", "complete").Omit(clause.Associations).
+		Updates(mission)
 	if res.Error != nil {
-		switch res.Error {
-		case gorm.ErrForeignKeyViolated:
-			return custerr.NewNotFoundErr(fmt.Sprintf("no cat with id \"%d\"", *mission.CatID))
+		switch {
+		case isForeignKeyViolation(res.Error):
+			return custerr.NewNotFoundErr(fmt.Sprintf("no cat with id \"%d\"", *mission.CatID)).WithCause(res.Error)
+		case isUniqueViolation(res.Error, activeCatMissionIndex):
+			return custerr.NewConflictErr("cat already has an active mission").WithCode(models.ErrCodeCatAlreadyOnMission).WithCause(res.Error)
 		default:
-			return custerr.NewInternalErr(res.Error)
+			return custerr.Classify(res.Error)
 		}
 	}
 
@@ -64,11 +124,11 @@ func (r *MissionRepository) Update(mission *models.Mission) error {
 func (r *MissionRepository) Delete(id uint) error {
 	res := r.db.Delete(&models.Mission{}, id)
 	if res.Error != nil {
-		return custerr.NewInternalErr(res.Error)
+		return custerr.Classify(res.Error)
 	}
 
 	if res.RowsAffected == 0 {
-		return custerr.NewNotFoundErr(fmt.Sprintf("no cat with id \"%d\"", id))
+		return custerr.NewNotFoundErr(fmt.Sprintf("no mission with id \"%d\"", id))
 	}
 	return nil
 }
@@ -80,7 +140,44 @@ func (r *MissionRepository) GetActiveByCatID(catID uint) (*models.Mission, error
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
 		}
-		return nil, custerr.NewInternalErr(err)
+		return nil, custerr.Classify(err)
 	}
 	return &mission, nil
 }
+
+func (r *MissionRepository) CreateAssignment(assignment *models.MissionAssignment) error {
+	if err := r.db.Create(assignment).Error; err != nil {
+		return custerr.Classify(err)
+	}
+	return nil
+}
+
+func (r *MissionRepository) GetAssignments(missionID uint) ([]models.MissionAssignment, error) {
+	assignments := []models.MissionAssignment{}
+	if err := r.db.Where("mission_id = ?", missionID).Order("id").Find(&assignments).Error; err != nil {
+		return nil, custerr.Classify(err)
+	}
+	return assignments, nil
+}
+
+func (r *MissionRepository) GetDeleted(query models.PageQuery) ([]models.Mission, int64, error) {
+	return getDeleted[models.Mission](r.db, "missions", query)
+}
+
+func (r *MissionRepository) GetDeletedByID(id uint) (*models.Mission, error) {
+	return getDeletedByID[models.Mission](r.db, "missions", "mission", id)
+}
+
+// GetDeletedByIDForUpdate loads a deleted mission and locks its row until the
+// surrounding transaction ends.
+func (r *MissionRepository) GetDeletedByIDForUpdate(id uint) (*models.Mission, error) {
+	return getDeletedByID[models.Mission](r.db.Clauses(clause.Locking{Strength: "UPDATE"}), "missions", "mission", id)
+}
+
+func (r *MissionRepository) Restore(id uint) error {
+	return restore[models.Mission](r.db, "missions", "mission", id)
+}
+
+func (r *MissionRepository) Purge(before time.Time) (int64, error) {
+	return purge[models.Mission](r.db, "missions", before)
+}
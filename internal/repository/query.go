@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"fmt"
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/pkg/custerr"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// orderBy parses a comma separated sort parameter such as "-salary,name"
+// into an ORDER BY clause. A leading "-" sorts descending. Only fields
+// present in columns are accepted; the id column is always appended as a
+// tie-breaker so pages are stable.
+func orderBy(sort string, columns map[string]string, table string) (clause.OrderBy, error) {
+	var order clause.OrderBy
+	for _, field := range strings.Split(sort, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+
+		desc := strings.HasPrefix(field, "-")
+		column, ok := columns[strings.TrimPrefix(field, "-")]
+		if !ok {
+			return order, custerr.NewBadRequestErr(fmt.Sprintf("cannot sort by \"%s\"", strings.TrimPrefix(field, "-"))).WithCode(models.ErrCodeInvalidSort)
+		}
+
+		order.Columns = append(order.Columns, clause.OrderByColumn{
+			Column: clause.Column{Table: table, Name: column},
+			Desc:   desc,
+		})
+	}
+
+	order.Columns = append(order.Columns, clause.OrderByColumn{
+		Column: clause.Column{Table: table, Name: "id"},
+	})
+	return order, nil
+}
+
+func paginate(query models.PageQuery) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Limit(query.Limit()).Offset(query.Offset())
+	}
+}
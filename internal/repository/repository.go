@@ -5,15 +5,21 @@ import (
 )
 
 type Repository struct {
-	Cat     *CatRepository
-	Mission *MissionRepository
-	Target  *TargetRepository
+	Cat        *CatRepository
+	Mission    *MissionRepository
+	Target     *TargetRepository
+	Audit      *AuditRepository
+	Stats      *StatsRepository
+	UnitOfWork *UnitOfWork
 }
 
 func New(db *gorm.DB) *Repository {
 	return &Repository{
-		Cat:     NewCatRepository(db),
-		Mission: NewMissionRepository(db),
-		Target:  NewTargetRepository(db),
+		Cat:        NewCatRepository(db),
+		Mission:    NewMissionRepository(db),
+		Target:     NewTargetRepository(db),
+		Audit:      NewAuditRepository(db),
+		Stats:      NewStatsRepository(db),
+		UnitOfWork: NewUnitOfWork(db),
 	}
 }
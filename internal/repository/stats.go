@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/pkg/custerr"
+
+	"gorm.io/gorm"
+)
+
+// StatsRepository runs the aggregate queries behind the agency statistics.
+// Every query only counts rows whose filter.DateField lies in the range.
+type StatsRepository struct {
+	db *gorm.DB
+}
+
+func NewStatsRepository(db *gorm.DB) *StatsRepository {
+	return &StatsRepository{db: db}
+}
+
+func (r *StatsRepository) Payroll(filter models.StatsFilter) (*models.PayrollStats, error) {
+	where, args := statsRange("c", filter)
+
+	var stats models.PayrollStats
+	err := r.db.Raw(`SELECT COUNT(*) AS cats, COALESCE(SUM(c.salary), 0) AS total, COALESCE(AVG(c.salary), 0) AS average
+		FROM cats c
+		WHERE c.deleted_at IS NULL`+where, args...).
+		Scan(&stats).Error
+	if err != nil {
+		return nil, custerr.Classify(err)
+	}
+	return &stats, nil
+}
+
+func (r *StatsRepository) Missions(filter models.StatsFilter) (*models.MissionStats, error) {
+	where, args := statsRange("m", filter)
+
+	var stats models.MissionStats
+	err := r.db.Raw(`SELECT COUNT(*) AS total,
+			COUNT(*) FILTER (WHERE NOT s.complete) AS open,
+			COUNT(*) FILTER (WHERE s.complete) AS complete,
+			COUNT(*) FILTER (WHERE NOT s.complete AND s.cat_id IS NULL) AS unassigned,
+			COALESCE(AVG(s.targets), 0) AS average_targets
+		FROM (
+			SELECT m.complete, m.cat_id,
+				(SELECT COUNT(*) FROM targets t WHERE t.mission_id = m.id AND t.deleted_at IS NULL) AS targets
+			FROM missions m
+			WHERE m.deleted_at IS NULL`+where+`
+		) s`, args...).
+		Scan(&stats).Error
+	if err != nil {
+		return nil, custerr.Classify(err)
+	}
+	return &stats, nil
+}
+
+// CatCompletion counts every mission a cat was ever assigned to, including
+// those it was reassigned off, and as completed the ones it finished itself.
+func (r *StatsRepository) CatCompletion(filter models.StatsFilter) ([]models.CatCompletionStats, error) {
+	where, args := statsRange("m", filter)
+
+	stats := []models.CatCompletionStats{}
+	err := r.db.Raw(`SELECT c.id AS cat_id, c.name,
+			COUNT(m.id) AS missions,
+			COUNT(m.id) FILTER (WHERE m.complete AND m.cat_id = c.id) AS completed
+		FROM cats c
+		JOIN missions m ON m.deleted_at IS NULL
+			AND (m.cat_id = c.id OR EXISTS (SELECT 1 FROM mission_assignments a WHERE a.mission_id = m.id AND a.to_cat_id = c.id))`+where+`
+		WHERE c.deleted_at IS NULL
+		GROUP BY c.id, c.name
+		ORDER BY completed DESC, c.id`, args...).
+		Scan(&stats).Error
+	if err != nil {
+		return nil, custerr.Classify(err)
+	}
+	return stats, nil
+}
+
+func (r *StatsRepository) TargetsByCountry(filter models.StatsFilter) ([]models.CountryStats, error) {
+	where, args := statsRange("t", filter)
+
+	stats := []models.CountryStats{}
+	err := r.db.Raw(`SELECT t.country, COUNT(*) AS targets, COUNT(*) FILTER (WHERE t.complete) AS complete
+		FROM targets t
+		JOIN missions m ON m.id = t.mission_id AND m.deleted_at IS NULL
+		WHERE t.deleted_at IS NULL`+where+`
+		GROUP BY t.country
+		ORDER BY targets DESC, t.country`, args...).
+		Scan(&stats).Error
+	if err != nil {
+		return nil, custerr.Classify(err)
+	}
+	return stats, nil
+}
+
+// idleCat selects the live cats aliased as c without an open mission.
+const idleCat = `c.deleted_at IS NULL
+			AND NOT EXISTS (SELECT 1 FROM missions m WHERE m.cat_id = c.id AND NOT m.complete AND m.deleted_at IS NULL)`
+
+func (r *StatsRepository) IdleCats(filter models.StatsFilter) ([]models.IdleCat, error) {
+	where, args := statsRange("c", filter)
+
+	cats := []models.IdleCat{}
+	err := r.db.Raw(`SELECT c.id, c.name, c.breed, c.years_experience, c.salary,
+			(SELECT MAX(m.updated_at) FROM missions m WHERE m.cat_id = c.id AND m.deleted_at IS NULL) AS last_mission_at
+		FROM cats c
+		WHERE `+idleCat+where+`
+		ORDER BY c.id`, args...).
+		Scan(&cats).Error
+	if err != nil {
+		return nil, custerr.Classify(err)
+	}
+	return cats, nil
+}
+
+func (r *StatsRepository) CountIdleCats(filter models.StatsFilter) (int64, error) {
+	where, args := statsRange("c", filter)
+
+	var count int64
+	err := r.db.Raw(`SELECT COUNT(*)
+		FROM cats c
+		WHERE `+idleCat+where, args...).
+		Scan(&count).Error
+	if err != nil {
+		return 0, custerr.Classify(err)
+	}
+	return count, nil
+}
+
+// statsRange builds the date range condition for the table aliased as
+// alias. The column comes from a validated set, never from raw input.
+func statsRange(alias string, filter models.StatsFilter) (string, []any) {
+	column := alias + ".created_at"
+	if filter.DateField == "updated_at" {
+		column = alias + ".updated_at"
+	}
+
+	var where string
+	var args []any
+	if filter.From != nil {
+		where += " AND " + column + " >= ?"
+		args = append(args, *filter.From)
+	}
+	if filter.To != nil {
+		where += " AND " + column + " < ?"
+		args = append(args, *filter.To)
+	}
+	return where, args
+}
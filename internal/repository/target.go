@@ -1,10 +1,13 @@
 package repository
 
 import (
+	"fmt"
 	"spy-cat-agency/internal/models"
 	"spy-cat-agency/pkg/custerr"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type TargetRepository struct {
@@ -17,30 +20,117 @@ func NewTargetRepository(db *gorm.DB) *TargetRepository {
 
 func (r *TargetRepository) Create(target *models.Target) error {
 	if err := r.db.Create(target).Error; err != nil {
-		return custerr.NewInternalErr(err)
+		return custerr.Classify(err)
 	}
 	return nil
 }
 
+func (r *TargetRepository) CreateTransition(transition *models.TargetTransition) error {
+	if err := r.db.Create(transition).Error; err != nil {
+		return custerr.Classify(err)
+	}
+	return nil
+}
+
+func (r *TargetRepository) CreateNoteRevision(revision *models.TargetNoteRevision) error {
+	if err := r.db.Create(revision).Error; err != nil {
+		return custerr.Classify(err)
+	}
+	return nil
+}
+
+func (r *TargetRepository) GetNoteRevisions(targetID uint) ([]models.TargetNoteRevision, error) {
+	revisions := []models.TargetNoteRevision{}
+	if err := r.db.Where("target_id = ?", targetID).Order("id").Find(&revisions).Error; err != nil {
+		return nil, custerr.Classify(err)
+	}
+	return revisions, nil
+}
+
+func (r *TargetRepository) GetNoteRevision(targetID, revisionID uint) (*models.TargetNoteRevision, error) {
+	var revision models.TargetNoteRevision
+	err := r.db.Where("target_id = ?", targetID).First(&revision, revisionID).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, custerr.NewNotFoundErr(fmt.Sprintf("no note revision with id \"%d\" for target \"%d\"", revisionID, targetID)).WithCause(err)
+		}
+		return nil, custerr.Classify(err)
+	}
+	return &revision, nil
+}
+
 func (r *TargetRepository) GetByID(id uint) (*models.Target, error) {
 	var target models.Target
 	err := r.db.First(&target, id).Error
 	if err != nil {
-		return nil, err
+		if err == gorm.ErrRecordNotFound {
+			return nil, custerr.NewNotFoundErr(fmt.Sprintf("no target with id \"%d\"", id)).WithCause(err)
+		}
+		return nil, custerr.Classify(err)
+	}
+	return &target, nil
+}
+
+// GetByMissionAndID loads a target only if it belongs to the mission, so a
+// target of another mission is as missing as one that does not exist.
+func (r *TargetRepository) GetByMissionAndID(missionID, id uint) (*models.Target, error) {
+	var target models.Target
+	err := r.db.Where("mission_id = ?", missionID).First(&target, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, custerr.NewNotFoundErr(fmt.Sprintf("no target with id \"%d\" in mission \"%d\"", id, missionID)).WithCause(err)
+		}
+		return nil, custerr.Classify(err)
 	}
 	return &target, nil
 }
 
 func (r *TargetRepository) Update(target *models.Target) error {
-	return r.db.Save(target).Error
+	res := r.db.Model(&models.Target{}).Where("id = ?", target.ID).
+		Select("notes", "complete", "status").Omit(clause.Associations).
+		Updates(target)
+	if res.Error != nil {
+		return custerr.Classify(res.Error)
+	}
+
+	if res.RowsAffected == 0 {
+		return custerr.NewNotFoundErr(fmt.Sprintf("no target with id \"%d\"", target.ID))
+	}
+	return nil
 }
 
 func (r *TargetRepository) Delete(id uint) error {
-	return r.db.Delete(&models.Target{}, id).Error
+	res := r.db.Delete(&models.Target{}, id)
+	if res.Error != nil {
+		return custerr.Classify(res.Error)
+	}
+
+	if res.RowsAffected == 0 {
+		return custerr.NewNotFoundErr(fmt.Sprintf("no target with id \"%d\"", id))
+	}
+	return nil
 }
 
 func (r *TargetRepository) CountByMissionID(missionID uint) (int64, error) {
 	var count int64
-	err := r.db.Model(&models.Target{}).Where("mission_id = ?", missionID).Count(&count).Error
-	return count, err
+	if err := r.db.Model(&models.Target{}).Where("mission_id = ?", missionID).Count(&count).Error; err != nil {
+		return 0, custerr.Classify(err)
+	}
+	return count, nil
+}
+
+func (r *TargetRepository) GetDeleted(query models.PageQuery) ([]models.Target, int64, error) {
+	return getDeleted[models.Target](r.db, "targets", query)
+}
+
+func (r *TargetRepository) GetDeletedByID(id uint) (*models.Target, error) {
+	return getDeletedByID[models.Target](r.db, "targets", "target", id)
+}
+
+func (r *TargetRepository) Restore(id uint) error {
+	return restore[models.Target](r.db, "targets", "target", id)
+}
+
+func (r *TargetRepository) Purge(before time.Time) (int64, error) {
+	return purge[models.Target](r.db, "targets", before)
 }
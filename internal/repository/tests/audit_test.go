@@ -0,0 +1,65 @@
+package tests
+
+import (
+	"encoding/json"
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/internal/repository"
+	"spy-cat-agency/pkg/custerr"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditRepository(t *testing.T) {
+	db := newTestDB(t)
+	auditRepo := repository.NewAuditRepository(db)
+	created := time.Now().Add(-2 * time.Hour)
+	entries := []*models.AuditLog{
+		{Actor: "admin", Action: models.AuditActionCreate, EntityType: models.AuditEntityCat, EntityID: 1, After: json.RawMessage(`{"name": "Whiskers"}`), CreatedAt: created},
+		{Actor: "admin", Action: models.AuditActionUpdate, EntityType: models.AuditEntityCat, EntityID: 1, Before: json.RawMessage(`{"salary": 1000}`), After: json.RawMessage(`{"salary": 2000}`), CreatedAt: created.Add(time.Hour)},
+		{Actor: "whiskers", Action: models.AuditActionUpdate, EntityType: models.AuditEntityTarget, EntityID: 1, CreatedAt: created.Add(2 * time.Hour)},
+	}
+	for _, entry := range entries {
+		require.NoError(t, auditRepo.Create(entry))
+	}
+
+	t.Run("newest first by default", func(t *testing.T) {
+		result, total, err := auditRepo.GetAll(models.AuditFilter{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), total)
+		require.Len(t, result, 3)
+		assert.Equal(t, entries[2].ID, result[0].ID)
+		assert.Equal(t, entries[0].ID, result[2].ID)
+		assert.JSONEq(t, `{"salary": 1000}`, string(result[1].Before))
+	})
+
+	t.Run("filters by entity and actor", func(t *testing.T) {
+		entityID := uint(1)
+
+		result, total, err := auditRepo.GetAll(models.AuditFilter{EntityType: models.AuditEntityCat, EntityID: &entityID, Actor: "admin"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), total)
+		assert.Len(t, result, 2)
+	})
+
+	t.Run("filters by time range", func(t *testing.T) {
+		from, to := created.Add(30*time.Minute), created.Add(90*time.Minute)
+
+		result, total, err := auditRepo.GetAll(models.AuditFilter{From: &from, To: &to})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+		require.Len(t, result, 1)
+		assert.Equal(t, entries[1].ID, result[0].ID)
+	})
+
+	t.Run("unknown sort field", func(t *testing.T) {
+		_, _, err := auditRepo.GetAll(models.AuditFilter{PageQuery: models.PageQuery{Sort: "before"}})
+
+		assert.IsType(t, custerr.BadRequestErr{}, err)
+	})
+}
@@ -0,0 +1,374 @@
+package tests
+
+import (
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/internal/repository"
+	"spy-cat-agency/pkg/custerr"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestCatRepository_Create(t *testing.T) {
+	db := newTestDB(t)
+	catRepo := repository.NewCatRepository(db)
+
+	t.Run("success", func(t *testing.T) {
+		cat := &models.Cat{CreateCatDTO: models.CreateCatDTO{Name: "Whiskers", YearsExperience: 5, Breed: "Siamese", Salary: 5000}, BreedID: "siam"}
+
+		err := catRepo.Create(cat)
+
+		assert.NoError(t, err)
+		assert.NotZero(t, cat.ID)
+		result, err := catRepo.GetByID(cat.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "siam", result.BreedID)
+	})
+
+	t.Run("check constraint", func(t *testing.T) {
+		err := catRepo.Create(&models.Cat{CreateCatDTO: models.CreateCatDTO{Name: "Broke", YearsExperience: 1, Breed: "Siamese", Salary: -1}})
+
+		assert.IsType(t, custerr.BadRequestErr{}, err)
+		assert.Equal(t, custerr.CodeConstraintViolation, err.(custerr.Error).Code())
+	})
+}
+
+func TestCatRepository_GetAll(t *testing.T) {
+	db := newTestDB(t)
+	catRepo := repository.NewCatRepository(db)
+	whiskers := createCat(t, db, "Whiskers")
+	shadow := createCat(t, db, "Shadow")
+	require.NoError(t, db.Model(shadow).Updates(map[string]any{"breed": "Bengal", "breed_id": "beng", "salary": 3000, "years_experience": 8}).Error)
+	deleted := createCat(t, db, "Ghost")
+	require.NoError(t, catRepo.Delete(deleted.ID))
+	createMission(t, db, &whiskers.ID, "Target Alpha")
+
+	t.Run("excludes deleted cats", func(t *testing.T) {
+		result, total, err := catRepo.GetAll(models.CatFilter{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), total)
+		assert.Len(t, result, 2)
+	})
+
+	t.Run("preloads active mission with targets", func(t *testing.T) {
+		result, _, err := catRepo.GetAll(models.CatFilter{PageQuery: models.PageQuery{Sort: "name"}})
+
+		assert.NoError(t, err)
+		require.Len(t, result, 2)
+		assert.Equal(t, "Shadow", result[0].Name)
+		assert.Nil(t, result[0].Mission)
+		require.NotNil(t, result[1].Mission)
+		assert.Len(t, result[1].Mission.Targets, 1)
+	})
+
+	t.Run("filters by breed name or id", func(t *testing.T) {
+		byName, _, err := catRepo.GetAll(models.CatFilter{Breed: "bengal"})
+		assert.NoError(t, err)
+		byID, _, err := catRepo.GetAll(models.CatFilter{Breed: "BENG"})
+		assert.NoError(t, err)
+
+		require.Len(t, byName, 1)
+		require.Len(t, byID, 1)
+		assert.Equal(t, shadow.ID, byName[0].ID)
+		assert.Equal(t, shadow.ID, byID[0].ID)
+	})
+
+	t.Run("filters by ranges", func(t *testing.T) {
+		minExperience, maxSalary := 5, 4000.0
+
+		result, total, err := catRepo.GetAll(models.CatFilter{MinExperience: &minExperience, MaxSalary: &maxSalary})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+		require.Len(t, result, 1)
+		assert.Equal(t, shadow.ID, result[0].ID)
+	})
+
+	t.Run("filters by active mission", func(t *testing.T) {
+		active, idle := true, false
+
+		withMission, _, err := catRepo.GetAll(models.CatFilter{HasActiveMission: &active})
+		assert.NoError(t, err)
+		withoutMission, _, err := catRepo.GetAll(models.CatFilter{HasActiveMission: &idle})
+		assert.NoError(t, err)
+
+		require.Len(t, withMission, 1)
+		require.Len(t, withoutMission, 1)
+		assert.Equal(t, whiskers.ID, withMission[0].ID)
+		assert.Equal(t, shadow.ID, withoutMission[0].ID)
+	})
+
+	t.Run("sorts and paginates", func(t *testing.T) {
+		result, total, err := catRepo.GetAll(models.CatFilter{PageQuery: models.PageQuery{Page: 2, PerPage: 1, Sort: "-salary"}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), total)
+		require.Len(t, result, 1)
+		assert.Equal(t, whiskers.ID, result[0].ID)
+	})
+
+	t.Run("unknown sort field", func(t *testing.T) {
+		_, _, err := catRepo.GetAll(models.CatFilter{PageQuery: models.PageQuery{Sort: "password"}})
+
+		assert.IsType(t, custerr.BadRequestErr{}, err)
+	})
+}
+
+func TestCatRepository_GetByID(t *testing.T) {
+	db := newTestDB(t)
+	catRepo := repository.NewCatRepository(db)
+	cat := createCat(t, db, "Whiskers")
+	mission := createMission(t, db, &cat.ID, "Target Alpha")
+
+	t.Run("preloads mission targets", func(t *testing.T) {
+		result, err := catRepo.GetByID(cat.ID)
+
+		assert.NoError(t, err)
+		require.NotNil(t, result.Mission)
+		assert.Equal(t, mission.ID, result.Mission.ID)
+		assert.Len(t, result.Mission.Targets, 1)
+	})
+
+	t.Run("ignores completed missions", func(t *testing.T) {
+		veteran := createCat(t, db, "Tom")
+		completed := createMission(t, db, &veteran.ID, "Target Beta")
+		require.NoError(t, db.Model(completed).Update("complete", true).Error)
+
+		result, err := catRepo.GetByID(veteran.ID)
+
+		assert.NoError(t, err)
+		assert.Nil(t, result.Mission)
+
+		active := createMission(t, db, &veteran.ID, "Target Gamma")
+
+		result, err = catRepo.GetByID(veteran.ID)
+
+		assert.NoError(t, err)
+		require.NotNil(t, result.Mission)
+		assert.Equal(t, active.ID, result.Mission.ID)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		result, err := catRepo.GetByID(999)
+
+		assert.Nil(t, result)
+		assert.IsType(t, custerr.NotFoundErr{}, err)
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	})
+}
+
+func TestCatRepository_GetByIDForUpdate(t *testing.T) {
+	db := newTestDB(t)
+	cat := createCat(t, db, "Whiskers")
+	createMission(t, db, &cat.ID, "Target Alpha")
+
+	t.Run("loads without associations", func(t *testing.T) {
+		err := repository.NewUnitOfWork(db).Do(func(repos *repository.Repository) error {
+			result, err := repos.Cat.GetByIDForUpdate(cat.ID)
+			if err != nil {
+				return err
+			}
+			assert.Equal(t, "Whiskers", result.Name)
+			assert.Nil(t, result.Mission)
+			return nil
+		})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		result, err := repository.NewCatRepository(db).GetByIDForUpdate(999)
+
+		assert.Nil(t, result)
+		assert.IsType(t, custerr.NotFoundErr{}, err)
+	})
+}
+
+func TestCatRepository_Update(t *testing.T) {
+	db := newTestDB(t)
+	catRepo := repository.NewCatRepository(db)
+	cat := createCat(t, db, "Whiskers")
+
+	cat.Salary = 7500
+	err := catRepo.Update(cat)
+
+	assert.NoError(t, err)
+	result, err := catRepo.GetByID(cat.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 7500.0, result.Salary)
+}
+
+func TestCatRepository_Delete(t *testing.T) {
+	db := newTestDB(t)
+	catRepo := repository.NewCatRepository(db)
+	cat := createCat(t, db, "Whiskers")
+	mission := createMission(t, db, &cat.ID)
+
+	t.Run("soft deletes", func(t *testing.T) {
+		err := catRepo.Delete(cat.ID)
+
+		assert.NoError(t, err)
+		_, err = catRepo.GetByID(cat.ID)
+		assert.IsType(t, custerr.NotFoundErr{}, err)
+		_, err = catRepo.GetDeletedByID(cat.ID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("keeps missions assigned", func(t *testing.T) {
+		var result models.Mission
+		require.NoError(t, db.First(&result, mission.ID).Error)
+
+		assert.Equal(t, &cat.ID, result.CatID)
+	})
+
+	t.Run("already deleted", func(t *testing.T) {
+		err := catRepo.Delete(cat.ID)
+
+		assert.IsType(t, custerr.NotFoundErr{}, err)
+	})
+}
+
+func TestCatRepository_Trash(t *testing.T) {
+	db := newTestDB(t)
+	catRepo := repository.NewCatRepository(db)
+	first := createCat(t, db, "Whiskers")
+	second := createCat(t, db, "Shadow")
+	kept := createCat(t, db, "Tom")
+	require.NoError(t, catRepo.Delete(first.ID))
+	require.NoError(t, catRepo.Delete(second.ID))
+
+	t.Run("lists deleted cats only", func(t *testing.T) {
+		result, total, err := catRepo.GetDeleted(models.PageQuery{PerPage: 1})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), total)
+		require.Len(t, result, 1)
+		assert.Equal(t, second.ID, result[0].ID)
+	})
+
+	t.Run("deleted by id ignores live cats", func(t *testing.T) {
+		result, err := catRepo.GetDeletedByID(kept.ID)
+
+		assert.Nil(t, result)
+		assert.IsType(t, custerr.NotFoundErr{}, err)
+	})
+
+	t.Run("restores", func(t *testing.T) {
+		err := catRepo.Restore(first.ID)
+
+		assert.NoError(t, err)
+		_, err = catRepo.GetByID(first.ID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("restore of live cat", func(t *testing.T) {
+		err := catRepo.Restore(kept.ID)
+
+		assert.IsType(t, custerr.NotFoundErr{}, err)
+	})
+
+	t.Run("purges before the given time", func(t *testing.T) {
+		none, err := catRepo.Purge(time.Now().Add(-time.Hour))
+		assert.NoError(t, err)
+		purged, err := catRepo.Purge(time.Now().Add(time.Hour))
+		assert.NoError(t, err)
+
+		assert.Equal(t, int64(0), none)
+		assert.Equal(t, int64(1), purged)
+		_, err = catRepo.GetDeletedByID(second.ID)
+		assert.IsType(t, custerr.NotFoundErr{}, err)
+		_, err = catRepo.GetByID(kept.ID)
+		assert.NoError(t, err)
+	})
+}
+
+func TestCatRepository_PurgeCascades(t *testing.T) {
+	db := newTestDB(t)
+	catRepo := repository.NewCatRepository(db)
+	cat := createCat(t, db, "Whiskers")
+	mission := createMission(t, db, &cat.ID, "Target Alpha")
+	require.NoError(t, catRepo.CreateSalary(&models.CatSalary{CatID: cat.ID, Salary: 1000, EffectiveFrom: time.Now(), Actor: "admin"}))
+	require.NoError(t, catRepo.Delete(cat.ID))
+
+	_, err := catRepo.Purge(time.Now().Add(time.Hour))
+
+	assert.NoError(t, err)
+	var result models.Mission
+	require.NoError(t, db.First(&result, mission.ID).Error)
+	assert.Nil(t, result.CatID)
+	var salaries int64
+	require.NoError(t, db.Model(&models.CatSalary{}).Where("cat_id = ?", cat.ID).Count(&salaries).Error)
+	assert.Zero(t, salaries)
+}
+
+func TestCatRepository_Salaries(t *testing.T) {
+	db := newTestDB(t)
+	catRepo := repository.NewCatRepository(db)
+	cat := createCat(t, db, "Whiskers")
+	other := createCat(t, db, "Shadow")
+
+	t.Run("lists oldest first", func(t *testing.T) {
+		require.NoError(t, catRepo.CreateSalary(&models.CatSalary{CatID: cat.ID, Salary: 2000, EffectiveFrom: day(2024, 3, 1), Actor: "admin"}))
+		require.NoError(t, catRepo.CreateSalary(&models.CatSalary{CatID: cat.ID, Salary: 1000, EffectiveFrom: day(2024, 1, 1), Actor: "admin"}))
+		require.NoError(t, catRepo.CreateSalary(&models.CatSalary{CatID: other.ID, Salary: 500, EffectiveFrom: day(2024, 1, 1), Actor: "admin"}))
+
+		result, err := catRepo.GetSalaries(cat.ID)
+
+		assert.NoError(t, err)
+		require.Len(t, result, 2)
+		assert.Equal(t, 1000.0, result[0].Salary)
+		assert.Equal(t, 2000.0, result[1].Salary)
+	})
+
+	t.Run("unknown cat", func(t *testing.T) {
+		err := catRepo.CreateSalary(&models.CatSalary{CatID: 999, Salary: 1000, EffectiveFrom: day(2024, 1, 1), Actor: "admin"})
+
+		assert.IsType(t, custerr.NotFoundErr{}, err)
+		assert.Equal(t, custerr.CodeReferenceNotFound, err.(custerr.Error).Code())
+	})
+
+	t.Run("no salaries", func(t *testing.T) {
+		result, err := catRepo.GetSalaries(999)
+
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+	})
+}
+
+func TestCatRepository_GetPayrollCats(t *testing.T) {
+	db := newTestDB(t)
+	catRepo := repository.NewCatRepository(db)
+	start, end := day(2024, 2, 1), day(2024, 3, 1)
+
+	employed := createCat(t, db, "Whiskers")
+	require.NoError(t, catRepo.CreateSalary(&models.CatSalary{CatID: employed.ID, Salary: 1000, EffectiveFrom: day(2024, 1, 1), Actor: "admin"}))
+	require.NoError(t, catRepo.CreateSalary(&models.CatSalary{CatID: employed.ID, Salary: 2000, EffectiveFrom: day(2024, 4, 1), Actor: "admin"}))
+
+	leftDuring := createCat(t, db, "Shadow")
+	require.NoError(t, catRepo.CreateSalary(&models.CatSalary{CatID: leftDuring.ID, Salary: 1000, EffectiveFrom: day(2024, 1, 1), Actor: "admin"}))
+	require.NoError(t, db.Unscoped().Model(leftDuring).Update("deleted_at", day(2024, 2, 15)).Error)
+
+	leftBefore := createCat(t, db, "Ghost")
+	require.NoError(t, catRepo.CreateSalary(&models.CatSalary{CatID: leftBefore.ID, Salary: 1000, EffectiveFrom: day(2024, 1, 1), Actor: "admin"}))
+	require.NoError(t, db.Unscoped().Model(leftBefore).Update("deleted_at", day(2024, 1, 15)).Error)
+
+	joinedAfter := createCat(t, db, "Tom")
+	require.NoError(t, catRepo.CreateSalary(&models.CatSalary{CatID: joinedAfter.ID, Salary: 1000, EffectiveFrom: day(2024, 3, 1), Actor: "admin"}))
+
+	result, err := catRepo.GetPayrollCats(start, end)
+
+	assert.NoError(t, err)
+	require.Len(t, result, 2)
+	assert.Equal(t, employed.ID, result[0].ID)
+	assert.Equal(t, leftDuring.ID, result[1].ID)
+	require.Len(t, result[0].Salaries, 1)
+	assert.Equal(t, 1000.0, result[0].Salaries[0].Salary)
+}
+
+func day(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
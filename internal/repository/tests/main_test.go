@@ -0,0 +1,158 @@
+package tests
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"os"
+	"spy-cat-agency/internal/database"
+	"spy-cat-agency/internal/models"
+	"strconv"
+	"testing"
+	"time"
+
+	embeddedpostgres "github.com/fergusstrange/embedded-postgres"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// testDatabaseURL points at a disposable database to run the tests against.
+// The tests empty every table, so it must never hold real data. Without it
+// an embedded Postgres is started for the package.
+const testDatabaseURL = "TEST_DATABASE_URL"
+
+// requireDatabase, like CI, turns a missing database into a failure instead
+// of skipping every test.
+const requireDatabase = "REQUIRE_DB"
+
+var (
+	testDB *gorm.DB
+	// unavailable says why there is no database, the tests are skipped then
+	unavailable string
+)
+
+func TestMain(m *testing.M) {
+	url, stop, err := startDatabase()
+	if err != nil {
+		if databaseRequired() {
+			log.Fatalf("no test database: %v", err)
+		}
+		log.Printf("WARNING: skipping every repository test, no query is checked: %v", err)
+		unavailable = err.Error()
+		os.Exit(m.Run())
+	}
+
+	testDB, err = connect(url)
+	if err != nil {
+		stop()
+		log.Fatalf("preparing test database: %v", err)
+	}
+
+	code := m.Run()
+	stop()
+	os.Exit(code)
+}
+
+// startDatabase returns the configured test database or starts an embedded
+// one, together with the function releasing it.
+func startDatabase() (string, func(), error) {
+	if url := os.Getenv(testDatabaseURL); url != "" {
+		return url, func() {}, nil
+	}
+
+	port, err := freePort()
+	if err != nil {
+		return "", nil, err
+	}
+	dir, err := os.MkdirTemp("", "spy-cat-agency-postgres")
+	if err != nil {
+		return "", nil, err
+	}
+
+	config := embeddedpostgres.DefaultConfig().
+		Version(embeddedpostgres.V15).
+		Port(port).
+		Database("spy_cat_agency_test").
+		RuntimePath(dir).
+		StartTimeout(time.Minute).
+		Logger(io.Discard)
+	postgres := embeddedpostgres.NewDatabase(config)
+	if err := postgres.Start(); err != nil {
+		os.RemoveAll(dir)
+		return "", nil, fmt.Errorf("%s is not set and no embedded Postgres could be started: %w", testDatabaseURL, err)
+	}
+
+	stop := func() {
+		postgres.Stop()
+		os.RemoveAll(dir)
+	}
+	return config.GetConnectionURL() + "?sslmode=disable", stop, nil
+}
+
+func databaseRequired() bool {
+	for _, name := range []string{requireDatabase, "CI"} {
+		if required, _ := strconv.ParseBool(os.Getenv(name)); required {
+			return true
+		}
+	}
+	return false
+}
+
+func freePort() (uint32, error) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		return 0, err
+	}
+	defer listener.Close()
+	return uint32(listener.Addr().(*net.TCPAddr).Port), nil
+}
+
+func connect(url string) (*gorm.DB, error) {
+	db, err := database.Connect(url)
+	if err != nil {
+		return nil, err
+	}
+	db.Logger = logger.Default.LogMode(logger.Silent)
+
+	if _, err := database.Migrate(db); err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
+// newTestDB returns the migrated test database and empties its tables once
+// the test is done.
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	if testDB == nil {
+		t.Skip(unavailable)
+	}
+
+	t.Cleanup(func() {
+		err := testDB.Exec("TRUNCATE cats, missions, targets, target_transitions, target_note_revisions, mission_assignments, cat_salaries, audit_logs RESTART IDENTITY CASCADE").Error
+		require.NoError(t, err)
+	})
+	return testDB
+}
+
+func createCat(t *testing.T, db *gorm.DB, name string) *models.Cat {
+	t.Helper()
+
+	cat := &models.Cat{CreateCatDTO: models.CreateCatDTO{Name: name, YearsExperience: 3, Breed: "Siamese", Salary: 1000}}
+	require.NoError(t, db.Create(cat).Error)
+	return cat
+}
+
+func createMission(t *testing.T, db *gorm.DB, catID *uint, targets ...string) *models.Mission {
+	t.Helper()
+
+	mission := &models.Mission{CatID: catID}
+	for _, name := range targets {
+		mission.Targets = append(mission.Targets, models.Target{Name: name, Country: "France"})
+	}
+	require.NoError(t, db.Create(mission).Error)
+	return mission
+}
@@ -0,0 +1,400 @@
+package tests
+
+import (
+	"fmt"
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/internal/repository"
+	"spy-cat-agency/pkg/custerr"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestMissionRepository_Create(t *testing.T) {
+	db := newTestDB(t)
+	missionRepo := repository.NewMissionRepository(db)
+	cat := createCat(t, db, "Whiskers")
+
+	t.Run("creates targets with default status", func(t *testing.T) {
+		mission := &models.Mission{CatID: &cat.ID, Targets: []models.Target{{Name: "Target Alpha", Country: "France"}}}
+
+		err := missionRepo.Create(mission)
+
+		assert.NoError(t, err)
+		result, err := missionRepo.GetByID(mission.ID)
+		require.NoError(t, err)
+		require.Len(t, result.Targets, 1)
+		assert.Equal(t, models.TargetStatusPending, result.Targets[0].Status)
+		assert.False(t, result.Targets[0].Complete)
+	})
+
+	t.Run("second active mission of a cat", func(t *testing.T) {
+		err := missionRepo.Create(&models.Mission{CatID: &cat.ID})
+
+		assert.IsType(t, custerr.ConflictErr{}, err)
+		assert.Equal(t, custerr.CodeDuplicate, err.(custerr.Error).Code())
+	})
+
+	t.Run("unknown cat", func(t *testing.T) {
+		catID := uint(999)
+
+		err := missionRepo.Create(&models.Mission{CatID: &catID})
+
+		assert.IsType(t, custerr.NotFoundErr{}, err)
+	})
+}
+
+func TestMissionRepository_GetAll(t *testing.T) {
+	db := newTestDB(t)
+	missionRepo := repository.NewMissionRepository(db)
+	cat := createCat(t, db, "Whiskers")
+	assigned := createMission(t, db, &cat.ID, "Target Alpha")
+	completed := createMission(t, db, nil, "Target Beta")
+	require.NoError(t, db.Model(completed).Update("complete", true).Error)
+	abroad := createMission(t, db, nil)
+	require.NoError(t, db.Create(&models.Target{MissionID: abroad.ID, Name: "Target Gamma", Country: "Japan"}).Error)
+	hidden := &models.Target{MissionID: abroad.ID, Name: "Target Delta", Country: "Peru"}
+	require.NoError(t, db.Create(hidden).Error)
+	require.NoError(t, db.Delete(hidden).Error)
+	deleted := createMission(t, db, nil)
+	require.NoError(t, missionRepo.Delete(deleted.ID))
+
+	t.Run("preloads cat and targets", func(t *testing.T) {
+		result, total, err := missionRepo.GetAll(models.MissionFilter{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), total)
+		require.Len(t, result, 3)
+		assert.Equal(t, assigned.ID, result[0].ID)
+		require.NotNil(t, result[0].Cat)
+		assert.Equal(t, "Whiskers", result[0].Cat.Name)
+		assert.Len(t, result[0].Targets, 1)
+		assert.Len(t, result[2].Targets, 1)
+	})
+
+	t.Run("filters by completion and cat", func(t *testing.T) {
+		complete := true
+
+		done, _, err := missionRepo.GetAll(models.MissionFilter{Complete: &complete})
+		assert.NoError(t, err)
+		ofCat, _, err := missionRepo.GetAll(models.MissionFilter{CatID: &cat.ID})
+		assert.NoError(t, err)
+
+		require.Len(t, done, 1)
+		require.Len(t, ofCat, 1)
+		assert.Equal(t, completed.ID, done[0].ID)
+		assert.Equal(t, assigned.ID, ofCat[0].ID)
+	})
+
+	t.Run("filters by country of live targets", func(t *testing.T) {
+		result, total, err := missionRepo.GetAll(models.MissionFilter{Country: "japan"})
+		assert.NoError(t, err)
+		_, deletedTotal, err := missionRepo.GetAll(models.MissionFilter{Country: "Peru"})
+		assert.NoError(t, err)
+
+		assert.Equal(t, int64(1), total)
+		require.Len(t, result, 1)
+		assert.Equal(t, abroad.ID, result[0].ID)
+		assert.Zero(t, deletedTotal)
+	})
+
+	t.Run("sorts and paginates", func(t *testing.T) {
+		result, total, err := missionRepo.GetAll(models.MissionFilter{PageQuery: models.PageQuery{PerPage: 2, Sort: "-id"}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), total)
+		require.Len(t, result, 2)
+		assert.Equal(t, abroad.ID, result[0].ID)
+		assert.Equal(t, completed.ID, result[1].ID)
+	})
+
+	t.Run("unknown sort field", func(t *testing.T) {
+		_, _, err := missionRepo.GetAll(models.MissionFilter{PageQuery: models.PageQuery{Sort: "secret"}})
+
+		assert.IsType(t, custerr.BadRequestErr{}, err)
+		assert.Equal(t, models.ErrCodeInvalidSort, err.(custerr.Error).Code())
+	})
+}
+
+func TestMissionRepository_GetByID(t *testing.T) {
+	db := newTestDB(t)
+	missionRepo := repository.NewMissionRepository(db)
+	targetRepo := repository.NewTargetRepository(db)
+	cat := createCat(t, db, "Whiskers")
+	mission := createMission(t, db, &cat.ID, "Target Alpha", "Target Beta")
+	require.NoError(t, targetRepo.CreateTransition(&models.TargetTransition{
+		TargetID: mission.Targets[0].ID,
+		From:     models.TargetStatusPending,
+		To:       models.TargetStatusUnderSurveillance,
+	}))
+	require.NoError(t, targetRepo.Delete(mission.Targets[1].ID))
+
+	t.Run("preloads cat and live targets with transitions", func(t *testing.T) {
+		result, err := missionRepo.GetByID(mission.ID)
+
+		assert.NoError(t, err)
+		require.NotNil(t, result.Cat)
+		assert.Equal(t, cat.ID, result.Cat.ID)
+		require.Len(t, result.Targets, 1)
+		require.Len(t, result.Targets[0].Transitions, 1)
+		assert.Equal(t, models.TargetStatusUnderSurveillance, result.Targets[0].Transitions[0].To)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		result, err := missionRepo.GetByID(999)
+
+		assert.Nil(t, result)
+		assert.IsType(t, custerr.NotFoundErr{}, err)
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	})
+}
+
+func TestMissionRepository_GetByIDForUpdate(t *testing.T) {
+	db := newTestDB(t)
+	mission := createMission(t, db, nil, "Target Alpha")
+
+	t.Run("loads without associations", func(t *testing.T) {
+		err := repository.NewUnitOfWork(db).Do(func(repos *repository.Repository) error {
+			result, err := repos.Mission.GetByIDForUpdate(mission.ID)
+			if err != nil {
+				return err
+			}
+			assert.Empty(t, result.Targets)
+			return nil
+		})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		result, err := repository.NewMissionRepository(db).GetByIDForUpdate(999)
+
+		assert.Nil(t, result)
+		assert.IsType(t, custerr.NotFoundErr{}, err)
+	})
+}
+
+func TestMissionRepository_Update(t *testing.T) {
+	db := newTestDB(t)
+	missionRepo := repository.NewMissionRepository(db)
+	cat := createCat(t, db, "Whiskers")
+	mission := createMission(t, db, nil, "Target Alpha")
+	other := createMission(t, db, nil)
+
+	t.Run("assigns cat without touching targets", func(t *testing.T) {
+		err := missionRepo.Update(&models.Mission{ID: mission.ID, CatID: &cat.ID})
+
+		assert.NoError(t, err)
+		result, err := missionRepo.GetByID(mission.ID)
+		require.NoError(t, err)
+		assert.Equal(t, &cat.ID, result.CatID)
+		assert.Len(t, result.Targets, 1)
+	})
+
+	t.Run("cat already on active mission", func(t *testing.T) {
+		err := missionRepo.Update(&models.Mission{ID: other.ID, CatID: &cat.ID})
+
+		assert.IsType(t, custerr.ConflictErr{}, err)
+		assert.Equal(t, models.ErrCodeCatAlreadyOnMission, err.(custerr.Error).Code())
+	})
+
+	t.Run("completed mission frees the cat", func(t *testing.T) {
+		require.NoError(t, missionRepo.Update(&models.Mission{ID: mission.ID, CatID: &cat.ID, Complete: true}))
+
+		err := missionRepo.Update(&models.Mission{ID: other.ID, CatID: &cat.ID})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("unknown cat", func(t *testing.T) {
+		catID := uint(999)
+
+		err := missionRepo.Update(&models.Mission{ID: other.ID, CatID: &catID})
+
+		assert.IsType(t, custerr.NotFoundErr{}, err)
+		assert.Equal(t, "no cat with id \"999\"", err.Error())
+	})
+
+	t.Run("unassigns cat", func(t *testing.T) {
+		err := missionRepo.Update(&models.Mission{ID: other.ID})
+
+		assert.NoError(t, err)
+		result, err := missionRepo.GetByID(other.ID)
+		require.NoError(t, err)
+		assert.Nil(t, result.CatID)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		err := missionRepo.Update(&models.Mission{ID: 999})
+
+		assert.IsType(t, custerr.NotFoundErr{}, err)
+		assert.Equal(t, "no mission with id \"999\"", err.Error())
+	})
+}
+
+func TestMissionRepository_Delete(t *testing.T) {
+	db := newTestDB(t)
+	missionRepo := repository.NewMissionRepository(db)
+	cat := createCat(t, db, "Whiskers")
+	mission := createMission(t, db, &cat.ID, "Target Alpha")
+
+	t.Run("soft deletes", func(t *testing.T) {
+		err := missionRepo.Delete(mission.ID)
+
+		assert.NoError(t, err)
+		_, err = missionRepo.GetByID(mission.ID)
+		assert.IsType(t, custerr.NotFoundErr{}, err)
+	})
+
+	t.Run("frees the cat for a new mission", func(t *testing.T) {
+		err := missionRepo.Create(&models.Mission{CatID: &cat.ID})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("already deleted", func(t *testing.T) {
+		err := missionRepo.Delete(mission.ID)
+
+		assert.IsType(t, custerr.NotFoundErr{}, err)
+		assert.Equal(t, fmt.Sprintf("no mission with id \"%d\"", mission.ID), err.Error())
+	})
+}
+
+func TestMissionRepository_GetActiveByCatID(t *testing.T) {
+	db := newTestDB(t)
+	missionRepo := repository.NewMissionRepository(db)
+	cat := createCat(t, db, "Whiskers")
+	idle := createCat(t, db, "Shadow")
+	completed := createMission(t, db, &cat.ID)
+	require.NoError(t, db.Model(completed).Update("complete", true).Error)
+	retired := createMission(t, db, &idle.ID)
+	require.NoError(t, db.Model(retired).Update("complete", true).Error)
+	active := createMission(t, db, &cat.ID, "Target Alpha")
+
+	t.Run("returns the incomplete mission", func(t *testing.T) {
+		result, err := missionRepo.GetActiveByCatID(cat.ID)
+
+		assert.NoError(t, err)
+		require.NotNil(t, result)
+		assert.Equal(t, active.ID, result.ID)
+		require.NotNil(t, result.Cat)
+		assert.Len(t, result.Targets, 1)
+	})
+
+	t.Run("nil without active mission", func(t *testing.T) {
+		result, err := missionRepo.GetActiveByCatID(idle.ID)
+
+		assert.NoError(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("ignores deleted missions", func(t *testing.T) {
+		require.NoError(t, missionRepo.Delete(active.ID))
+
+		result, err := missionRepo.GetActiveByCatID(cat.ID)
+
+		assert.NoError(t, err)
+		assert.Nil(t, result)
+	})
+}
+
+func TestMissionRepository_Assignments(t *testing.T) {
+	db := newTestDB(t)
+	missionRepo := repository.NewMissionRepository(db)
+	cat := createCat(t, db, "Whiskers")
+	mission := createMission(t, db, nil)
+	other := createMission(t, db, nil)
+
+	t.Run("lists in order of creation", func(t *testing.T) {
+		require.NoError(t, missionRepo.CreateAssignment(&models.MissionAssignment{MissionID: mission.ID, ToCatID: &cat.ID, Actor: "admin"}))
+		require.NoError(t, missionRepo.CreateAssignment(&models.MissionAssignment{MissionID: mission.ID, FromCatID: &cat.ID, Reason: "injured", Actor: "admin"}))
+		require.NoError(t, missionRepo.CreateAssignment(&models.MissionAssignment{MissionID: other.ID, ToCatID: &cat.ID, Actor: "admin"}))
+
+		result, err := missionRepo.GetAssignments(mission.ID)
+
+		assert.NoError(t, err)
+		require.Len(t, result, 2)
+		assert.Equal(t, &cat.ID, result[0].ToCatID)
+		assert.Equal(t, "injured", result[1].Reason)
+		assert.Nil(t, result[1].ToCatID)
+	})
+
+	t.Run("unknown mission", func(t *testing.T) {
+		err := missionRepo.CreateAssignment(&models.MissionAssignment{MissionID: 999, Actor: "admin"})
+
+		assert.IsType(t, custerr.NotFoundErr{}, err)
+	})
+
+	t.Run("no assignments", func(t *testing.T) {
+		result, err := missionRepo.GetAssignments(999)
+
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+	})
+}
+
+func TestMissionRepository_Trash(t *testing.T) {
+	db := newTestDB(t)
+	missionRepo := repository.NewMissionRepository(db)
+	targetRepo := repository.NewTargetRepository(db)
+	mission := createMission(t, db, nil, "Target Alpha")
+	kept := createMission(t, db, nil)
+	require.NoError(t, missionRepo.Delete(mission.ID))
+
+	t.Run("lists deleted missions only", func(t *testing.T) {
+		result, total, err := missionRepo.GetDeleted(models.PageQuery{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+		require.Len(t, result, 1)
+		assert.Equal(t, mission.ID, result[0].ID)
+	})
+
+	t.Run("deleted by id ignores live missions", func(t *testing.T) {
+		_, err := missionRepo.GetDeletedByID(kept.ID)
+
+		assert.IsType(t, custerr.NotFoundErr{}, err)
+	})
+
+	t.Run("locks deleted mission", func(t *testing.T) {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			result, err := repository.NewMissionRepository(tx).GetDeletedByIDForUpdate(mission.ID)
+			require.NoError(t, err)
+			assert.Equal(t, mission.ID, result.ID)
+			return nil
+		})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("restores", func(t *testing.T) {
+		require.NoError(t, missionRepo.Restore(mission.ID))
+
+		result, err := missionRepo.GetByID(mission.ID)
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Targets, 1)
+	})
+
+	t.Run("restore of live mission", func(t *testing.T) {
+		err := missionRepo.Restore(kept.ID)
+
+		assert.IsType(t, custerr.NotFoundErr{}, err)
+	})
+
+	t.Run("purge removes targets", func(t *testing.T) {
+		require.NoError(t, missionRepo.Delete(mission.ID))
+
+		purged, err := missionRepo.Purge(time.Now().Add(time.Hour))
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), purged)
+		_, err = targetRepo.GetByID(mission.Targets[0].ID)
+		assert.IsType(t, custerr.NotFoundErr{}, err)
+		_, err = targetRepo.GetDeletedByID(mission.Targets[0].ID)
+		assert.IsType(t, custerr.NotFoundErr{}, err)
+	})
+}
@@ -0,0 +1,101 @@
+package tests
+
+import (
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/internal/repository"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsRepository(t *testing.T) {
+	db := newTestDB(t)
+	statsRepo := repository.NewStatsRepository(db)
+	catRepo := repository.NewCatRepository(db)
+	missionRepo := repository.NewMissionRepository(db)
+
+	whiskers := createCat(t, db, "Whiskers")
+	shadow := createCat(t, db, "Shadow")
+	require.NoError(t, db.Model(shadow).Update("salary", 3000).Error)
+	ghost := createCat(t, db, "Ghost")
+	require.NoError(t, catRepo.Delete(ghost.ID))
+
+	completed := createMission(t, db, &whiskers.ID, "Target Alpha")
+	require.NoError(t, db.Model(completed).Update("complete", true).Error)
+	require.NoError(t, db.Model(&completed.Targets[0]).Update("complete", true).Error)
+	// Shadow held the completed mission before it was reassigned to Whiskers
+	require.NoError(t, db.Create(&models.MissionAssignment{MissionID: completed.ID, ToCatID: &shadow.ID, Actor: "admin"}).Error)
+	active := createMission(t, db, &whiskers.ID, "Target Beta")
+	require.NoError(t, db.Create(&models.Target{MissionID: active.ID, Name: "Target Gamma", Country: "Japan"}).Error)
+	createMission(t, db, nil)
+	deleted := createMission(t, db, &shadow.ID, "Target Delta")
+	require.NoError(t, missionRepo.Delete(deleted.ID))
+
+	t.Run("payroll of live cats", func(t *testing.T) {
+		result, err := statsRepo.Payroll(models.StatsFilter{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, &models.PayrollStats{Cats: 2, Total: 4000, Average: 2000}, result)
+	})
+
+	t.Run("missions", func(t *testing.T) {
+		result, err := statsRepo.Missions(models.StatsFilter{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, &models.MissionStats{Total: 3, Open: 2, Complete: 1, Unassigned: 1, AverageTargets: 1}, result)
+	})
+
+	t.Run("completion per cat", func(t *testing.T) {
+		result, err := statsRepo.CatCompletion(models.StatsFilter{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []models.CatCompletionStats{
+			{CatID: whiskers.ID, Name: "Whiskers", Missions: 2, Completed: 1},
+			{CatID: shadow.ID, Name: "Shadow", Missions: 1, Completed: 0},
+		}, result)
+	})
+
+	t.Run("targets by country", func(t *testing.T) {
+		result, err := statsRepo.TargetsByCountry(models.StatsFilter{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []models.CountryStats{
+			{Country: "France", Targets: 2, Complete: 1},
+			{Country: "Japan", Targets: 1, Complete: 0},
+		}, result)
+	})
+
+	t.Run("idle cats", func(t *testing.T) {
+		result, err := statsRepo.IdleCats(models.StatsFilter{})
+
+		assert.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.Equal(t, shadow.ID, result[0].ID)
+		assert.Nil(t, result[0].LastMissionAt)
+	})
+
+	t.Run("idle cat count", func(t *testing.T) {
+		result, err := statsRepo.CountIdleCats(models.StatsFilter{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), result)
+	})
+
+	t.Run("date range", func(t *testing.T) {
+		from := time.Now().Add(time.Hour)
+		filter := models.StatsFilter{From: &from, DateField: "updated_at"}
+
+		payroll, err := statsRepo.Payroll(filter)
+		assert.NoError(t, err)
+		missions, err := statsRepo.Missions(filter)
+		assert.NoError(t, err)
+		countries, err := statsRepo.TargetsByCountry(filter)
+		assert.NoError(t, err)
+
+		assert.Zero(t, payroll.Cats)
+		assert.Zero(t, missions.Total)
+		assert.Empty(t, countries)
+	})
+}
@@ -0,0 +1,260 @@
+package tests
+
+import (
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/internal/repository"
+	"spy-cat-agency/pkg/custerr"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestTargetRepository_Create(t *testing.T) {
+	db := newTestDB(t)
+	targetRepo := repository.NewTargetRepository(db)
+	mission := createMission(t, db, nil)
+
+	t.Run("success", func(t *testing.T) {
+		target := &models.Target{MissionID: mission.ID, Name: "Target Alpha", Country: "France"}
+
+		err := targetRepo.Create(target)
+
+		assert.NoError(t, err)
+		assert.NotZero(t, target.ID)
+		assert.Equal(t, models.TargetStatusPending, target.Status)
+	})
+
+	t.Run("unknown mission", func(t *testing.T) {
+		err := targetRepo.Create(&models.Target{MissionID: 999, Name: "Target Beta", Country: "France"})
+
+		assert.IsType(t, custerr.NotFoundErr{}, err)
+		assert.Equal(t, custerr.CodeReferenceNotFound, err.(custerr.Error).Code())
+	})
+
+	t.Run("invalid status", func(t *testing.T) {
+		err := targetRepo.Create(&models.Target{MissionID: mission.ID, Name: "Target Gamma", Country: "France", Status: "lost"})
+
+		assert.IsType(t, custerr.BadRequestErr{}, err)
+	})
+}
+
+func TestTargetRepository_CreateTransition(t *testing.T) {
+	db := newTestDB(t)
+	targetRepo := repository.NewTargetRepository(db)
+
+	err := targetRepo.CreateTransition(&models.TargetTransition{TargetID: 999, From: models.TargetStatusPending, To: models.TargetStatusEscaped})
+
+	assert.IsType(t, custerr.NotFoundErr{}, err)
+}
+
+func TestTargetRepository_NoteRevisions(t *testing.T) {
+	db := newTestDB(t)
+	targetRepo := repository.NewTargetRepository(db)
+	mission := createMission(t, db, nil, "Target Alpha", "Target Beta")
+	target, other := mission.Targets[0], mission.Targets[1]
+	first := &models.TargetNoteRevision{TargetID: target.ID, Author: "whiskers", Notes: "seen in Paris"}
+	require.NoError(t, targetRepo.CreateNoteRevision(first))
+	require.NoError(t, targetRepo.CreateNoteRevision(&models.TargetNoteRevision{TargetID: target.ID, Author: "whiskers", Notes: "seen in Lyon"}))
+	require.NoError(t, targetRepo.CreateNoteRevision(&models.TargetNoteRevision{TargetID: other.ID, Author: "shadow", Notes: "lost"}))
+
+	t.Run("lists in order of creation", func(t *testing.T) {
+		result, err := targetRepo.GetNoteRevisions(target.ID)
+
+		assert.NoError(t, err)
+		require.Len(t, result, 2)
+		assert.Equal(t, "seen in Paris", result[0].Notes)
+		assert.Equal(t, "seen in Lyon", result[1].Notes)
+	})
+
+	t.Run("gets one revision", func(t *testing.T) {
+		result, err := targetRepo.GetNoteRevision(target.ID, first.ID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "seen in Paris", result.Notes)
+	})
+
+	t.Run("revision of another target", func(t *testing.T) {
+		result, err := targetRepo.GetNoteRevision(other.ID, first.ID)
+
+		assert.Nil(t, result)
+		assert.IsType(t, custerr.NotFoundErr{}, err)
+	})
+
+	t.Run("unknown target", func(t *testing.T) {
+		err := targetRepo.CreateNoteRevision(&models.TargetNoteRevision{TargetID: 999, Author: "whiskers"})
+
+		assert.IsType(t, custerr.NotFoundErr{}, err)
+	})
+}
+
+func TestTargetRepository_GetByID(t *testing.T) {
+	db := newTestDB(t)
+	targetRepo := repository.NewTargetRepository(db)
+	mission := createMission(t, db, nil, "Target Alpha")
+
+	t.Run("success", func(t *testing.T) {
+		result, err := targetRepo.GetByID(mission.Targets[0].ID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Target Alpha", result.Name)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		result, err := targetRepo.GetByID(999)
+
+		assert.Nil(t, result)
+		assert.IsType(t, custerr.NotFoundErr{}, err)
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	})
+}
+
+func TestTargetRepository_GetByMissionAndID(t *testing.T) {
+	db := newTestDB(t)
+	targetRepo := repository.NewTargetRepository(db)
+	mission := createMission(t, db, nil, "Target Alpha")
+	other := createMission(t, db, nil, "Target Beta")
+
+	t.Run("success", func(t *testing.T) {
+		result, err := targetRepo.GetByMissionAndID(mission.ID, mission.Targets[0].ID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, mission.ID, result.MissionID)
+	})
+
+	t.Run("target of another mission", func(t *testing.T) {
+		result, err := targetRepo.GetByMissionAndID(mission.ID, other.Targets[0].ID)
+
+		assert.Nil(t, result)
+		assert.IsType(t, custerr.NotFoundErr{}, err)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		result, err := targetRepo.GetByMissionAndID(mission.ID, 999)
+
+		assert.Nil(t, result)
+		assert.IsType(t, custerr.NotFoundErr{}, err)
+	})
+}
+
+func TestTargetRepository_Update(t *testing.T) {
+	db := newTestDB(t)
+	targetRepo := repository.NewTargetRepository(db)
+	mission := createMission(t, db, nil, "Target Alpha")
+
+	t.Run("updates notes and status only", func(t *testing.T) {
+		target := mission.Targets[0]
+		target.Notes = "seen near the harbour"
+		target.Status = models.TargetStatusUnderSurveillance
+		target.Name = "Renamed"
+
+		err := targetRepo.Update(&target)
+
+		assert.NoError(t, err)
+		result, err := targetRepo.GetByID(target.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "seen near the harbour", result.Notes)
+		assert.Equal(t, models.TargetStatusUnderSurveillance, result.Status)
+		assert.Equal(t, "Target Alpha", result.Name)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		err := targetRepo.Update(&models.Target{ID: 999, Notes: "nothing"})
+
+		assert.IsType(t, custerr.NotFoundErr{}, err)
+	})
+}
+
+func TestTargetRepository_Delete(t *testing.T) {
+	db := newTestDB(t)
+	targetRepo := repository.NewTargetRepository(db)
+	mission := createMission(t, db, nil, "Target Alpha", "Target Beta")
+
+	t.Run("success", func(t *testing.T) {
+		err := targetRepo.Delete(mission.Targets[0].ID)
+
+		assert.NoError(t, err)
+		_, err = targetRepo.GetByID(mission.Targets[0].ID)
+		assert.IsType(t, custerr.NotFoundErr{}, err)
+	})
+
+	t.Run("already deleted", func(t *testing.T) {
+		err := targetRepo.Delete(mission.Targets[0].ID)
+
+		assert.IsType(t, custerr.NotFoundErr{}, err)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		err := targetRepo.Delete(999)
+
+		assert.IsType(t, custerr.NotFoundErr{}, err)
+	})
+}
+
+func TestTargetRepository_CountByMissionID(t *testing.T) {
+	db := newTestDB(t)
+	targetRepo := repository.NewTargetRepository(db)
+	mission := createMission(t, db, nil, "Target Alpha", "Target Beta")
+	require.NoError(t, targetRepo.Delete(mission.Targets[1].ID))
+
+	count, err := targetRepo.CountByMissionID(mission.ID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestTargetRepository_Trash(t *testing.T) {
+	db := newTestDB(t)
+	targetRepo := repository.NewTargetRepository(db)
+	mission := createMission(t, db, nil, "Target Alpha", "Target Beta")
+	target, kept := mission.Targets[0], mission.Targets[1]
+	require.NoError(t, targetRepo.CreateTransition(&models.TargetTransition{TargetID: target.ID, From: models.TargetStatusPending, To: models.TargetStatusEscaped}))
+	require.NoError(t, targetRepo.CreateNoteRevision(&models.TargetNoteRevision{TargetID: target.ID, Author: "whiskers", Notes: "gone"}))
+	require.NoError(t, targetRepo.Delete(target.ID))
+
+	t.Run("lists deleted targets only", func(t *testing.T) {
+		result, total, err := targetRepo.GetDeleted(models.PageQuery{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+		require.Len(t, result, 1)
+		assert.Equal(t, target.ID, result[0].ID)
+	})
+
+	t.Run("deleted by id ignores live targets", func(t *testing.T) {
+		_, err := targetRepo.GetDeletedByID(kept.ID)
+
+		assert.IsType(t, custerr.NotFoundErr{}, err)
+	})
+
+	t.Run("restores", func(t *testing.T) {
+		require.NoError(t, targetRepo.Restore(target.ID))
+
+		count, err := targetRepo.CountByMissionID(mission.ID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+	})
+
+	t.Run("restore of live target", func(t *testing.T) {
+		err := targetRepo.Restore(kept.ID)
+
+		assert.IsType(t, custerr.NotFoundErr{}, err)
+	})
+
+	t.Run("purge removes transitions and revisions", func(t *testing.T) {
+		require.NoError(t, targetRepo.Delete(target.ID))
+
+		purged, err := targetRepo.Purge(time.Now().Add(time.Hour))
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), purged)
+		var transitions, revisions int64
+		require.NoError(t, db.Model(&models.TargetTransition{}).Where("target_id = ?", target.ID).Count(&transitions).Error)
+		require.NoError(t, db.Model(&models.TargetNoteRevision{}).Where("target_id = ?", target.ID).Count(&revisions).Error)
+		assert.Zero(t, transitions)
+		assert.Zero(t, revisions)
+	})
+}
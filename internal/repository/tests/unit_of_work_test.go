@@ -0,0 +1,63 @@
+package tests
+
+import (
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/internal/repository"
+	"spy-cat-agency/pkg/custerr"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitOfWork_Do(t *testing.T) {
+	db := newTestDB(t)
+	uow := repository.NewUnitOfWork(db)
+	catRepo := repository.NewCatRepository(db)
+
+	t.Run("commits", func(t *testing.T) {
+		cat := &models.Cat{CreateCatDTO: models.CreateCatDTO{Name: "Whiskers", YearsExperience: 3, Breed: "Siamese", Salary: 1000}}
+
+		err := uow.Do(func(repos *repository.Repository) error {
+			if err := repos.Cat.Create(cat); err != nil {
+				return err
+			}
+			return repos.Mission.Create(&models.Mission{CatID: &cat.ID})
+		})
+
+		assert.NoError(t, err)
+		mission, err := repository.NewMissionRepository(db).GetActiveByCatID(cat.ID)
+		require.NoError(t, err)
+		assert.NotNil(t, mission)
+	})
+
+	t.Run("rolls back and returns the error", func(t *testing.T) {
+		cat := &models.Cat{CreateCatDTO: models.CreateCatDTO{Name: "Shadow", YearsExperience: 3, Breed: "Siamese", Salary: 1000}}
+
+		err := uow.Do(func(repos *repository.Repository) error {
+			if err := repos.Cat.Create(cat); err != nil {
+				return err
+			}
+			return custerr.NewConflictErr("cat already has an active mission")
+		})
+
+		assert.IsType(t, custerr.ConflictErr{}, err)
+		_, err = catRepo.GetByID(cat.ID)
+		assert.IsType(t, custerr.NotFoundErr{}, err)
+	})
+
+	t.Run("rolls back on database errors", func(t *testing.T) {
+		cat := &models.Cat{CreateCatDTO: models.CreateCatDTO{Name: "Tom", YearsExperience: 3, Breed: "Siamese", Salary: 1000}}
+
+		err := uow.Do(func(repos *repository.Repository) error {
+			if err := repos.Cat.Create(cat); err != nil {
+				return err
+			}
+			return repos.Cat.CreateSalary(&models.CatSalary{CatID: cat.ID, Salary: -1, Actor: "admin"})
+		})
+
+		assert.IsType(t, custerr.BadRequestErr{}, err)
+		_, err = catRepo.GetByID(cat.ID)
+		assert.IsType(t, custerr.NotFoundErr{}, err)
+	})
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"fmt"
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/pkg/custerr"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// The helpers below work on soft deleted rows of any model carrying
+// gorm.DeletedAt, which regular queries never see.
+
+var deletedSortColumns = map[string]string{
+	"id":         "id",
+	"created_at": "created_at",
+	"deleted_at": "deleted_at",
+}
+
+func onlyDeleted(table string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(table + ".deleted_at IS NOT NULL")
+	}
+}
+
+func getDeleted[T any](db *gorm.DB, table string, query models.PageQuery) ([]T, int64, error) {
+	if query.Sort == "" {
+		query.Sort = "-deleted_at"
+	}
+	order, err := orderBy(query.Sort, deletedSortColumns, table)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var total int64
+	if err := db.Unscoped().Model(new(T)).Scopes(onlyDeleted(table)).Count(&total).Error; err != nil {
+		return nil, 0, custerr.Classify(err)
+	}
+
+	items := []T{}
+	err = db.Unscoped().Scopes(onlyDeleted(table), paginate(query)).
+		Clauses(order).
+		Find(&items).Error
+	if err != nil {
+		return nil, 0, custerr.Classify(err)
+	}
+	return items, total, nil
+}
+
+func getDeletedByID[T any](db *gorm.DB, table, entity string, id uint) (*T, error) {
+	var item T
+	err := db.Unscoped().Scopes(onlyDeleted(table)).First(&item, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, custerr.NewNotFoundErr(fmt.Sprintf("no deleted %s with id \"%d\"", entity, id)).WithCause(err)
+		}
+		return nil, custerr.Classify(err)
+	}
+	return &item, nil
+}
+
+func restore[T any](db *gorm.DB, table, entity string, id uint) error {
+	res := db.Unscoped().Model(new(T)).Scopes(onlyDeleted(table)).
+		Where(table+".id = ?", id).
+		Update("deleted_at", nil)
+	if res.Error != nil {
+		return custerr.Classify(res.Error)
+	}
+
+	if res.RowsAffected == 0 {
+		return custerr.NewNotFoundErr(fmt.Sprintf("no deleted %s with id \"%d\"", entity, id))
+	}
+	return nil
+}
+
+// purge permanently removes rows soft deleted before the given time.
+func purge[T any](db *gorm.DB, table string, before time.Time) (int64, error) {
+	res := db.Unscoped().Scopes(onlyDeleted(table)).
+		Where(table+".deleted_at < ?", before).
+		Delete(new(T))
+	if res.Error != nil {
+		return 0, custerr.Classify(res.Error)
+	}
+	return res.RowsAffected, nil
+}
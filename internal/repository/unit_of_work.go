@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"spy-cat-agency/pkg/custerr"
+
+	"gorm.io/gorm"
+)
+
+type UnitOfWork struct {
+	db *gorm.DB
+}
+
+func NewUnitOfWork(db *gorm.DB) *UnitOfWork {
+	return &UnitOfWork{db: db}
+}
+
+// Do runs fn inside a single database transaction. All repositories passed
+// to fn share that transaction, which is rolled back if fn returns an error.
+func (u *UnitOfWork) Do(fn func(repos *Repository) error) error {
+	var fnErr error
+	err := u.db.Transaction(func(tx *gorm.DB) error {
+		fnErr = fn(New(tx))
+		return fnErr
+	})
+	if fnErr != nil {
+		return fnErr
+	}
+	if err != nil {
+		return custerr.Classify(err)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"spy-cat-agency/internal/actor"
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/pkg/custerr"
+)
+
+type AuditService struct {
+	repo AuditRepository
+}
+
+func NewAuditService(repo AuditRepository) *AuditService {
+	return &AuditService{repo: repo}
+}
+
+func (s *AuditService) GetAll(ctx context.Context, filter models.AuditFilter) (*models.Page[models.AuditLog], error) {
+	entries, total, err := s.repo.GetAll(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.NewPage(entries, filter.PageQuery, total), nil
+}
+
+// recordAudit stores who performed action on an entity together with its
+// state before and after the change. Either state may be nil.
+func recordAudit(ctx context.Context, repo AuditRepository, action models.AuditAction, entityType string, entityID uint, before, after any) error {
+	entry := &models.AuditLog{
+		Actor:      actor.FromContext(ctx),
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+	}
+
+	var err error
+	if entry.Before, err = auditState(before); err != nil {
+		return custerr.NewInternalErr(err)
+	}
+	if entry.After, err = auditState(after); err != nil {
+		return custerr.NewInternalErr(err)
+	}
+
+	return repo.Create(entry)
+}
+
+func auditState(v any) (json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+	return json.Marshal(v)
+}
+
+// The snapshots below drop loaded associations so each audit entry only
+// describes the entity it is about.
+
+func catSnapshot(cat *models.Cat) models.Cat {
+	snapshot := *cat
+	snapshot.Mission = nil
+	return snapshot
+}
+
+func missionSnapshot(mission *models.Mission) models.Mission {
+	snapshot := *mission
+	snapshot.Cat = nil
+	snapshot.Targets = nil
+	return snapshot
+}
+
+func targetSnapshot(target *models.Target) models.Target {
+	snapshot := *target
+	snapshot.Transitions = nil
+	return snapshot
+}
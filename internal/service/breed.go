@@ -0,0 +1,15 @@
+package service
+
+import "spy-cat-agency/pkg/catapi"
+
+type BreedService struct {
+	catalog BreedCatalog
+}
+
+func NewBreedService(catalog BreedCatalog) *BreedService {
+	return &BreedService{catalog: catalog}
+}
+
+func (s *BreedService) GetAll() ([]catapi.CatAPIBreed, error) {
+	return s.catalog.Breeds()
+}
@@ -1,63 +1,173 @@
 package service
 
 import (
-	"errors"
+	"context"
+	"fmt"
+	"spy-cat-agency/internal/actor"
 	"spy-cat-agency/internal/models"
 	"spy-cat-agency/pkg/catapi"
+	"spy-cat-agency/pkg/custerr"
+	"time"
 )
 
-var ErrInvalidCatBreed = errors.New("invalid cat breed")
-
 type CatService struct {
 	repo         CatRepository
+	uow          UnitOfWork
 	catValidator catapi.CatValidator
 }
 
-func NewCatService(repo CatRepository, catValidator catapi.CatValidator) *CatService {
+func NewCatService(repo CatRepository, uow UnitOfWork, catValidator catapi.CatValidator) *CatService {
 	return &CatService{
 		repo:         repo,
+		uow:          uow,
 		catValidator: catValidator,
 	}
 }
 
-func (s *CatService) Create(catDTO *models.CreateCatDTO) (*models.Cat, error) {
-	isValid, err := s.catValidator.ValidateBreed(catDTO.Breed)
+func (s *CatService) Create(ctx context.Context, catDTO *models.CreateCatDTO) (*models.Cat, error) {
+	breed, err := s.catValidator.ValidateBreed(catDTO.Breed)
 	if err != nil {
 		return nil, err
 	}
-	if !isValid {
-		return nil, ErrInvalidCatBreed
+	if breed == nil {
+		suggestions, err := s.catValidator.SuggestBreeds(catDTO.Breed)
+		if err != nil {
+			return nil, err
+		}
+		return nil, custerr.NewBadRequestErrWithDetails("invalid cat breed", map[string]any{
+			"suggestions": suggestions,
+		}).WithCode(models.ErrCodeInvalidBreed)
 	}
 
 	cat := &models.Cat{
 		CreateCatDTO: *catDTO,
+		BreedID:      breed.ID,
 	}
+	cat.Breed = breed.Name
 
-	if err := s.repo.Create(cat); err != nil {
+	err = s.uow.Do(func(repos Repositories) error {
+		if err := repos.Cat.Create(cat); err != nil {
+			return err
+		}
+		if err := recordSalary(ctx, repos.Cat, cat.ID, cat.Salary, time.Now()); err != nil {
+			return err
+		}
+		return recordAudit(ctx, repos.Audit, models.AuditActionCreate, models.AuditEntityCat, cat.ID, nil, catSnapshot(cat))
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return cat, nil
 }
 
-func (s *CatService) GetAll() ([]models.Cat, error) {
-	return s.repo.GetAll()
+func (s *CatService) GetAll(ctx context.Context, filter models.CatFilter) (*models.Page[models.Cat], error) {
+	cats, total, err := s.repo.GetAll(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.NewPage(cats, filter.PageQuery, total), nil
 }
 
-func (s *CatService) GetByID(id uint) (*models.Cat, error) {
+func (s *CatService) GetByID(ctx context.Context, id uint) (*models.Cat, error) {
 	return s.repo.GetByID(id)
 }
 
-func (s *CatService) Update(id uint, dto models.UpdateCatDTO) (*models.Cat, error) {
-	cat, err := s.repo.GetByID(id)
+// Update changes a cat's salary and adds the change to its salary history,
+// effective now unless the change is backdated.
+func (s *CatService) Update(ctx context.Context, id uint, dto models.UpdateCatDTO) (*models.Cat, error) {
+	effectiveFrom := time.Now()
+	if dto.EffectiveFrom != nil {
+		if dto.EffectiveFrom.After(effectiveFrom) {
+			return nil, custerr.NewBadRequestErr("effective_from cannot be in the future").WithCode(models.ErrCodeInvalidEffectiveFrom)
+		}
+		effectiveFrom = *dto.EffectiveFrom
+	}
+
+	var cat *models.Cat
+
+	err := s.uow.Do(func(repos Repositories) error {
+		var err error
+		cat, err = repos.Cat.GetByID(id)
+		if err != nil {
+			return err
+		}
+		before := catSnapshot(cat)
+
+		if dto.EffectiveFrom != nil {
+			salaries, err := repos.Cat.GetSalaries(id)
+			if err != nil {
+				return err
+			}
+			if n := len(salaries); n > 0 && effectiveFrom.Before(salaries[n-1].EffectiveFrom) {
+				return custerr.NewBadRequestErr(fmt.Sprintf("effective_from cannot be before the latest salary change on %s", salaries[n-1].EffectiveFrom.Format(time.RFC3339))).WithCode(models.ErrCodeInvalidEffectiveFrom)
+			}
+		}
+
+		if dto.Salary != cat.Salary {
+			if err := recordSalary(ctx, repos.Cat, cat.ID, dto.Salary, effectiveFrom); err != nil {
+				return err
+			}
+		}
+		cat.Salary = dto.Salary
+
+		if err := repos.Cat.Update(cat); err != nil {
+			return err
+		}
+		return recordAudit(ctx, repos.Audit, models.AuditActionUpdate, models.AuditEntityCat, cat.ID, before, catSnapshot(cat))
+	})
 	if err != nil {
 		return nil, err
 	}
-	cat.Salary = dto.Salary
 
-	return cat, s.repo.Update(cat)
+	return cat, nil
+}
+
+// GetSalaries returns a cat's salary history, oldest change first.
+func (s *CatService) GetSalaries(ctx context.Context, id uint) ([]models.CatSalary, error) {
+	if _, err := s.repo.GetByID(id); err != nil {
+		return nil, err
+	}
+	return s.repo.GetSalaries(id)
+}
+
+// Delete soft deletes a cat. A cat on an active mission is only deleted
+// with force, which unassigns it from the mission first.
+func (s *CatService) Delete(ctx context.Context, id uint, force bool) error {
+	return s.uow.Do(func(repos Repositories) error {
+		// the lock keeps the cat from being assigned while it is deleted
+		cat, err := repos.Cat.GetByIDForUpdate(id)
+		if err != nil {
+			return err
+		}
+
+		activeMission, err := repos.Mission.GetActiveByCatID(id)
+		if err != nil {
+			return err
+		}
+		if activeMission != nil {
+			if !force {
+				return custerr.NewConflictErr("cannot delete cat with an active mission").WithCode(models.ErrCodeCatOnActiveMission)
+			}
+			if err := changeAssignment(ctx, repos, activeMission, nil, "cat deleted"); err != nil {
+				return err
+			}
+		}
+
+		if err := repos.Cat.Delete(id); err != nil {
+			return err
+		}
+		return recordAudit(ctx, repos.Audit, models.AuditActionDelete, models.AuditEntityCat, id, catSnapshot(cat), nil)
+	})
 }
 
-func (s *CatService) Delete(id uint) error {
-	return s.repo.Delete(id)
+// recordSalary adds an entry to a cat's salary history.
+func recordSalary(ctx context.Context, repo CatRepository, catID uint, salary float64, effectiveFrom time.Time) error {
+	return repo.CreateSalary(&models.CatSalary{
+		CatID:         catID,
+		Salary:        salary,
+		EffectiveFrom: effectiveFrom,
+		Actor:         actor.FromContext(ctx),
+	})
 }
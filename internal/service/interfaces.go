@@ -1,28 +1,86 @@
 package service
 
-import "spy-cat-agency/internal/models"
+import (
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/pkg/catapi"
+	"time"
+)
 
 type CatRepository interface {
 	Create(cat *models.Cat) error
-	GetAll() ([]models.Cat, error)
+	GetAll(filter models.CatFilter) ([]models.Cat, int64, error)
 	GetByID(id uint) (*models.Cat, error)
+	GetByIDForUpdate(id uint) (*models.Cat, error)
 	Update(cat *models.Cat) error
 	Delete(id uint) error
+	GetDeleted(query models.PageQuery) ([]models.Cat, int64, error)
+	GetDeletedByID(id uint) (*models.Cat, error)
+	Restore(id uint) error
+	Purge(before time.Time) (int64, error)
+	CreateSalary(salary *models.CatSalary) error
+	GetSalaries(catID uint) ([]models.CatSalary, error)
+	GetPayrollCats(start, end time.Time) ([]models.Cat, error)
 }
 
 type MissionRepository interface {
 	Create(mission *models.Mission) error
-	GetAll() ([]models.Mission, error)
+	GetAll(filter models.MissionFilter) ([]models.Mission, int64, error)
 	GetByID(id uint) (*models.Mission, error)
+	GetByIDForUpdate(id uint) (*models.Mission, error)
 	Update(mission *models.Mission) error
 	Delete(id uint) error
 	GetActiveByCatID(catID uint) (*models.Mission, error)
+	CreateAssignment(assignment *models.MissionAssignment) error
+	GetAssignments(missionID uint) ([]models.MissionAssignment, error)
+	GetDeleted(query models.PageQuery) ([]models.Mission, int64, error)
+	GetDeletedByID(id uint) (*models.Mission, error)
+	GetDeletedByIDForUpdate(id uint) (*models.Mission, error)
+	Restore(id uint) error
+	Purge(before time.Time) (int64, error)
 }
 
 type TargetRepository interface {
 	Create(target *models.Target) error
+	CreateTransition(transition *models.TargetTransition) error
+	CreateNoteRevision(revision *models.TargetNoteRevision) error
+	GetNoteRevisions(targetID uint) ([]models.TargetNoteRevision, error)
+	GetNoteRevision(targetID, revisionID uint) (*models.TargetNoteRevision, error)
 	GetByID(id uint) (*models.Target, error)
+	GetByMissionAndID(missionID, id uint) (*models.Target, error)
 	Update(target *models.Target) error
 	Delete(id uint) error
 	CountByMissionID(missionID uint) (int64, error)
+	GetDeleted(query models.PageQuery) ([]models.Target, int64, error)
+	GetDeletedByID(id uint) (*models.Target, error)
+	Restore(id uint) error
+	Purge(before time.Time) (int64, error)
+}
+
+type AuditRepository interface {
+	Create(entry *models.AuditLog) error
+	GetAll(filter models.AuditFilter) ([]models.AuditLog, int64, error)
+}
+
+type StatsRepository interface {
+	Payroll(filter models.StatsFilter) (*models.PayrollStats, error)
+	Missions(filter models.StatsFilter) (*models.MissionStats, error)
+	CatCompletion(filter models.StatsFilter) ([]models.CatCompletionStats, error)
+	TargetsByCountry(filter models.StatsFilter) ([]models.CountryStats, error)
+	IdleCats(filter models.StatsFilter) ([]models.IdleCat, error)
+	CountIdleCats(filter models.StatsFilter) (int64, error)
+}
+
+type BreedCatalog interface {
+	Breeds() ([]catapi.CatAPIBreed, error)
+}
+
+type Repositories struct {
+	Cat     CatRepository
+	Mission MissionRepository
+	Target  TargetRepository
+	Audit   AuditRepository
+}
+
+type UnitOfWork interface {
+	Do(fn func(repos Repositories) error) error
 }
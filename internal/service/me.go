@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"spy-cat-agency/internal/auth"
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/pkg/custerr"
+)
+
+// MeService serves field cats their own profile and missions. The cat is
+// always taken from the authenticated principal, never from the request.
+type MeService struct {
+	catRepo        CatRepository
+	missionRepo    MissionRepository
+	missionService *MissionService
+}
+
+func NewMeService(catRepo CatRepository, missionRepo MissionRepository, missionService *MissionService) *MeService {
+	return &MeService{
+		catRepo:        catRepo,
+		missionRepo:    missionRepo,
+		missionService: missionService,
+	}
+}
+
+func (s *MeService) GetProfile(ctx context.Context) (*models.CatProfile, error) {
+	catID, err := callerCatID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cat, err := s.catRepo.GetByID(catID)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.NewCatProfile(cat), nil
+}
+
+func (s *MeService) GetActiveMission(ctx context.Context) (*models.Mission, error) {
+	catID, err := callerCatID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	mission, err := s.activeMission(catID)
+	if err != nil {
+		return nil, err
+	}
+
+	return withoutCat(mission), nil
+}
+
+func (s *MeService) GetMissions(ctx context.Context, filter models.CatMissionFilter) (*models.Page[models.Mission], error) {
+	catID, err := callerCatID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	missions, total, err := s.missionRepo.GetAll(models.MissionFilter{
+		PageQuery: filter.PageQuery,
+		Complete:  filter.Complete,
+		CatID:     &catID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range missions {
+		withoutCat(&missions[i])
+	}
+
+	return models.NewPage(missions, filter.PageQuery, total), nil
+}
+
+// UpdateTarget updates a target of the caller's active mission.
+func (s *MeService) UpdateTarget(ctx context.Context, targetID uint, dto models.UpdateTargetDTO) (*models.Mission, error) {
+	catID, err := callerCatID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	mission, err := s.activeMission(catID)
+	if err != nil {
+		return nil, err
+	}
+
+	mission, err = s.missionService.UpdateTarget(ctx, mission.ID, targetID, dto)
+	if err != nil {
+		return nil, err
+	}
+
+	return withoutCat(mission), nil
+}
+
+func (s *MeService) activeMission(catID uint) (*models.Mission, error) {
+	mission, err := s.missionRepo.GetActiveByCatID(catID)
+	if err != nil {
+		return nil, err
+	}
+	if mission == nil {
+		return nil, custerr.NewNotFoundErr("no active mission").WithCode(models.ErrCodeNoActiveMission)
+	}
+	return mission, nil
+}
+
+func callerCatID(ctx context.Context) (uint, error) {
+	principal, ok := auth.FromContext(ctx)
+	if !ok || principal.Role != auth.RoleFieldCat || principal.CatID == nil {
+		return 0, custerr.NewForbiddenErr("only field cats have a profile")
+	}
+	return *principal.CatID, nil
+}
+
+// withoutCat drops the preloaded cat, which carries its salary.
+func withoutCat(mission *models.Mission) *models.Mission {
+	mission.Cat = nil
+	return mission
+}
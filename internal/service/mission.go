@@ -1,196 +1,526 @@
 package service
 
 import (
+	"context"
+	"fmt"
+	"spy-cat-agency/internal/actor"
+	"spy-cat-agency/internal/auth"
 	"spy-cat-agency/internal/models"
 	"spy-cat-agency/pkg/custerr"
+	"spy-cat-agency/pkg/textdiff"
 )
 
 type MissionService struct {
 	missionRepo MissionRepository
 	targetRepo  TargetRepository
+	uow         UnitOfWork
 }
 
-func NewMissionService(missionRepo MissionRepository, targetRepo TargetRepository) *MissionService {
+func NewMissionService(missionRepo MissionRepository, targetRepo TargetRepository, uow UnitOfWork) *MissionService {
 	return &MissionService{
 		missionRepo: missionRepo,
 		targetRepo:  targetRepo,
+		uow:         uow,
 	}
 }
 
-func (s *MissionService) Create(dto models.CreateMissionDTO) (*models.Mission, error) {
-	mission := &models.Mission{}
+func (s *MissionService) Create(ctx context.Context, dto models.CreateMissionDTO) (*models.Mission, error) {
+	var mission *models.Mission
+
+	err := s.uow.Do(func(repos Repositories) error {
+		var err error
+		mission, err = createMission(ctx, repos, dto.Targets)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	if err := s.missionRepo.Create(mission); err != nil {
+	return s.missionRepo.GetByID(mission.ID)
+}
+
+// createMission creates an open mission without a cat along with its
+// targets.
+func createMission(ctx context.Context, repos Repositories, targets []models.CreateTargetDTO) (*models.Mission, error) {
+	mission := &models.Mission{}
+	if err := repos.Mission.Create(mission); err != nil {
+		return nil, err
+	}
+	if err := recordAudit(ctx, repos.Audit, models.AuditActionCreate, models.AuditEntityMission, mission.ID, nil, missionSnapshot(mission)); err != nil {
 		return nil, err
 	}
 
-	for _, targetReq := range dto.Targets {
+	for _, targetReq := range targets {
 		target := &models.Target{
 			MissionID: mission.ID,
 			Name:      targetReq.Name,
 			Country:   targetReq.Country,
 			Notes:     targetReq.Notes,
+			Status:    models.TargetStatusPending,
+		}
+		if err := repos.Target.Create(target); err != nil {
+			return nil, err
+		}
+		if err := recordNotesRevision(ctx, repos.Target, target); err != nil {
+			return nil, err
 		}
-		if err := s.targetRepo.Create(target); err != nil {
+		if err := recordAudit(ctx, repos.Audit, models.AuditActionCreate, models.AuditEntityTarget, target.ID, nil, targetSnapshot(target)); err != nil {
 			return nil, err
 		}
 	}
 
-	return s.missionRepo.GetByID(mission.ID)
+	return mission, nil
 }
 
-func (s *MissionService) GetAll() ([]models.Mission, error) {
-	return s.missionRepo.GetAll()
+func (s *MissionService) GetAll(ctx context.Context, filter models.MissionFilter) (*models.Page[models.Mission], error) {
+	missions, total, err := s.missionRepo.GetAll(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.NewPage(missions, filter.PageQuery, total), nil
 }
 
-func (s *MissionService) GetByID(id uint) (*models.Mission, error) {
+func (s *MissionService) GetByID(ctx context.Context, id uint) (*models.Mission, error) {
 	return s.missionRepo.GetByID(id)
 }
 
-func (s *MissionService) Update(id uint, dto models.UpdateMissionDTO) (*models.Mission, error) {
-	mission, err := s.missionRepo.GetByID(id)
+func (s *MissionService) Update(ctx context.Context, id uint, dto models.UpdateMissionDTO) (*models.Mission, error) {
+	var mission *models.Mission
+
+	err := s.uow.Do(func(repos Repositories) error {
+		var err error
+		mission, err = repos.Mission.GetByID(id)
+		if err != nil {
+			return err
+		}
+		before := missionSnapshot(mission)
+
+		if dto.Complete != nil {
+			if *dto.Complete && !mission.Complete && !dto.Force && !allTargetsTerminal(mission.Targets) {
+				return custerr.NewConflictErr("cannot complete mission while targets are still open").WithCode(models.ErrCodeMissionTargetsOpen)
+			}
+			// terminal targets cannot be updated, so nothing would ever
+			// complete such a mission again
+			if !*dto.Complete && mission.Complete && !dto.Force && allTargetsTerminal(mission.Targets) {
+				return custerr.NewConflictErr("cannot reopen mission while all targets are terminal").WithCode(models.ErrCodeMissionTargetsTerminal)
+			}
+			mission.Complete = *dto.Complete
+		}
+
+		if err := repos.Mission.Update(mission); err != nil {
+			return err
+		}
+		return recordAudit(ctx, repos.Audit, models.AuditActionUpdate, models.AuditEntityMission, mission.ID, before, missionSnapshot(mission))
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	if dto.Complete != nil {
-		mission.Complete = *dto.Complete
-	}
+	return mission, nil
+}
+
+func (s *MissionService) Delete(ctx context.Context, id uint) error {
+	return s.uow.Do(func(repos Repositories) error {
+		mission, err := repos.Mission.GetByID(id)
+		if err != nil {
+			return err
+		}
 
-	return mission, s.missionRepo.Update(mission)
+		if mission.CatID != nil {
+			return custerr.NewConflictErr("cannot delete assigned mission, unassign its cat first").WithCode(models.ErrCodeMissionAssigned)
+		}
+
+		if err := repos.Mission.Delete(id); err != nil {
+			return err
+		}
+		return recordAudit(ctx, repos.Audit, models.AuditActionDelete, models.AuditEntityMission, id, missionSnapshot(mission), nil)
+	})
 }
 
-func (s *MissionService) Delete(id uint) error {
-	mission, err := s.missionRepo.GetByID(id)
-	if err != nil {
-		return err
-	}
+func (s *MissionService) AssignCat(ctx context.Context, missionID, catID uint) (*models.Mission, error) {
+	err := s.uow.Do(func(repos Repositories) error {
+		mission, err := repos.Mission.GetByIDForUpdate(missionID)
+		if err != nil {
+			return err
+		}
+
+		if mission.Complete {
+			return custerr.NewConflictErr("cannot assign cat to completed mission").WithCode(models.ErrCodeMissionCompleted)
+		}
+		if mission.CatID != nil {
+			return custerr.NewConflictErr("mission already has an assigned cat, reassign it instead").WithCode(models.ErrCodeMissionAssigned)
+		}
 
-	if mission.CatID != nil {
-		return custerr.NewConflictErr("cannot delete assigned mission")
+		if err := claimCat(repos, catID); err != nil {
+			return err
+		}
+
+		return changeAssignment(ctx, repos, mission, &catID, "")
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return s.missionRepo.Delete(id)
+	return s.missionRepo.GetByID(missionID)
 }
 
-func (s *MissionService) AssignCat(missionID, catID uint) (*models.Mission, error) {
-	mission, err := s.missionRepo.GetByID(missionID)
+func (s *MissionService) UnassignCat(ctx context.Context, missionID uint, dto models.MissionAssignmentDTO) (*models.Mission, error) {
+	err := s.uow.Do(func(repos Repositories) error {
+		mission, err := repos.Mission.GetByIDForUpdate(missionID)
+		if err != nil {
+			return err
+		}
+
+		if mission.Complete {
+			return custerr.NewConflictErr("cannot unassign cat from completed mission").WithCode(models.ErrCodeMissionCompleted)
+		}
+		if mission.CatID == nil {
+			return custerr.NewConflictErr("mission has no assigned cat").WithCode(models.ErrCodeMissionUnassigned)
+		}
+
+		return changeAssignment(ctx, repos, mission, nil, dto.Reason)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	if mission.Complete {
-		return nil, custerr.NewConflictErr("cannot assign cat to completed mission")
-	}
+	return s.missionRepo.GetByID(missionID)
+}
+
+// ReassignCat hands an active mission over to another cat in a single
+// transaction, so the mission is never left without a cat in between.
+func (s *MissionService) ReassignCat(ctx context.Context, missionID, catID uint, dto models.MissionAssignmentDTO) (*models.Mission, error) {
+	err := s.uow.Do(func(repos Repositories) error {
+		mission, err := repos.Mission.GetByIDForUpdate(missionID)
+		if err != nil {
+			return err
+		}
 
-	activeMission, err := s.missionRepo.GetActiveByCatID(catID)
+		if mission.Complete {
+			return custerr.NewConflictErr("cannot reassign completed mission").WithCode(models.ErrCodeMissionCompleted)
+		}
+		if mission.CatID == nil {
+			return custerr.NewConflictErr("mission has no assigned cat, assign one instead").WithCode(models.ErrCodeMissionUnassigned)
+		}
+		if *mission.CatID == catID {
+			return custerr.NewConflictErr("cat is already assigned to this mission").WithCode(models.ErrCodeCatAlreadyAssigned)
+		}
+
+		if err := claimCat(repos, catID); err != nil {
+			return err
+		}
+
+		return changeAssignment(ctx, repos, mission, &catID, dto.Reason)
+	})
 	if err != nil {
 		return nil, err
 	}
-	if activeMission != nil {
-		return nil, custerr.NewConflictErr("cat already has an active mission")
-	}
 
-	mission.CatID = &catID
-
-	return mission, s.missionRepo.Update(mission)
+	return s.missionRepo.GetByID(missionID)
 }
 
-func (s *MissionService) CreateTarget(missionID uint, dto models.CreateTargetDTO) (*models.Mission, error) {
-	mission, err := s.missionRepo.GetByID(missionID)
-	if err != nil {
+func (s *MissionService) GetAssignments(ctx context.Context, missionID uint) ([]models.MissionAssignment, error) {
+	if _, err := s.missionRepo.GetByID(missionID); err != nil {
 		return nil, err
 	}
 
-	if mission.Complete {
-		return nil, custerr.NewConflictErr("cannot add targets to completed mission")
+	return s.missionRepo.GetAssignments(missionID)
+}
+
+// claimCat makes sure a cat exists and is free to take a mission.
+func claimCat(repos Repositories, catID uint) error {
+	// Locking the cat row serializes concurrent assignments of the same
+	// cat, so the active mission check below cannot be raced.
+	if _, err := repos.Cat.GetByIDForUpdate(catID); err != nil {
+		return err
 	}
 
-	count, err := s.targetRepo.CountByMissionID(missionID)
+	activeMission, err := repos.Mission.GetActiveByCatID(catID)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	if activeMission != nil {
+		return custerr.NewConflictErr("cat already has an active mission").WithCode(models.ErrCodeCatAlreadyOnMission)
 	}
 
-	if count >= 3 {
-		return nil, custerr.NewConflictErr("mission cannot have more than 3 targets")
+	return nil
+}
+
+// changeAssignment moves a mission to catID, or leaves it without a cat when
+// catID is nil, and records the change with its reason.
+func changeAssignment(ctx context.Context, repos Repositories, mission *models.Mission, catID *uint, reason string) error {
+	before := missionSnapshot(mission)
+	assignment := &models.MissionAssignment{
+		MissionID: mission.ID,
+		FromCatID: mission.CatID,
+		ToCatID:   catID,
+		Reason:    reason,
+		Actor:     actor.FromContext(ctx),
 	}
 
-	target := &models.Target{
-		MissionID: missionID,
-		Name:      dto.Name,
-		Country:   dto.Country,
-		Notes:     dto.Notes,
+	mission.CatID = catID
+	if err := repos.Mission.Update(mission); err != nil {
+		return err
+	}
+	if err := repos.Mission.CreateAssignment(assignment); err != nil {
+		return err
 	}
+	return recordAudit(ctx, repos.Audit, models.AuditActionUpdate, models.AuditEntityMission, mission.ID, before, missionSnapshot(mission))
+}
 
-	if err := s.targetRepo.Create(target); err != nil {
+func (s *MissionService) CreateTarget(ctx context.Context, missionID uint, dto models.CreateTargetDTO) (*models.Mission, error) {
+	err := s.uow.Do(func(repos Repositories) error {
+		mission, err := repos.Mission.GetByIDForUpdate(missionID)
+		if err != nil {
+			return err
+		}
+
+		if mission.Complete {
+			return custerr.NewConflictErr("cannot add targets to completed mission").WithCode(models.ErrCodeMissionCompleted)
+		}
+
+		count, err := repos.Target.CountByMissionID(missionID)
+		if err != nil {
+			return err
+		}
+
+		if count >= 3 {
+			return custerr.NewConflictErr("mission cannot have more than 3 targets").WithCode(models.ErrCodeMissionTargetLimit)
+		}
+
+		target := &models.Target{
+			MissionID: missionID,
+			Name:      dto.Name,
+			Country:   dto.Country,
+			Notes:     dto.Notes,
+			Status:    models.TargetStatusPending,
+		}
+
+		if err := repos.Target.Create(target); err != nil {
+			return err
+		}
+		if err := recordNotesRevision(ctx, repos.Target, target); err != nil {
+			return err
+		}
+		return recordAudit(ctx, repos.Audit, models.AuditActionCreate, models.AuditEntityTarget, target.ID, nil, targetSnapshot(target))
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return s.missionRepo.GetByID(missionID)
 }
 
-func (s *MissionService) UpdateTarget(missionID, targetID uint, dto models.UpdateTargetDTO) (*models.Mission, error) {
-	target, err := s.targetRepo.GetByID(targetID)
+func (s *MissionService) UpdateTarget(ctx context.Context, missionID, targetID uint, dto models.UpdateTargetDTO) (*models.Mission, error) {
+	err := s.uow.Do(func(repos Repositories) error {
+		// locking the mission serializes updates of its targets, so the status
+		// checked below and the targets deciding completion are current
+		mission, err := repos.Mission.GetByIDForUpdate(missionID)
+		if err != nil {
+			return err
+		}
+
+		target, err := repos.Target.GetByMissionAndID(missionID, targetID)
+		if err != nil {
+			return err
+		}
+
+		// field cats may only work on targets of the mission assigned to them
+		if principal, ok := auth.FromContext(ctx); ok && principal.Role == auth.RoleFieldCat && !principal.IsCat(mission.CatID) {
+			return custerr.NewForbiddenErr("field cats can only update targets of their own mission").WithCode(models.ErrCodeNotOwnMission)
+		}
+
+		if target.Status.IsTerminal() {
+			return custerr.NewConflictErr(fmt.Sprintf("cannot update target in terminal status \"%s\"", target.Status)).WithCode(models.ErrCodeTargetTerminal)
+		}
+		if mission.Complete {
+			return custerr.NewConflictErr("cannot update notes if mission is completed").WithCode(models.ErrCodeMissionCompleted)
+		}
+
+		before := targetSnapshot(target)
+
+		next := target.Status
+		if dto.Complete != nil && *dto.Complete {
+			next = models.TargetStatusNeutralized
+		}
+		if dto.Status != nil {
+			next = *dto.Status
+		}
+
+		notesChanged := dto.Notes != nil && *dto.Notes != target.Notes
+		if notesChanged {
+			target.Notes = *dto.Notes
+		}
+
+		if next != target.Status {
+			if !target.Status.CanTransitionTo(next) {
+				return custerr.NewConflictErr(fmt.Sprintf("cannot change target status from \"%s\" to \"%s\"", target.Status, next)).WithCode(models.ErrCodeInvalidStatusTransition)
+			}
+
+			transition := &models.TargetTransition{TargetID: target.ID, From: target.Status, To: next}
+			if err := repos.Target.CreateTransition(transition); err != nil {
+				return err
+			}
+
+			target.Status = next
+			target.Complete = next.IsTerminal()
+		}
+
+		if err := repos.Target.Update(target); err != nil {
+			return err
+		}
+		if notesChanged {
+			if err := recordNotesRevision(ctx, repos.Target, target); err != nil {
+				return err
+			}
+		}
+		if err := recordAudit(ctx, repos.Audit, models.AuditActionUpdate, models.AuditEntityTarget, target.ID, before, targetSnapshot(target)); err != nil {
+			return err
+		}
+
+		if !target.Complete {
+			return nil
+		}
+
+		// read the targets again, including the one just updated
+		mission, err = repos.Mission.GetByID(missionID)
+		if err != nil {
+			return err
+		}
+		return completeIfTargetsTerminal(ctx, repos, mission)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	if target.MissionID != missionID {
-		return nil, custerr.NewBadRequestErr("target does not belong to this mission")
-	}
+	return s.missionRepo.GetByID(missionID)
+}
 
-	mission, err := s.missionRepo.GetByID(missionID)
-	if err != nil {
-		return nil, err
+// recordNotesRevision stores the current notes of a target as a new revision
+// authored by the actor in ctx. Targets created without notes get none.
+func recordNotesRevision(ctx context.Context, repo TargetRepository, target *models.Target) error {
+	if target.Notes == "" {
+		return nil
 	}
+	return repo.CreateNoteRevision(&models.TargetNoteRevision{
+		TargetID: target.ID,
+		Author:   actor.FromContext(ctx),
+		Notes:    target.Notes,
+	})
+}
 
-	if target.Complete {
-		return nil, custerr.NewConflictErr("cannot update notes of completed target")
+// allTargetsTerminal reports whether a mission has targets and all of them
+// reached a terminal status.
+func allTargetsTerminal(targets []models.Target) bool {
+	if len(targets) == 0 {
+		return false
 	}
-	if mission.Complete {
-		return nil, custerr.NewConflictErr("cannot update notes if mission is completed")
+	for _, t := range targets {
+		if !t.Status.IsTerminal() {
+			return false
+		}
 	}
+	return true
+}
 
-	if dto.Notes != nil {
-		target.Notes = *dto.Notes
+// completeIfTargetsTerminal completes an open mission once its last target
+// is terminal, which also frees the assigned cat for a new mission.
+func completeIfTargetsTerminal(ctx context.Context, repos Repositories, mission *models.Mission) error {
+	if mission.Complete || !allTargetsTerminal(mission.Targets) {
+		return nil
 	}
-	if dto.Complete != nil {
-		target.Complete = *dto.Complete
+
+	before := missionSnapshot(mission)
+	mission.Complete = true
+
+	if err := repos.Mission.Update(mission); err != nil {
+		return err
 	}
+	return recordAudit(ctx, repos.Audit, models.AuditActionUpdate, models.AuditEntityMission, mission.ID, before, missionSnapshot(mission))
+}
+
+func (s *MissionService) DeleteTarget(ctx context.Context, missionID, targetID uint) (*models.Mission, error) {
+	err := s.uow.Do(func(repos Repositories) error {
+		// the mission lock keeps a concurrent update from completing the
+		// target between the check below and the delete
+		if _, err := repos.Mission.GetByIDForUpdate(missionID); err != nil {
+			return err
+		}
+
+		target, err := repos.Target.GetByMissionAndID(missionID, targetID)
+		if err != nil {
+			return err
+		}
+
+		if target.Status.IsTerminal() {
+			return custerr.NewConflictErr("cannot delete completed target").WithCode(models.ErrCodeTargetTerminal)
+		}
+
+		count, err := repos.Target.CountByMissionID(missionID)
+		if err != nil {
+			return err
+		}
 
-	if err := s.targetRepo.Update(target); err != nil {
+		if count <= 1 {
+			return custerr.NewConflictErr("mission must have at least 1 target").WithCode(models.ErrCodeMissionTargetLimit)
+		}
+
+		if err := repos.Target.Delete(targetID); err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, repos.Audit, models.AuditActionDelete, models.AuditEntityTarget, targetID, targetSnapshot(target), nil); err != nil {
+			return err
+		}
+
+		// removing the last open target may leave only terminal ones
+		mission, err := repos.Mission.GetByID(missionID)
+		if err != nil {
+			return err
+		}
+		return completeIfTargetsTerminal(ctx, repos, mission)
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return s.missionRepo.GetByID(missionID)
 }
 
-func (s *MissionService) DeleteTarget(missionID, targetID uint) (*models.Mission, error) {
-	target, err := s.targetRepo.GetByID(targetID)
-	if err != nil {
+func (s *MissionService) GetTargetRevisions(ctx context.Context, missionID, targetID uint) ([]models.TargetNoteRevision, error) {
+	if _, err := s.targetRepo.GetByMissionAndID(missionID, targetID); err != nil {
 		return nil, err
 	}
 
-	if target.MissionID != missionID {
-		return nil, custerr.NewConflictErr("target does not belong to this mission")
-	}
+	return s.targetRepo.GetNoteRevisions(targetID)
+}
 
-	if target.Complete {
-		return nil, custerr.NewConflictErr("cannot delete completed target")
+func (s *MissionService) GetTargetRevision(ctx context.Context, missionID, targetID, revisionID uint) (*models.TargetNoteRevision, error) {
+	if _, err := s.targetRepo.GetByMissionAndID(missionID, targetID); err != nil {
+		return nil, err
 	}
 
-	count, err := s.targetRepo.CountByMissionID(missionID)
-	if err != nil {
+	return s.targetRepo.GetNoteRevision(targetID, revisionID)
+}
+
+func (s *MissionService) DiffTargetRevisions(ctx context.Context, missionID, targetID, fromID, toID uint) (*models.TargetNotesDiff, error) {
+	if _, err := s.targetRepo.GetByMissionAndID(missionID, targetID); err != nil {
 		return nil, err
 	}
 
-	if count <= 1 {
-		return nil, custerr.NewConflictErr("mission must have at least 1 target")
+	from, err := s.targetRepo.GetNoteRevision(targetID, fromID)
+	if err != nil {
+		return nil, err
 	}
-
-	if err := s.targetRepo.Delete(targetID); err != nil {
+	to, err := s.targetRepo.GetNoteRevision(targetID, toID)
+	if err != nil {
 		return nil, err
 	}
 
-	return s.missionRepo.GetByID(missionID)
+	return &models.TargetNotesDiff{
+		TargetID: targetID,
+		From:     from.ID,
+		To:       to.ID,
+		Lines:    textdiff.Lines(from.Notes, to.Notes),
+	}, nil
 }
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"math"
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/pkg/custerr"
+	"time"
+)
+
+const payrollDaysPerYear = 365
+
+type PayrollService struct {
+	repo CatRepository
+}
+
+func NewPayrollService(repo CatRepository) *PayrollService {
+	return &PayrollService{repo: repo}
+}
+
+// GetReport works out what every cat was owed from the first to the last day
+// of the query. Each day is paid at the salary in effect that day, where the
+// last change made on a day counts for the whole day. A deleted cat is paid
+// up to the day before it was deleted.
+func (s *PayrollService) GetReport(ctx context.Context, query models.PayrollQuery) (*models.PayrollReport, error) {
+	start := payrollDay(*query.From)
+	end := payrollDay(*query.To).AddDate(0, 0, 1)
+	if !start.Before(end) {
+		return nil, custerr.NewBadRequestErr("from cannot be after to").WithCode(models.ErrCodeInvalidDateRange)
+	}
+
+	cats, err := s.repo.GetPayrollCats(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.PayrollReport{
+		From: start.Format(models.PayrollDateFormat),
+		To:   end.AddDate(0, 0, -1).Format(models.PayrollDateFormat),
+		Days: days(start, end),
+		Cats: []models.CatPayroll{},
+	}
+	for _, cat := range cats {
+		payroll := catPayroll(cat, start, end)
+		if payroll.Days == 0 {
+			continue
+		}
+		report.Cats = append(report.Cats, payroll)
+		report.Total += payroll.Owed
+	}
+	report.Total = cents(report.Total)
+
+	return report, nil
+}
+
+// catPayroll splits [start, end) into periods at the days the cat's salary
+// changed. cat.Salaries must be ordered by effective_from.
+func catPayroll(cat models.Cat, start, end time.Time) models.CatPayroll {
+	payroll := models.CatPayroll{
+		CatID:   cat.ID,
+		Name:    cat.Name,
+		Deleted: cat.DeletedAt.Valid,
+		Periods: []models.PayrollPeriod{},
+	}
+	if cat.DeletedAt.Valid {
+		if deleted := payrollDay(cat.DeletedAt.Time); deleted.Before(end) {
+			end = deleted
+		}
+	}
+
+	for i, salary := range cat.Salaries {
+		from := payrollDay(salary.EffectiveFrom)
+		to := end
+		if i+1 < len(cat.Salaries) {
+			to = payrollDay(cat.Salaries[i+1].EffectiveFrom)
+		}
+		if from.Before(start) {
+			from = start
+		}
+		if to.After(end) {
+			to = end
+		}
+		// also skips changes overridden later on the same day
+		if !from.Before(to) {
+			continue
+		}
+
+		period := models.PayrollPeriod{
+			From:   from.Format(models.PayrollDateFormat),
+			To:     to.AddDate(0, 0, -1).Format(models.PayrollDateFormat),
+			Salary: salary.Salary,
+			Days:   days(from, to),
+		}
+		period.Amount = cents(salary.Salary * float64(period.Days) / payrollDaysPerYear)
+
+		payroll.Periods = append(payroll.Periods, period)
+		payroll.Days += period.Days
+		payroll.Owed += period.Amount
+	}
+	payroll.Owed = cents(payroll.Owed)
+
+	return payroll
+}
+
+// payrollDay is the start of the UTC day t falls on.
+func payrollDay(t time.Time) time.Time {
+	year, month, day := t.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func days(from, to time.Time) int {
+	return int(to.Sub(from) / (24 * time.Hour))
+}
+
+func cents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
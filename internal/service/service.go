@@ -7,15 +7,56 @@ import (
 )
 
 type Service struct {
-	Cat     *CatService
-	Mission *MissionService
+	Cat      *CatService
+	Mission  *MissionService
+	Breed    *BreedService
+	Audit    *AuditService
+	Me       *MeService
+	Trash    *TrashService
+	Transfer *TransferService
+	Stats    *StatsService
+	Payroll  *PayrollService
+	Catalog  *catapi.Catalog
 }
 
 func New(repo *repository.Repository, cfg *config.Config) *Service {
-	catValidator := catapi.NewCatValidator()
+	catalog := catapi.NewCatalog(catapi.CatalogConfig{
+		BaseURL:      cfg.CatAPIBaseURL,
+		TTL:          cfg.BreedCacheTTL,
+		SnapshotPath: cfg.BreedSnapshotPath,
+	})
+	catValidator := catapi.NewCatValidator(catalog)
+	uow := unitOfWork{uow: repo.UnitOfWork}
+
+	missionService := NewMissionService(repo.Mission, repo.Target, uow)
 
 	return &Service{
-		Cat:     NewCatService(repo.Cat, catValidator),
-		Mission: NewMissionService(repo.Mission, repo.Target),
+		Cat:      NewCatService(repo.Cat, uow, catValidator),
+		Mission:  missionService,
+		Breed:    NewBreedService(catalog),
+		Audit:    NewAuditService(repo.Audit),
+		Me:       NewMeService(repo.Cat, repo.Mission, missionService),
+		Trash:    NewTrashService(repo.Cat, repo.Mission, repo.Target, uow),
+		Transfer: NewTransferService(repo.Cat, repo.Mission, uow, catValidator),
+		Stats:    NewStatsService(repo.Stats),
+		Payroll:  NewPayrollService(repo.Cat),
+		Catalog:  catalog,
 	}
 }
+
+// unitOfWork adapts repository.UnitOfWork to the service-level UnitOfWork
+// interface so services only ever see repository interfaces.
+type unitOfWork struct {
+	uow *repository.UnitOfWork
+}
+
+func (u unitOfWork) Do(fn func(repos Repositories) error) error {
+	return u.uow.Do(func(repos *repository.Repository) error {
+		return fn(Repositories{
+			Cat:     repos.Cat,
+			Mission: repos.Mission,
+			Target:  repos.Target,
+			Audit:   repos.Audit,
+		})
+	})
+}
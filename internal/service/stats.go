@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"math"
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/pkg/custerr"
+)
+
+type StatsService struct {
+	repo StatsRepository
+}
+
+func NewStatsService(repo StatsRepository) *StatsService {
+	return &StatsService{repo: repo}
+}
+
+func (s *StatsService) GetOverview(ctx context.Context, filter models.StatsFilter) (*models.StatsOverview, error) {
+	if err := checkStatsRange(filter); err != nil {
+		return nil, err
+	}
+
+	payroll, err := s.repo.Payroll(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	missions, err := s.repo.Missions(filter)
+	if err != nil {
+		return nil, err
+	}
+	missions.CompletionRate = rate(missions.Complete, missions.Total)
+
+	idleCats, err := s.repo.CountIdleCats(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.StatsOverview{
+		Payroll:  *payroll,
+		Missions: *missions,
+		IdleCats: idleCats,
+	}, nil
+}
+
+func (s *StatsService) GetCatCompletion(ctx context.Context, filter models.StatsFilter) ([]models.CatCompletionStats, error) {
+	if err := checkStatsRange(filter); err != nil {
+		return nil, err
+	}
+
+	stats, err := s.repo.CatCompletion(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range stats {
+		stats[i].CompletionRate = rate(stats[i].Completed, stats[i].Missions)
+	}
+	return stats, nil
+}
+
+func (s *StatsService) GetTargetsByCountry(ctx context.Context, filter models.StatsFilter) ([]models.CountryStats, error) {
+	if err := checkStatsRange(filter); err != nil {
+		return nil, err
+	}
+
+	return s.repo.TargetsByCountry(filter)
+}
+
+func (s *StatsService) GetIdleCats(ctx context.Context, filter models.StatsFilter) ([]models.IdleCat, error) {
+	if err := checkStatsRange(filter); err != nil {
+		return nil, err
+	}
+
+	return s.repo.IdleCats(filter)
+}
+
+func checkStatsRange(filter models.StatsFilter) error {
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return custerr.NewBadRequestErr("from must be before to").WithCode(models.ErrCodeInvalidDateRange)
+	}
+	return nil
+}
+
+// rate is part/total rounded to four decimals, 0 when there is no total.
+func rate(part, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(part)/float64(total)*10000) / 10000
+}
@@ -0,0 +1,173 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"spy-cat-agency/internal/actor"
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/internal/service"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// FakeAuditRepository keeps audit entries in memory so tests can inspect
+// them without setting an expectation for every write.
+type FakeAuditRepository struct {
+	entries []models.AuditLog
+	page    []models.AuditLog
+	total   int64
+	err     error
+}
+
+func (f *FakeAuditRepository) Create(entry *models.AuditLog) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.entries = append(f.entries, *entry)
+	return nil
+}
+
+func (f *FakeAuditRepository) GetAll(filter models.AuditFilter) ([]models.AuditLog, int64, error) {
+	return f.page, f.total, f.err
+}
+
+func TestAuditService_GetAll(t *testing.T) {
+	t.Run("returns page metadata", func(t *testing.T) {
+		entries := []models.AuditLog{{ID: 1}, {ID: 2}}
+		auditService := service.NewAuditService(&FakeAuditRepository{page: entries, total: 3})
+
+		result, err := auditService.GetAll(context.Background(), models.AuditFilter{PageQuery: models.PageQuery{PerPage: 2}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, entries, result.Items)
+		assert.Equal(t, models.PageMeta{Page: 1, PerPage: 2, Total: 3, TotalPages: 2}, result.Meta)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		auditService := service.NewAuditService(&FakeAuditRepository{err: errors.New("database error")})
+
+		result, err := auditService.GetAll(context.Background(), models.AuditFilter{})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
+
+func TestAudit_RecordsChanges(t *testing.T) {
+	t.Run("cat update records actor and before and after state", func(t *testing.T) {
+		mockRepo := new(MockCatRepository)
+		mockMissionRepo := new(MockMissionRepository)
+		uow := newMockUnitOfWork(mockRepo, mockMissionRepo, new(MockTargetRepository))
+		catService := service.NewCatService(mockRepo, uow, new(MockCatValidator))
+
+		mockRepo.On("GetByID", uint(1)).Return(&models.Cat{ID: 1, CreateCatDTO: models.CreateCatDTO{Name: "Agent Whiskers", Salary: 50000}}, nil)
+		mockRepo.On("CreateSalary", mock.AnythingOfType("*models.CatSalary")).Return(nil)
+		mockRepo.On("Update", mock.AnythingOfType("*models.Cat")).Return(nil)
+
+		ctx := actor.WithName(context.Background(), "handler-jane")
+		_, err := catService.Update(ctx, 1, models.UpdateCatDTO{Salary: 60000})
+
+		require.NoError(t, err)
+		require.Len(t, uow.audit.entries, 1)
+		entry := uow.audit.entries[0]
+		assert.Equal(t, "handler-jane", entry.Actor)
+		assert.Equal(t, models.AuditActionUpdate, entry.Action)
+		assert.Equal(t, models.AuditEntityCat, entry.EntityType)
+		assert.Equal(t, uint(1), entry.EntityID)
+		assert.Equal(t, float64(50000), auditField(t, entry.Before, "salary"))
+		assert.Equal(t, float64(60000), auditField(t, entry.After, "salary"))
+	})
+
+	t.Run("cat delete records anonymous actor and no after state", func(t *testing.T) {
+		mockRepo := new(MockCatRepository)
+		mockMissionRepo := new(MockMissionRepository)
+		uow := newMockUnitOfWork(mockRepo, mockMissionRepo, new(MockTargetRepository))
+		catService := service.NewCatService(mockRepo, uow, new(MockCatValidator))
+
+		mockRepo.On("GetByIDForUpdate", uint(1)).Return(&models.Cat{ID: 1}, nil)
+		mockMissionRepo.On("GetActiveByCatID", uint(1)).Return(nil, nil)
+		mockRepo.On("Delete", uint(1)).Return(nil)
+
+		err := catService.Delete(context.Background(), 1, false)
+
+		require.NoError(t, err)
+		require.Len(t, uow.audit.entries, 1)
+		entry := uow.audit.entries[0]
+		assert.Equal(t, actor.Anonymous, entry.Actor)
+		assert.Equal(t, models.AuditActionDelete, entry.Action)
+		assert.NotNil(t, entry.Before)
+		assert.Nil(t, entry.After)
+	})
+
+	t.Run("failed change is not recorded", func(t *testing.T) {
+		mockRepo := new(MockCatRepository)
+		mockMissionRepo := new(MockMissionRepository)
+		uow := newMockUnitOfWork(mockRepo, mockMissionRepo, new(MockTargetRepository))
+		catService := service.NewCatService(mockRepo, uow, new(MockCatValidator))
+
+		mockRepo.On("GetByIDForUpdate", uint(1)).Return(&models.Cat{ID: 1}, nil)
+		mockMissionRepo.On("GetActiveByCatID", uint(1)).Return(nil, nil)
+		mockRepo.On("Delete", uint(1)).Return(errors.New("database error"))
+
+		err := catService.Delete(context.Background(), 1, false)
+
+		assert.Error(t, err)
+		assert.Empty(t, uow.audit.entries)
+	})
+
+	t.Run("audit failure fails the change", func(t *testing.T) {
+		mockRepo := new(MockCatRepository)
+		mockMissionRepo := new(MockMissionRepository)
+		uow := newMockUnitOfWork(mockRepo, mockMissionRepo, new(MockTargetRepository))
+		uow.audit.err = errors.New("audit write failed")
+		catService := service.NewCatService(mockRepo, uow, new(MockCatValidator))
+
+		mockRepo.On("GetByIDForUpdate", uint(1)).Return(&models.Cat{ID: 1}, nil)
+		mockMissionRepo.On("GetActiveByCatID", uint(1)).Return(nil, nil)
+		mockRepo.On("Delete", uint(1)).Return(nil)
+
+		err := catService.Delete(context.Background(), 1, false)
+
+		assert.EqualError(t, err, "audit write failed")
+	})
+
+	t.Run("target completion records target and mission updates", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
+		mockMissionRepo := new(MockMissionRepository)
+		mockTargetRepo := new(MockTargetRepository)
+		uow := newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, uow)
+
+		target := &models.Target{ID: 1, MissionID: 1, Status: models.TargetStatusUnderSurveillance}
+		mission := &models.Mission{ID: 1, Targets: []models.Target{{ID: 1, MissionID: 1, Status: models.TargetStatusNeutralized, Complete: true}}}
+
+		mockMissionRepo.On("GetByIDForUpdate", uint(1)).Return(&models.Mission{ID: 1}, nil)
+		mockTargetRepo.On("GetByMissionAndID", uint(1), uint(1)).Return(target, nil)
+		mockMissionRepo.On("GetByID", uint(1)).Return(mission, nil)
+		mockTargetRepo.On("CreateTransition", mock.AnythingOfType("*models.TargetTransition")).Return(nil)
+		mockTargetRepo.On("Update", target).Return(nil)
+		mockMissionRepo.On("Update", mission).Return(nil)
+
+		status := models.TargetStatusNeutralized
+		_, err := missionService.UpdateTarget(context.Background(), 1, 1, models.UpdateTargetDTO{Status: &status})
+
+		require.NoError(t, err)
+		require.Len(t, uow.audit.entries, 2)
+		assert.Equal(t, models.AuditEntityTarget, uow.audit.entries[0].EntityType)
+		assert.Equal(t, "neutralized", auditField(t, uow.audit.entries[0].After, "status"))
+		assert.Equal(t, models.AuditEntityMission, uow.audit.entries[1].EntityType)
+		assert.Equal(t, false, auditField(t, uow.audit.entries[1].Before, "complete"))
+		assert.Equal(t, true, auditField(t, uow.audit.entries[1].After, "complete"))
+	})
+}
+
+func auditField(t *testing.T, state json.RawMessage, field string) any {
+	t.Helper()
+	var fields map[string]any
+	require.NoError(t, json.Unmarshal(state, &fields))
+	return fields[field]
+}
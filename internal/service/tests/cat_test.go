@@ -1,9 +1,14 @@
 package tests
 
 import (
+	"context"
+	"errors"
 	"spy-cat-agency/internal/models"
 	"spy-cat-agency/internal/service"
+	"spy-cat-agency/pkg/catapi"
+	"spy-cat-agency/pkg/custerr"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -18,9 +23,9 @@ func (m *MockCatRepository) Create(cat *models.Cat) error {
 	return args.Error(0)
 }
 
-func (m *MockCatRepository) GetAll() ([]models.Cat, error) {
-	args := m.Called()
-	return args.Get(0).([]models.Cat), args.Error(1)
+func (m *MockCatRepository) GetAll(filter models.CatFilter) ([]models.Cat, int64, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]models.Cat), args.Get(1).(int64), args.Error(2)
 }
 
 func (m *MockCatRepository) GetByID(id uint) (*models.Cat, error) {
@@ -31,6 +36,14 @@ func (m *MockCatRepository) GetByID(id uint) (*models.Cat, error) {
 	return args.Get(0).(*models.Cat), args.Error(1)
 }
 
+func (m *MockCatRepository) GetByIDForUpdate(id uint) (*models.Cat, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Cat), args.Error(1)
+}
+
 func (m *MockCatRepository) Update(cat *models.Cat) error {
 	args := m.Called(cat)
 	return args.Error(0)
@@ -41,20 +54,66 @@ func (m *MockCatRepository) Delete(id uint) error {
 	return args.Error(0)
 }
 
+func (m *MockCatRepository) GetDeleted(query models.PageQuery) ([]models.Cat, int64, error) {
+	args := m.Called(query)
+	return args.Get(0).([]models.Cat), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockCatRepository) GetDeletedByID(id uint) (*models.Cat, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Cat), args.Error(1)
+}
+
+func (m *MockCatRepository) Restore(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockCatRepository) Purge(before time.Time) (int64, error) {
+	args := m.Called(before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockCatRepository) CreateSalary(salary *models.CatSalary) error {
+	args := m.Called(salary)
+	return args.Error(0)
+}
+
+func (m *MockCatRepository) GetSalaries(catID uint) ([]models.CatSalary, error) {
+	args := m.Called(catID)
+	return args.Get(0).([]models.CatSalary), args.Error(1)
+}
+
+func (m *MockCatRepository) GetPayrollCats(start, end time.Time) ([]models.Cat, error) {
+	args := m.Called(start, end)
+	return args.Get(0).([]models.Cat), args.Error(1)
+}
+
 type MockCatValidator struct {
 	mock.Mock
 }
 
-func (m *MockCatValidator) ValidateBreed(breed string) (bool, error) {
+func (m *MockCatValidator) ValidateBreed(breed string) (*catapi.CatAPIBreed, error) {
 	args := m.Called(breed)
-	return args.Bool(0), args.Error(1)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*catapi.CatAPIBreed), args.Error(1)
+}
+
+func (m *MockCatValidator) SuggestBreeds(breed string) ([]catapi.BreedSuggestion, error) {
+	args := m.Called(breed)
+	return args.Get(0).([]catapi.BreedSuggestion), args.Error(1)
 }
 
 func TestCatService_CreateCat(t *testing.T) {
 	t.Run("successful creation", func(t *testing.T) {
 		mockRepo := new(MockCatRepository)
 		mockValidator := new(MockCatValidator)
-		catService := service.NewCatService(mockRepo, mockValidator)
+		catService := service.NewCatService(mockRepo, newMockUnitOfWork(mockRepo, new(MockMissionRepository), new(MockTargetRepository)), mockValidator)
 
 		catDTO := &models.CreateCatDTO{
 			Name:            "Agent Whiskers",
@@ -63,14 +122,42 @@ func TestCatService_CreateCat(t *testing.T) {
 			Salary:          50000,
 		}
 
-		mockValidator.On("ValidateBreed", "Persian").Return(true, nil)
+		mockValidator.On("ValidateBreed", "Persian").Return(&catapi.CatAPIBreed{ID: "pers", Name: "Persian"}, nil)
 		mockRepo.On("Create", mock.MatchedBy(func(cat *models.Cat) bool {
 			return cat.Name == "Agent Whiskers" && cat.Breed == "Persian"
 		})).Return(nil)
+		mockRepo.On("CreateSalary", mock.MatchedBy(func(salary *models.CatSalary) bool {
+			return salary.Salary == 50000 && salary.Actor == "anonymous"
+		})).Return(nil)
+
+		_, err := catService.Create(context.Background(), catDTO)
 
-		_, err := catService.Create(catDTO)
+		assert.NoError(t, err)
+		mockValidator.AssertExpectations(t)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("stores canonical breed", func(t *testing.T) {
+		mockRepo := new(MockCatRepository)
+		mockValidator := new(MockCatValidator)
+		catService := service.NewCatService(mockRepo, newMockUnitOfWork(mockRepo, new(MockMissionRepository), new(MockTargetRepository)), mockValidator)
+
+		catDTO := &models.CreateCatDTO{
+			Name:            "Agent Mittens",
+			YearsExperience: 2,
+			Breed:           "siam",
+			Salary:          40000,
+		}
+
+		mockValidator.On("ValidateBreed", "siam").Return(&catapi.CatAPIBreed{ID: "siam", Name: "Siamese"}, nil)
+		mockRepo.On("Create", mock.AnythingOfType("*models.Cat")).Return(nil)
+		mockRepo.On("CreateSalary", mock.AnythingOfType("*models.CatSalary")).Return(nil)
+
+		cat, err := catService.Create(context.Background(), catDTO)
 
 		assert.NoError(t, err)
+		assert.Equal(t, "siam", cat.BreedID)
+		assert.Equal(t, "Siamese", cat.Breed)
 		mockValidator.AssertExpectations(t)
 		mockRepo.AssertExpectations(t)
 	})
@@ -78,7 +165,7 @@ func TestCatService_CreateCat(t *testing.T) {
 	t.Run("invalid breed", func(t *testing.T) {
 		mockRepo := new(MockCatRepository)
 		mockValidator := new(MockCatValidator)
-		catService := service.NewCatService(mockRepo, mockValidator)
+		catService := service.NewCatService(mockRepo, newMockUnitOfWork(mockRepo, new(MockMissionRepository), new(MockTargetRepository)), mockValidator)
 
 		invalidCatDTO := &models.CreateCatDTO{
 			Name:            "Agent Invalid",
@@ -87,12 +174,228 @@ func TestCatService_CreateCat(t *testing.T) {
 			Salary:          45000,
 		}
 
-		mockValidator.On("ValidateBreed", "InvalidBreed").Return(false, nil)
+		mockValidator.On("ValidateBreed", "InvalidBreed").Return(nil, nil)
+		mockValidator.On("SuggestBreeds", "InvalidBreed").Return([]catapi.BreedSuggestion{}, nil)
 
-		_, err := catService.Create(invalidCatDTO)
+		_, err := catService.Create(context.Background(), invalidCatDTO)
 
 		assert.Error(t, err)
 		assert.Equal(t, "invalid cat breed", err.Error())
+		assert.IsType(t, custerr.BadRequestErr{}, err)
 		mockValidator.AssertExpectations(t)
 	})
+
+	t.Run("invalid breed with suggestions", func(t *testing.T) {
+		mockRepo := new(MockCatRepository)
+		mockValidator := new(MockCatValidator)
+		catService := service.NewCatService(mockRepo, newMockUnitOfWork(mockRepo, new(MockMissionRepository), new(MockTargetRepository)), mockValidator)
+
+		catDTO := &models.CreateCatDTO{
+			Name:            "Agent Typo",
+			YearsExperience: 1,
+			Breed:           "Siamse",
+			Salary:          30000,
+		}
+		suggestions := []catapi.BreedSuggestion{{ID: "siam", Name: "Siamese"}}
+
+		mockValidator.On("ValidateBreed", "Siamse").Return(nil, nil)
+		mockValidator.On("SuggestBreeds", "Siamse").Return(suggestions, nil)
+
+		_, err := catService.Create(context.Background(), catDTO)
+
+		var badRequest custerr.BadRequestErr
+		assert.ErrorAs(t, err, &badRequest)
+		assert.Equal(t, suggestions, badRequest.Details()["suggestions"])
+		mockValidator.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+}
+
+func TestCatService_GetAll(t *testing.T) {
+	t.Run("returns page metadata", func(t *testing.T) {
+		mockRepo := new(MockCatRepository)
+		mockValidator := new(MockCatValidator)
+		catService := service.NewCatService(mockRepo, newMockUnitOfWork(mockRepo, new(MockMissionRepository), new(MockTargetRepository)), mockValidator)
+
+		filter := models.CatFilter{PageQuery: models.PageQuery{Page: 2, PerPage: 2}}
+		cats := []models.Cat{{ID: 3}, {ID: 4}}
+
+		mockRepo.On("GetAll", filter).Return(cats, int64(5), nil)
+
+		result, err := catService.GetAll(context.Background(), filter)
+
+		assert.NoError(t, err)
+		assert.Equal(t, cats, result.Items)
+		assert.Equal(t, models.PageMeta{Page: 2, PerPage: 2, Total: 5, TotalPages: 3}, result.Meta)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("applies default page size", func(t *testing.T) {
+		mockRepo := new(MockCatRepository)
+		mockValidator := new(MockCatValidator)
+		catService := service.NewCatService(mockRepo, newMockUnitOfWork(mockRepo, new(MockMissionRepository), new(MockTargetRepository)), mockValidator)
+
+		filter := models.CatFilter{}
+
+		mockRepo.On("GetAll", filter).Return([]models.Cat(nil), int64(0), nil)
+
+		result, err := catService.GetAll(context.Background(), filter)
+
+		assert.NoError(t, err)
+		assert.Empty(t, result.Items)
+		assert.NotNil(t, result.Items)
+		assert.Equal(t, models.PageMeta{Page: 1, PerPage: models.DefaultPerPage, Total: 0, TotalPages: 0}, result.Meta)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockRepo := new(MockCatRepository)
+		mockValidator := new(MockCatValidator)
+		catService := service.NewCatService(mockRepo, newMockUnitOfWork(mockRepo, new(MockMissionRepository), new(MockTargetRepository)), mockValidator)
+
+		filter := models.CatFilter{PageQuery: models.PageQuery{Sort: "-unknown"}}
+
+		mockRepo.On("GetAll", filter).Return([]models.Cat(nil), int64(0), errors.New("cannot sort by \"unknown\""))
+
+		result, err := catService.GetAll(context.Background(), filter)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestCatService_Delete(t *testing.T) {
+	t.Run("deletes cat without active mission", func(t *testing.T) {
+		mockRepo := new(MockCatRepository)
+		mockMissionRepo := new(MockMissionRepository)
+		catService := service.NewCatService(mockRepo, newMockUnitOfWork(mockRepo, mockMissionRepo, new(MockTargetRepository)), new(MockCatValidator))
+
+		mockRepo.On("GetByIDForUpdate", uint(1)).Return(&models.Cat{ID: 1}, nil)
+		mockMissionRepo.On("GetActiveByCatID", uint(1)).Return(nil, nil)
+		mockRepo.On("Delete", uint(1)).Return(nil)
+
+		err := catService.Delete(context.Background(), 1, false)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("refuses cat with active mission", func(t *testing.T) {
+		mockRepo := new(MockCatRepository)
+		mockMissionRepo := new(MockMissionRepository)
+		catService := service.NewCatService(mockRepo, newMockUnitOfWork(mockRepo, mockMissionRepo, new(MockTargetRepository)), new(MockCatValidator))
+
+		catID := uint(1)
+		mockRepo.On("GetByIDForUpdate", catID).Return(&models.Cat{ID: catID}, nil)
+		mockMissionRepo.On("GetActiveByCatID", catID).Return(&models.Mission{ID: 7, CatID: &catID}, nil)
+
+		err := catService.Delete(context.Background(), catID, false)
+
+		assert.IsType(t, custerr.ConflictErr{}, err)
+		mockRepo.AssertNotCalled(t, "Delete", mock.Anything)
+		mockMissionRepo.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("force unassigns cat before deleting", func(t *testing.T) {
+		mockRepo := new(MockCatRepository)
+		mockMissionRepo := new(MockMissionRepository)
+		catService := service.NewCatService(mockRepo, newMockUnitOfWork(mockRepo, mockMissionRepo, new(MockTargetRepository)), new(MockCatValidator))
+
+		catID := uint(1)
+		mockRepo.On("GetByIDForUpdate", catID).Return(&models.Cat{ID: catID}, nil)
+		mockMissionRepo.On("GetActiveByCatID", catID).Return(&models.Mission{ID: 7, CatID: &catID}, nil)
+		mockMissionRepo.On("Update", mock.MatchedBy(func(m *models.Mission) bool {
+			return m.ID == 7 && m.CatID == nil
+		})).Return(nil)
+		mockMissionRepo.On("CreateAssignment", mock.MatchedBy(func(a *models.MissionAssignment) bool {
+			return a.MissionID == 7 && *a.FromCatID == catID && a.ToCatID == nil && a.Reason == "cat deleted"
+		})).Return(nil)
+		mockRepo.On("Delete", catID).Return(nil)
+
+		err := catService.Delete(context.Background(), catID, true)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+		mockMissionRepo.AssertExpectations(t)
+	})
+}
+
+func TestCatService_Update(t *testing.T) {
+	t.Run("records salary change effective now", func(t *testing.T) {
+		mockRepo := new(MockCatRepository)
+		catService := service.NewCatService(mockRepo, newMockUnitOfWork(mockRepo, new(MockMissionRepository), new(MockTargetRepository)), new(MockCatValidator))
+
+		mockRepo.On("GetByID", uint(1)).Return(&models.Cat{ID: 1, CreateCatDTO: models.CreateCatDTO{Salary: 50000}}, nil)
+		mockRepo.On("CreateSalary", mock.MatchedBy(func(salary *models.CatSalary) bool {
+			return salary.CatID == 1 && salary.Salary == 60000 && time.Since(salary.EffectiveFrom) < time.Minute
+		})).Return(nil)
+		mockRepo.On("Update", mock.AnythingOfType("*models.Cat")).Return(nil)
+
+		cat, err := catService.Update(context.Background(), 1, models.UpdateCatDTO{Salary: 60000})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 60000.0, cat.Salary)
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "GetSalaries", mock.Anything)
+	})
+
+	t.Run("unchanged salary is not recorded", func(t *testing.T) {
+		mockRepo := new(MockCatRepository)
+		catService := service.NewCatService(mockRepo, newMockUnitOfWork(mockRepo, new(MockMissionRepository), new(MockTargetRepository)), new(MockCatValidator))
+
+		mockRepo.On("GetByID", uint(1)).Return(&models.Cat{ID: 1, CreateCatDTO: models.CreateCatDTO{Salary: 50000}}, nil)
+		mockRepo.On("Update", mock.AnythingOfType("*models.Cat")).Return(nil)
+
+		_, err := catService.Update(context.Background(), 1, models.UpdateCatDTO{Salary: 50000})
+
+		assert.NoError(t, err)
+		mockRepo.AssertNotCalled(t, "CreateSalary", mock.Anything)
+	})
+
+	t.Run("backdates change after latest one", func(t *testing.T) {
+		mockRepo := new(MockCatRepository)
+		catService := service.NewCatService(mockRepo, newMockUnitOfWork(mockRepo, new(MockMissionRepository), new(MockTargetRepository)), new(MockCatValidator))
+
+		latest := time.Now().AddDate(0, 0, -10)
+		effectiveFrom := time.Now().AddDate(0, 0, -5)
+		mockRepo.On("GetByID", uint(1)).Return(&models.Cat{ID: 1, CreateCatDTO: models.CreateCatDTO{Salary: 50000}}, nil)
+		mockRepo.On("GetSalaries", uint(1)).Return([]models.CatSalary{{Salary: 50000, EffectiveFrom: latest}}, nil)
+		mockRepo.On("CreateSalary", mock.MatchedBy(func(salary *models.CatSalary) bool {
+			return salary.EffectiveFrom.Equal(effectiveFrom)
+		})).Return(nil)
+		mockRepo.On("Update", mock.AnythingOfType("*models.Cat")).Return(nil)
+
+		_, err := catService.Update(context.Background(), 1, models.UpdateCatDTO{Salary: 60000, EffectiveFrom: &effectiveFrom})
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects change before latest one", func(t *testing.T) {
+		mockRepo := new(MockCatRepository)
+		catService := service.NewCatService(mockRepo, newMockUnitOfWork(mockRepo, new(MockMissionRepository), new(MockTargetRepository)), new(MockCatValidator))
+
+		effectiveFrom := time.Now().AddDate(0, 0, -10)
+		mockRepo.On("GetByID", uint(1)).Return(&models.Cat{ID: 1, CreateCatDTO: models.CreateCatDTO{Salary: 50000}}, nil)
+		mockRepo.On("GetSalaries", uint(1)).Return([]models.CatSalary{{Salary: 50000, EffectiveFrom: time.Now().AddDate(0, 0, -5)}}, nil)
+
+		_, err := catService.Update(context.Background(), 1, models.UpdateCatDTO{Salary: 60000, EffectiveFrom: &effectiveFrom})
+
+		assert.IsType(t, custerr.BadRequestErr{}, err)
+		mockRepo.AssertNotCalled(t, "CreateSalary", mock.Anything)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("rejects future change", func(t *testing.T) {
+		mockRepo := new(MockCatRepository)
+		catService := service.NewCatService(mockRepo, newMockUnitOfWork(mockRepo, new(MockMissionRepository), new(MockTargetRepository)), new(MockCatValidator))
+
+		effectiveFrom := time.Now().Add(time.Hour)
+
+		_, err := catService.Update(context.Background(), 1, models.UpdateCatDTO{Salary: 60000, EffectiveFrom: &effectiveFrom})
+
+		assert.IsType(t, custerr.BadRequestErr{}, err)
+		mockRepo.AssertNotCalled(t, "GetByID", mock.Anything)
+	})
 }
@@ -0,0 +1,147 @@
+package tests
+
+import (
+	"context"
+	"spy-cat-agency/internal/auth"
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/internal/service"
+	"spy-cat-agency/pkg/custerr"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newMeService(catRepo *MockCatRepository, missionRepo *MockMissionRepository, targetRepo *MockTargetRepository) *service.MeService {
+	missionService := service.NewMissionService(missionRepo, targetRepo, newMockUnitOfWork(catRepo, missionRepo, targetRepo))
+	return service.NewMeService(catRepo, missionRepo, missionService)
+}
+
+func fieldCatContext(catID uint) context.Context {
+	return auth.WithPrincipal(context.Background(), &auth.Principal{Subject: "tom", Role: auth.RoleFieldCat, CatID: &catID})
+}
+
+func TestMeService_GetProfile(t *testing.T) {
+	t.Run("returns profile without salary", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
+		meService := newMeService(mockCatRepo, new(MockMissionRepository), new(MockTargetRepository))
+
+		cat := &models.Cat{ID: 3, CreateCatDTO: models.CreateCatDTO{Name: "Agent Tom", YearsExperience: 4, Breed: "Siamese", Salary: 50000}, BreedID: "siam"}
+		mockCatRepo.On("GetByID", uint(3)).Return(cat, nil)
+
+		profile, err := meService.GetProfile(fieldCatContext(3))
+
+		assert.NoError(t, err)
+		assert.Equal(t, &models.CatProfile{ID: 3, Name: "Agent Tom", YearsExperience: 4, Breed: "Siamese", BreedID: "siam"}, profile)
+	})
+
+	t.Run("rejects callers that are not cats", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
+		meService := newMeService(mockCatRepo, new(MockMissionRepository), new(MockTargetRepository))
+
+		ctx := auth.WithPrincipal(context.Background(), &auth.Principal{Subject: "jane", Role: auth.RoleAdmin})
+		profile, err := meService.GetProfile(ctx)
+
+		assert.Nil(t, profile)
+		assert.IsType(t, custerr.ForbiddenErr{}, err)
+		mockCatRepo.AssertNotCalled(t, "GetByID", mock.Anything)
+	})
+}
+
+func TestMeService_GetActiveMission(t *testing.T) {
+	t.Run("returns active mission without cat", func(t *testing.T) {
+		mockMissionRepo := new(MockMissionRepository)
+		meService := newMeService(new(MockCatRepository), mockMissionRepo, new(MockTargetRepository))
+
+		catID := uint(3)
+		mission := &models.Mission{ID: 1, CatID: &catID, Cat: &models.Cat{ID: 3}}
+		mockMissionRepo.On("GetActiveByCatID", uint(3)).Return(mission, nil)
+
+		result, err := meService.GetActiveMission(fieldCatContext(3))
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(1), result.ID)
+		assert.Nil(t, result.Cat)
+	})
+
+	t.Run("no active mission", func(t *testing.T) {
+		mockMissionRepo := new(MockMissionRepository)
+		meService := newMeService(new(MockCatRepository), mockMissionRepo, new(MockTargetRepository))
+
+		mockMissionRepo.On("GetActiveByCatID", uint(3)).Return(nil, nil)
+
+		result, err := meService.GetActiveMission(fieldCatContext(3))
+
+		assert.Nil(t, result)
+		assert.IsType(t, custerr.NotFoundErr{}, err)
+	})
+}
+
+func TestMeService_GetMissions(t *testing.T) {
+	t.Run("lists only the caller's missions", func(t *testing.T) {
+		mockMissionRepo := new(MockMissionRepository)
+		meService := newMeService(new(MockCatRepository), mockMissionRepo, new(MockTargetRepository))
+
+		complete := true
+		missions := []models.Mission{{ID: 1, Cat: &models.Cat{ID: 3}}, {ID: 2, Cat: &models.Cat{ID: 3}}}
+		mockMissionRepo.On("GetAll", mock.MatchedBy(func(filter models.MissionFilter) bool {
+			return filter.CatID != nil && *filter.CatID == 3 && filter.Complete == &complete
+		})).Return(missions, int64(2), nil)
+
+		result, err := meService.GetMissions(fieldCatContext(3), models.CatMissionFilter{Complete: &complete})
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Items, 2)
+		for _, mission := range result.Items {
+			assert.Nil(t, mission.Cat)
+		}
+		mockMissionRepo.AssertExpectations(t)
+	})
+}
+
+func TestMeService_UpdateTarget(t *testing.T) {
+	t.Run("updates target of active mission", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
+		mockMissionRepo := new(MockMissionRepository)
+		mockTargetRepo := new(MockTargetRepository)
+		meService := newMeService(mockCatRepo, mockMissionRepo, mockTargetRepo)
+
+		catID := uint(3)
+		notes := "Seen at the docks"
+		mission := &models.Mission{ID: 1, CatID: &catID, Cat: &models.Cat{ID: 3}}
+		target := &models.Target{ID: 5, MissionID: 1, Status: models.TargetStatusPending}
+
+		mockMissionRepo.On("GetActiveByCatID", uint(3)).Return(mission, nil)
+		mockMissionRepo.On("GetByIDForUpdate", uint(1)).Return(&models.Mission{ID: 1, CatID: &catID}, nil)
+		mockTargetRepo.On("GetByMissionAndID", uint(1), uint(5)).Return(target, nil)
+		mockMissionRepo.On("GetByID", uint(1)).Return(mission, nil)
+		mockTargetRepo.On("Update", target).Return(nil)
+		mockTargetRepo.On("CreateNoteRevision", mock.AnythingOfType("*models.TargetNoteRevision")).Return(nil)
+
+		result, err := meService.UpdateTarget(fieldCatContext(3), 5, models.UpdateTargetDTO{Notes: &notes})
+
+		assert.NoError(t, err)
+		assert.Nil(t, result.Cat)
+		mockTargetRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects target of another mission", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
+		mockMissionRepo := new(MockMissionRepository)
+		mockTargetRepo := new(MockTargetRepository)
+		meService := newMeService(mockCatRepo, mockMissionRepo, mockTargetRepo)
+
+		catID := uint(3)
+		notes := "Seen at the docks"
+
+		mockMissionRepo.On("GetActiveByCatID", uint(3)).Return(&models.Mission{ID: 1, CatID: &catID}, nil)
+		mockMissionRepo.On("GetByIDForUpdate", uint(1)).Return(&models.Mission{ID: 1, CatID: &catID}, nil)
+		mockTargetRepo.On("GetByMissionAndID", uint(1), uint(9)).Return(nil, custerr.NewNotFoundErr("no target with id \"9\" in mission \"1\""))
+
+		result, err := meService.UpdateTarget(fieldCatContext(3), 9, models.UpdateTargetDTO{Notes: &notes})
+
+		assert.Nil(t, result)
+		assert.IsType(t, custerr.NotFoundErr{}, err)
+		mockTargetRepo.AssertNotCalled(t, "Update", mock.Anything)
+	})
+}
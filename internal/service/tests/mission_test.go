@@ -1,10 +1,16 @@
 package tests
 
 import (
+	"context"
 	"errors"
+	"spy-cat-agency/internal/actor"
+	"spy-cat-agency/internal/auth"
 	"spy-cat-agency/internal/models"
 	"spy-cat-agency/internal/service"
+	"spy-cat-agency/pkg/custerr"
+	"spy-cat-agency/pkg/textdiff"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -19,9 +25,9 @@ func (m *MockMissionRepository) Create(mission *models.Mission) error {
 	return args.Error(0)
 }
 
-func (m *MockMissionRepository) GetAll() ([]models.Mission, error) {
-	args := m.Called()
-	return args.Get(0).([]models.Mission), args.Error(1)
+func (m *MockMissionRepository) GetAll(filter models.MissionFilter) ([]models.Mission, int64, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]models.Mission), args.Get(1).(int64), args.Error(2)
 }
 
 func (m *MockMissionRepository) GetByID(id uint) (*models.Mission, error) {
@@ -32,6 +38,14 @@ func (m *MockMissionRepository) GetByID(id uint) (*models.Mission, error) {
 	return args.Get(0).(*models.Mission), args.Error(1)
 }
 
+func (m *MockMissionRepository) GetByIDForUpdate(id uint) (*models.Mission, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Mission), args.Error(1)
+}
+
 func (m *MockMissionRepository) Update(mission *models.Mission) error {
 	args := m.Called(mission)
 	return args.Error(0)
@@ -50,6 +64,47 @@ func (m *MockMissionRepository) GetActiveByCatID(catID uint) (*models.Mission, e
 	return args.Get(0).(*models.Mission), args.Error(1)
 }
 
+func (m *MockMissionRepository) CreateAssignment(assignment *models.MissionAssignment) error {
+	args := m.Called(assignment)
+	return args.Error(0)
+}
+
+func (m *MockMissionRepository) GetAssignments(missionID uint) ([]models.MissionAssignment, error) {
+	args := m.Called(missionID)
+	return args.Get(0).([]models.MissionAssignment), args.Error(1)
+}
+
+func (m *MockMissionRepository) GetDeleted(query models.PageQuery) ([]models.Mission, int64, error) {
+	args := m.Called(query)
+	return args.Get(0).([]models.Mission), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockMissionRepository) GetDeletedByID(id uint) (*models.Mission, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Mission), args.Error(1)
+}
+
+func (m *MockMissionRepository) GetDeletedByIDForUpdate(id uint) (*models.Mission, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Mission), args.Error(1)
+}
+
+func (m *MockMissionRepository) Restore(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockMissionRepository) Purge(before time.Time) (int64, error) {
+	args := m.Called(before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 type MockTargetRepository struct {
 	mock.Mock
 }
@@ -59,6 +114,29 @@ func (m *MockTargetRepository) Create(target *models.Target) error {
 	return args.Error(0)
 }
 
+func (m *MockTargetRepository) CreateTransition(transition *models.TargetTransition) error {
+	args := m.Called(transition)
+	return args.Error(0)
+}
+
+func (m *MockTargetRepository) CreateNoteRevision(revision *models.TargetNoteRevision) error {
+	args := m.Called(revision)
+	return args.Error(0)
+}
+
+func (m *MockTargetRepository) GetNoteRevisions(targetID uint) ([]models.TargetNoteRevision, error) {
+	args := m.Called(targetID)
+	return args.Get(0).([]models.TargetNoteRevision), args.Error(1)
+}
+
+func (m *MockTargetRepository) GetNoteRevision(targetID, revisionID uint) (*models.TargetNoteRevision, error) {
+	args := m.Called(targetID, revisionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TargetNoteRevision), args.Error(1)
+}
+
 func (m *MockTargetRepository) GetByID(id uint) (*models.Target, error) {
 	args := m.Called(id)
 	if args.Get(0) == nil {
@@ -67,6 +145,14 @@ func (m *MockTargetRepository) GetByID(id uint) (*models.Target, error) {
 	return args.Get(0).(*models.Target), args.Error(1)
 }
 
+func (m *MockTargetRepository) GetByMissionAndID(missionID, id uint) (*models.Target, error) {
+	args := m.Called(missionID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Target), args.Error(1)
+}
+
 func (m *MockTargetRepository) Update(target *models.Target) error {
 	args := m.Called(target)
 	return args.Error(0)
@@ -82,11 +168,59 @@ func (m *MockTargetRepository) CountByMissionID(missionID uint) (int64, error) {
 	return args.Get(0).(int64), args.Error(1)
 }
 
+func (m *MockTargetRepository) GetDeleted(query models.PageQuery) ([]models.Target, int64, error) {
+	args := m.Called(query)
+	return args.Get(0).([]models.Target), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockTargetRepository) GetDeletedByID(id uint) (*models.Target, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Target), args.Error(1)
+}
+
+func (m *MockTargetRepository) Restore(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockTargetRepository) Purge(before time.Time) (int64, error) {
+	args := m.Called(before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// MockUnitOfWork runs the work directly against the mock repositories, so
+// expectations set on them apply inside transactions as well.
+type MockUnitOfWork struct {
+	repos service.Repositories
+	audit *FakeAuditRepository
+}
+
+func newMockUnitOfWork(catRepo *MockCatRepository, missionRepo *MockMissionRepository, targetRepo *MockTargetRepository) *MockUnitOfWork {
+	audit := &FakeAuditRepository{}
+	return &MockUnitOfWork{
+		repos: service.Repositories{
+			Cat:     catRepo,
+			Mission: missionRepo,
+			Target:  targetRepo,
+			Audit:   audit,
+		},
+		audit: audit,
+	}
+}
+
+func (m *MockUnitOfWork) Do(fn func(repos service.Repositories) error) error {
+	return fn(m.repos)
+}
+
 func TestMissionService_CreateMission(t *testing.T) {
 	t.Run("successful creation with targets", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
 		mockMissionRepo := new(MockMissionRepository)
 		mockTargetRepo := new(MockTargetRepository)
-		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
 
 		dto := models.CreateMissionDTO{
 			Targets: []models.CreateTargetDTO{
@@ -103,10 +237,11 @@ func TestMissionService_CreateMission(t *testing.T) {
 		})
 
 		mockTargetRepo.On("Create", mock.AnythingOfType("*models.Target")).Return(nil)
+		mockTargetRepo.On("CreateNoteRevision", mock.AnythingOfType("*models.TargetNoteRevision")).Return(nil).Twice()
 
 		mockMissionRepo.On("GetByID", uint(1)).Return(mission, nil)
 
-		result, err := missionService.Create(dto)
+		result, err := missionService.Create(context.Background(), dto)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
@@ -115,10 +250,46 @@ func TestMissionService_CreateMission(t *testing.T) {
 		mockTargetRepo.AssertExpectations(t)
 	})
 
+	t.Run("target creation fails", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
+		mockMissionRepo := new(MockMissionRepository)
+		mockTargetRepo := new(MockTargetRepository)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
+
+		dto := models.CreateMissionDTO{
+			Targets: []models.CreateTargetDTO{
+				{Name: "Target 1", Country: "USA"},
+				{Name: "Target 2", Country: "UK"},
+			},
+		}
+
+		mockMissionRepo.On("Create", mock.AnythingOfType("*models.Mission")).Return(nil).Run(func(args mock.Arguments) {
+			arg := args.Get(0).(*models.Mission)
+			arg.ID = 1
+		})
+
+		mockTargetRepo.On("Create", mock.MatchedBy(func(target *models.Target) bool {
+			return target.Name == "Target 1"
+		})).Return(nil)
+		mockTargetRepo.On("Create", mock.MatchedBy(func(target *models.Target) bool {
+			return target.Name == "Target 2"
+		})).Return(errors.New("database error"))
+
+		result, err := missionService.Create(context.Background(), dto)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "database error")
+		mockMissionRepo.AssertExpectations(t)
+		mockMissionRepo.AssertNotCalled(t, "GetByID", uint(1))
+		mockTargetRepo.AssertExpectations(t)
+	})
+
 	t.Run("successful creation with no targets", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
 		mockMissionRepo := new(MockMissionRepository)
 		mockTargetRepo := new(MockTargetRepository)
-		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
 
 		dto := models.CreateMissionDTO{
 			Targets: []models.CreateTargetDTO{},
@@ -133,7 +304,7 @@ func TestMissionService_CreateMission(t *testing.T) {
 
 		mockMissionRepo.On("GetByID", uint(1)).Return(mission, nil)
 
-		result, err := missionService.Create(dto)
+		result, err := missionService.Create(context.Background(), dto)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
@@ -143,34 +314,176 @@ func TestMissionService_CreateMission(t *testing.T) {
 	})
 }
 
+func TestMissionService_UpdateMission(t *testing.T) {
+	t.Run("cannot complete mission with open targets", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
+		mockMissionRepo := new(MockMissionRepository)
+		mockTargetRepo := new(MockTargetRepository)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
+
+		complete := true
+		mission := &models.Mission{ID: 1, Targets: []models.Target{
+			{ID: 1, Status: models.TargetStatusNeutralized, Complete: true},
+			{ID: 2, Status: models.TargetStatusUnderSurveillance},
+		}}
+
+		mockMissionRepo.On("GetByID", uint(1)).Return(mission, nil)
+
+		result, err := missionService.Update(context.Background(), 1, models.UpdateMissionDTO{Complete: &complete})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.IsType(t, custerr.ConflictErr{}, err)
+		assert.Contains(t, err.Error(), "cannot complete mission while targets are still open")
+		mockMissionRepo.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("force completes mission with open targets", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
+		mockMissionRepo := new(MockMissionRepository)
+		mockTargetRepo := new(MockTargetRepository)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
+
+		complete := true
+		mission := &models.Mission{ID: 1, Targets: []models.Target{
+			{ID: 1, Status: models.TargetStatusPending},
+		}}
+
+		mockMissionRepo.On("GetByID", uint(1)).Return(mission, nil)
+		mockMissionRepo.On("Update", mock.MatchedBy(func(m *models.Mission) bool {
+			return m.ID == 1 && m.Complete
+		})).Return(nil)
+
+		result, err := missionService.Update(context.Background(), 1, models.UpdateMissionDTO{Complete: &complete, Force: true})
+
+		assert.NoError(t, err)
+		assert.True(t, result.Complete)
+		mockMissionRepo.AssertExpectations(t)
+	})
+
+	t.Run("completes mission with terminal targets", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
+		mockMissionRepo := new(MockMissionRepository)
+		mockTargetRepo := new(MockTargetRepository)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
+
+		complete := true
+		mission := &models.Mission{ID: 1, Targets: []models.Target{
+			{ID: 1, Status: models.TargetStatusNeutralized, Complete: true},
+			{ID: 2, Status: models.TargetStatusEscaped, Complete: true},
+		}}
+
+		mockMissionRepo.On("GetByID", uint(1)).Return(mission, nil)
+		mockMissionRepo.On("Update", mock.MatchedBy(func(m *models.Mission) bool {
+			return m.Complete
+		})).Return(nil)
+
+		result, err := missionService.Update(context.Background(), 1, models.UpdateMissionDTO{Complete: &complete})
+
+		assert.NoError(t, err)
+		assert.True(t, result.Complete)
+		mockMissionRepo.AssertExpectations(t)
+	})
+
+	t.Run("cannot reopen mission with only terminal targets", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
+		mockMissionRepo := new(MockMissionRepository)
+		mockTargetRepo := new(MockTargetRepository)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
+
+		complete := false
+		mission := &models.Mission{ID: 1, Complete: true, Targets: []models.Target{
+			{ID: 1, Status: models.TargetStatusNeutralized, Complete: true},
+			{ID: 2, Status: models.TargetStatusEscaped, Complete: true},
+		}}
+
+		mockMissionRepo.On("GetByID", uint(1)).Return(mission, nil)
+
+		result, err := missionService.Update(context.Background(), 1, models.UpdateMissionDTO{Complete: &complete})
+
+		assert.Nil(t, result)
+		assert.IsType(t, custerr.ConflictErr{}, err)
+		assert.Equal(t, models.ErrCodeMissionTargetsTerminal, err.(custerr.ConflictErr).Code())
+		mockMissionRepo.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("force reopens mission with only terminal targets", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
+		mockMissionRepo := new(MockMissionRepository)
+		mockTargetRepo := new(MockTargetRepository)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
+
+		complete := false
+		mission := &models.Mission{ID: 1, Complete: true, Targets: []models.Target{
+			{ID: 1, Status: models.TargetStatusNeutralized, Complete: true},
+		}}
+
+		mockMissionRepo.On("GetByID", uint(1)).Return(mission, nil)
+		mockMissionRepo.On("Update", mock.MatchedBy(func(m *models.Mission) bool {
+			return m.ID == 1 && !m.Complete
+		})).Return(nil)
+
+		result, err := missionService.Update(context.Background(), 1, models.UpdateMissionDTO{Complete: &complete, Force: true})
+
+		assert.NoError(t, err)
+		assert.False(t, result.Complete)
+		mockMissionRepo.AssertExpectations(t)
+	})
+
+	t.Run("reopening fails when cat has another active mission", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
+		mockMissionRepo := new(MockMissionRepository)
+		mockTargetRepo := new(MockTargetRepository)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
+
+		complete := false
+		catID := uint(1)
+		mission := &models.Mission{ID: 1, CatID: &catID, Complete: true}
+
+		mockMissionRepo.On("GetByID", uint(1)).Return(mission, nil)
+		mockMissionRepo.On("Update", mock.MatchedBy(func(m *models.Mission) bool {
+			return !m.Complete
+		})).Return(custerr.NewConflictErr("cat already has an active mission"))
+
+		result, err := missionService.Update(context.Background(), 1, models.UpdateMissionDTO{Complete: &complete})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.IsType(t, custerr.ConflictErr{}, err)
+		mockMissionRepo.AssertExpectations(t)
+	})
+}
+
 func TestMissionService_DeleteMission(t *testing.T) {
 	t.Run("successful deletion of unassigned mission", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
 		mockMissionRepo := new(MockMissionRepository)
 		mockTargetRepo := new(MockTargetRepository)
-		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
 
 		mission := &models.Mission{ID: 1, CatID: nil, Complete: false}
 
 		mockMissionRepo.On("GetByID", uint(1)).Return(mission, nil)
 		mockMissionRepo.On("Delete", uint(1)).Return(nil)
 
-		err := missionService.Delete(1)
+		err := missionService.Delete(context.Background(), 1)
 
 		assert.NoError(t, err)
 		mockMissionRepo.AssertExpectations(t)
 	})
 
 	t.Run("cannot delete assigned mission", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
 		mockMissionRepo := new(MockMissionRepository)
 		mockTargetRepo := new(MockTargetRepository)
-		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
 
 		catID := uint(1)
 		mission := &models.Mission{ID: 1, CatID: &catID, Complete: false}
 
 		mockMissionRepo.On("GetByID", uint(1)).Return(mission, nil)
 
-		err := missionService.Delete(1)
+		err := missionService.Delete(context.Background(), 1)
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "cannot delete assigned mission")
@@ -180,129 +493,353 @@ func TestMissionService_DeleteMission(t *testing.T) {
 
 func TestMissionService_AssignCat(t *testing.T) {
 	t.Run("successful cat assignment", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
 		mockMissionRepo := new(MockMissionRepository)
 		mockTargetRepo := new(MockTargetRepository)
-		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
 
 		missionID, catID := uint(1), uint(1)
 		mission := &models.Mission{ID: 1, Complete: false}
 		updatedMission := &models.Mission{ID: 1, CatID: &catID, Complete: false}
 
-		mockMissionRepo.On("GetByID", missionID).Return(mission, nil)
+		mockMissionRepo.On("GetByIDForUpdate", missionID).Return(mission, nil)
+		mockCatRepo.On("GetByIDForUpdate", catID).Return(&models.Cat{ID: catID}, nil)
 		mockMissionRepo.On("GetActiveByCatID", catID).Return(nil, nil)
 		mockMissionRepo.On("Update", mock.MatchedBy(func(m *models.Mission) bool {
 			return m.CatID != nil && *m.CatID == catID && m.ID == missionID
 		})).Return(nil)
+		mockMissionRepo.On("CreateAssignment", mock.MatchedBy(func(a *models.MissionAssignment) bool {
+			return a.MissionID == missionID && a.FromCatID == nil && *a.ToCatID == catID
+		})).Return(nil)
+		mockMissionRepo.On("GetByID", missionID).Return(updatedMission, nil)
 
-		result, err := missionService.AssignCat(missionID, catID)
+		result, err := missionService.AssignCat(context.Background(), missionID, catID)
 
 		assert.NoError(t, err)
 		assert.Equal(t, updatedMission, result)
+		mockCatRepo.AssertExpectations(t)
 		mockMissionRepo.AssertExpectations(t)
 	})
 
 	t.Run("cat already has active mission", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
 		mockMissionRepo := new(MockMissionRepository)
 		mockTargetRepo := new(MockTargetRepository)
-		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
 
 		missionID, catID := uint(1), uint(1)
 		mission := &models.Mission{ID: 1, Complete: false}
 		activeMission := &models.Mission{ID: 2, CatID: &catID, Complete: false}
 
-		mockMissionRepo.On("GetByID", missionID).Return(mission, nil)
+		mockMissionRepo.On("GetByIDForUpdate", missionID).Return(mission, nil)
+		mockCatRepo.On("GetByIDForUpdate", catID).Return(&models.Cat{ID: catID}, nil)
 		mockMissionRepo.On("GetActiveByCatID", catID).Return(activeMission, nil)
 
-		result, err := missionService.AssignCat(missionID, catID)
+		result, err := missionService.AssignCat(context.Background(), missionID, catID)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
 		assert.Contains(t, err.Error(), "cat already has an active mission")
+		mockCatRepo.AssertExpectations(t)
 		mockMissionRepo.AssertExpectations(t)
 	})
 
+	t.Run("cannot assign cat to mission with a cat", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
+		mockMissionRepo := new(MockMissionRepository)
+		mockTargetRepo := new(MockTargetRepository)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
+
+		assignedCatID := uint(2)
+		mockMissionRepo.On("GetByIDForUpdate", uint(1)).Return(&models.Mission{ID: 1, CatID: &assignedCatID}, nil)
+
+		result, err := missionService.AssignCat(context.Background(), 1, 1)
+
+		assert.Nil(t, result)
+		assert.IsType(t, custerr.ConflictErr{}, err)
+		mockCatRepo.AssertNotCalled(t, "GetByIDForUpdate", mock.Anything)
+	})
+
 	t.Run("cannot assign cat to completed mission", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
 		mockMissionRepo := new(MockMissionRepository)
 		mockTargetRepo := new(MockTargetRepository)
-		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
 
 		missionID, catID := uint(1), uint(1)
 		mission := &models.Mission{ID: 1, Complete: true}
 
-		mockMissionRepo.On("GetByID", missionID).Return(mission, nil)
+		mockMissionRepo.On("GetByIDForUpdate", missionID).Return(mission, nil)
 
-		result, err := missionService.AssignCat(missionID, catID)
+		result, err := missionService.AssignCat(context.Background(), missionID, catID)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
 		assert.Contains(t, err.Error(), "cannot assign cat to completed mission")
+		mockCatRepo.AssertExpectations(t)
 		mockMissionRepo.AssertExpectations(t)
 	})
 
 	t.Run("mission not found", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
 		mockMissionRepo := new(MockMissionRepository)
 		mockTargetRepo := new(MockTargetRepository)
-		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
 
 		missionID, catID := uint(999), uint(1)
 
-		mockMissionRepo.On("GetByID", missionID).Return(nil, errors.New("mission not found"))
+		mockMissionRepo.On("GetByIDForUpdate", missionID).Return(nil, errors.New("mission not found"))
 
-		result, err := missionService.AssignCat(missionID, catID)
+		result, err := missionService.AssignCat(context.Background(), missionID, catID)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
 		assert.Contains(t, err.Error(), "mission not found")
+		mockCatRepo.AssertExpectations(t)
 		mockMissionRepo.AssertExpectations(t)
 	})
 
 	t.Run("database error when checking active mission", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
 		mockMissionRepo := new(MockMissionRepository)
 		mockTargetRepo := new(MockTargetRepository)
-		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
 
 		missionID, catID := uint(1), uint(1)
 		mission := &models.Mission{ID: 1, Complete: false}
 
-		mockMissionRepo.On("GetByID", missionID).Return(mission, nil)
+		mockMissionRepo.On("GetByIDForUpdate", missionID).Return(mission, nil)
+		mockCatRepo.On("GetByIDForUpdate", catID).Return(&models.Cat{ID: catID}, nil)
 		mockMissionRepo.On("GetActiveByCatID", catID).Return(nil, errors.New("database error"))
 
-		result, err := missionService.AssignCat(missionID, catID)
+		result, err := missionService.AssignCat(context.Background(), missionID, catID)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
 		assert.Contains(t, err.Error(), "database error")
+		mockCatRepo.AssertExpectations(t)
 		mockMissionRepo.AssertExpectations(t)
 	})
 
 	t.Run("successful assignment when cat has no active mission", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
 		mockMissionRepo := new(MockMissionRepository)
 		mockTargetRepo := new(MockTargetRepository)
-		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
 
 		missionID, catID := uint(1), uint(1)
 		mission := &models.Mission{ID: 1, Complete: false}
 		updatedMission := &models.Mission{ID: 1, CatID: &catID, Complete: false}
 
-		mockMissionRepo.On("GetByID", missionID).Return(mission, nil)
+		mockMissionRepo.On("GetByIDForUpdate", missionID).Return(mission, nil)
+		mockCatRepo.On("GetByIDForUpdate", catID).Return(&models.Cat{ID: catID}, nil)
 		mockMissionRepo.On("GetActiveByCatID", catID).Return(nil, nil)
 		mockMissionRepo.On("Update", mock.MatchedBy(func(m *models.Mission) bool {
 			return m.CatID != nil && *m.CatID == catID && m.ID == missionID
 		})).Return(nil)
+		mockMissionRepo.On("CreateAssignment", mock.MatchedBy(func(a *models.MissionAssignment) bool {
+			return a.MissionID == missionID && a.FromCatID == nil && *a.ToCatID == catID
+		})).Return(nil)
+		mockMissionRepo.On("GetByID", missionID).Return(updatedMission, nil)
 
-		result, err := missionService.AssignCat(missionID, catID)
+		result, err := missionService.AssignCat(context.Background(), missionID, catID)
 
 		assert.NoError(t, err)
 		assert.Equal(t, updatedMission, result)
+		mockCatRepo.AssertExpectations(t)
+		mockMissionRepo.AssertExpectations(t)
+	})
+
+	t.Run("cat not found", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
+		mockMissionRepo := new(MockMissionRepository)
+		mockTargetRepo := new(MockTargetRepository)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
+
+		missionID, catID := uint(1), uint(999)
+		mission := &models.Mission{ID: 1, Complete: false}
+
+		mockMissionRepo.On("GetByIDForUpdate", missionID).Return(mission, nil)
+		mockCatRepo.On("GetByIDForUpdate", catID).Return(nil, custerr.NewNotFoundErr("no cat with id \"999\""))
+
+		result, err := missionService.AssignCat(context.Background(), missionID, catID)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.IsType(t, custerr.NotFoundErr{}, err)
+		mockCatRepo.AssertExpectations(t)
+		mockMissionRepo.AssertExpectations(t)
+	})
+
+	t.Run("concurrent assignment rejected by database", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
+		mockMissionRepo := new(MockMissionRepository)
+		mockTargetRepo := new(MockTargetRepository)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
+
+		missionID, catID := uint(1), uint(1)
+		mission := &models.Mission{ID: 1, Complete: false}
+
+		mockMissionRepo.On("GetByIDForUpdate", missionID).Return(mission, nil)
+		mockCatRepo.On("GetByIDForUpdate", catID).Return(&models.Cat{ID: catID}, nil)
+		mockMissionRepo.On("GetActiveByCatID", catID).Return(nil, nil)
+		mockMissionRepo.On("Update", mock.AnythingOfType("*models.Mission")).Return(custerr.NewConflictErr("cat already has an active mission"))
+
+		result, err := missionService.AssignCat(context.Background(), missionID, catID)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.IsType(t, custerr.ConflictErr{}, err)
+		mockCatRepo.AssertExpectations(t)
 		mockMissionRepo.AssertExpectations(t)
 	})
 }
 
+func TestMissionService_UnassignCat(t *testing.T) {
+	t.Run("successful unassignment records reason", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
+		mockMissionRepo := new(MockMissionRepository)
+		mockTargetRepo := new(MockTargetRepository)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
+
+		catID := uint(3)
+		mission := &models.Mission{ID: 1, CatID: &catID}
+		updatedMission := &models.Mission{ID: 1}
+
+		mockMissionRepo.On("GetByIDForUpdate", uint(1)).Return(mission, nil)
+		mockMissionRepo.On("Update", mock.MatchedBy(func(m *models.Mission) bool {
+			return m.ID == 1 && m.CatID == nil
+		})).Return(nil)
+		mockMissionRepo.On("CreateAssignment", &models.MissionAssignment{
+			MissionID: 1,
+			FromCatID: &catID,
+			Reason:    "cover blown",
+			Actor:     "handler-jane",
+		}).Return(nil)
+		mockMissionRepo.On("GetByID", uint(1)).Return(updatedMission, nil)
+
+		ctx := actor.WithName(context.Background(), "handler-jane")
+		result, err := missionService.UnassignCat(ctx, 1, models.MissionAssignmentDTO{Reason: "cover blown"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, updatedMission, result)
+		mockMissionRepo.AssertExpectations(t)
+	})
+
+	t.Run("mission has no cat", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
+		mockMissionRepo := new(MockMissionRepository)
+		mockTargetRepo := new(MockTargetRepository)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
+
+		mockMissionRepo.On("GetByIDForUpdate", uint(1)).Return(&models.Mission{ID: 1}, nil)
+
+		result, err := missionService.UnassignCat(context.Background(), 1, models.MissionAssignmentDTO{Reason: "cover blown"})
+
+		assert.Nil(t, result)
+		assert.IsType(t, custerr.ConflictErr{}, err)
+		mockMissionRepo.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("cannot unassign completed mission", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
+		mockMissionRepo := new(MockMissionRepository)
+		mockTargetRepo := new(MockTargetRepository)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
+
+		catID := uint(3)
+		mockMissionRepo.On("GetByIDForUpdate", uint(1)).Return(&models.Mission{ID: 1, CatID: &catID, Complete: true}, nil)
+
+		_, err := missionService.UnassignCat(context.Background(), 1, models.MissionAssignmentDTO{Reason: "cover blown"})
+
+		assert.EqualError(t, err, "cannot unassign cat from completed mission")
+	})
+}
+
+func TestMissionService_ReassignCat(t *testing.T) {
+	t.Run("successful reassignment", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
+		mockMissionRepo := new(MockMissionRepository)
+		mockTargetRepo := new(MockTargetRepository)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
+
+		oldCatID, newCatID := uint(3), uint(4)
+		mission := &models.Mission{ID: 1, CatID: &oldCatID}
+		updatedMission := &models.Mission{ID: 1, CatID: &newCatID}
+
+		mockMissionRepo.On("GetByIDForUpdate", uint(1)).Return(mission, nil)
+		mockCatRepo.On("GetByIDForUpdate", newCatID).Return(&models.Cat{ID: newCatID}, nil)
+		mockMissionRepo.On("GetActiveByCatID", newCatID).Return(nil, nil)
+		mockMissionRepo.On("Update", mock.MatchedBy(func(m *models.Mission) bool {
+			return m.ID == 1 && m.CatID != nil && *m.CatID == newCatID
+		})).Return(nil)
+		mockMissionRepo.On("CreateAssignment", mock.MatchedBy(func(a *models.MissionAssignment) bool {
+			return *a.FromCatID == oldCatID && *a.ToCatID == newCatID && a.Reason == "injured"
+		})).Return(nil)
+		mockMissionRepo.On("GetByID", uint(1)).Return(updatedMission, nil)
+
+		result, err := missionService.ReassignCat(context.Background(), 1, newCatID, models.MissionAssignmentDTO{Reason: "injured"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, updatedMission, result)
+		mockCatRepo.AssertExpectations(t)
+		mockMissionRepo.AssertExpectations(t)
+	})
+
+	t.Run("new cat already has active mission", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
+		mockMissionRepo := new(MockMissionRepository)
+		mockTargetRepo := new(MockTargetRepository)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
+
+		oldCatID, newCatID := uint(3), uint(4)
+
+		mockMissionRepo.On("GetByIDForUpdate", uint(1)).Return(&models.Mission{ID: 1, CatID: &oldCatID}, nil)
+		mockCatRepo.On("GetByIDForUpdate", newCatID).Return(&models.Cat{ID: newCatID}, nil)
+		mockMissionRepo.On("GetActiveByCatID", newCatID).Return(&models.Mission{ID: 2, CatID: &newCatID}, nil)
+
+		result, err := missionService.ReassignCat(context.Background(), 1, newCatID, models.MissionAssignmentDTO{Reason: "injured"})
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "cat already has an active mission")
+		mockMissionRepo.AssertNotCalled(t, "Update", mock.Anything)
+		mockMissionRepo.AssertNotCalled(t, "CreateAssignment", mock.Anything)
+	})
+
+	t.Run("same cat", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
+		mockMissionRepo := new(MockMissionRepository)
+		mockTargetRepo := new(MockTargetRepository)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
+
+		catID := uint(3)
+		mockMissionRepo.On("GetByIDForUpdate", uint(1)).Return(&models.Mission{ID: 1, CatID: &catID}, nil)
+
+		_, err := missionService.ReassignCat(context.Background(), 1, catID, models.MissionAssignmentDTO{Reason: "injured"})
+
+		assert.IsType(t, custerr.ConflictErr{}, err)
+	})
+
+	t.Run("mission without cat must be assigned instead", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
+		mockMissionRepo := new(MockMissionRepository)
+		mockTargetRepo := new(MockTargetRepository)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
+
+		mockMissionRepo.On("GetByIDForUpdate", uint(1)).Return(&models.Mission{ID: 1}, nil)
+
+		_, err := missionService.ReassignCat(context.Background(), 1, 4, models.MissionAssignmentDTO{Reason: "injured"})
+
+		assert.EqualError(t, err, "mission has no assigned cat, assign one instead")
+	})
+}
+
 func TestMissionService_CreateTarget(t *testing.T) {
 	t.Run("successful target creation", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
 		mockMissionRepo := new(MockMissionRepository)
 		mockTargetRepo := new(MockTargetRepository)
-		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
 
 		missionID := uint(1)
 		dto := models.CreateTargetDTO{
@@ -314,7 +851,7 @@ func TestMissionService_CreateTarget(t *testing.T) {
 		mission := &models.Mission{ID: 1, Complete: false}
 		updatedMission := &models.Mission{ID: 1, Complete: false}
 
-		mockMissionRepo.On("GetByID", missionID).Return(mission, nil)
+		mockMissionRepo.On("GetByIDForUpdate", missionID).Return(mission, nil)
 		mockTargetRepo.On("CountByMissionID", missionID).Return(int64(1), nil)
 		mockTargetRepo.On("Create", mock.MatchedBy(func(target *models.Target) bool {
 			return target.MissionID == missionID &&
@@ -322,9 +859,12 @@ func TestMissionService_CreateTarget(t *testing.T) {
 				target.Country == dto.Country &&
 				target.Notes == dto.Notes
 		})).Return(nil)
+		mockTargetRepo.On("CreateNoteRevision", mock.MatchedBy(func(revision *models.TargetNoteRevision) bool {
+			return revision.Notes == dto.Notes
+		})).Return(nil)
 		mockMissionRepo.On("GetByID", missionID).Return(updatedMission, nil)
 
-		result, err := missionService.CreateTarget(missionID, dto)
+		result, err := missionService.CreateTarget(context.Background(), missionID, dto)
 
 		assert.NoError(t, err)
 		assert.Equal(t, updatedMission, result)
@@ -333,18 +873,19 @@ func TestMissionService_CreateTarget(t *testing.T) {
 	})
 
 	t.Run("cannot create target for completed mission", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
 		mockMissionRepo := new(MockMissionRepository)
 		mockTargetRepo := new(MockTargetRepository)
-		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
 
 		missionID := uint(1)
 		dto := models.CreateTargetDTO{Name: "Target Alpha", Country: "USA"}
 
 		mission := &models.Mission{ID: 1, Complete: true}
 
-		mockMissionRepo.On("GetByID", missionID).Return(mission, nil)
+		mockMissionRepo.On("GetByIDForUpdate", missionID).Return(mission, nil)
 
-		result, err := missionService.CreateTarget(missionID, dto)
+		result, err := missionService.CreateTarget(context.Background(), missionID, dto)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -353,19 +894,20 @@ func TestMissionService_CreateTarget(t *testing.T) {
 	})
 
 	t.Run("cannot create more than 3 targets", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
 		mockMissionRepo := new(MockMissionRepository)
 		mockTargetRepo := new(MockTargetRepository)
-		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
 
 		missionID := uint(1)
 		dto := models.CreateTargetDTO{Name: "Target Delta", Country: "Canada"}
 
 		mission := &models.Mission{ID: 1, Complete: false}
 
-		mockMissionRepo.On("GetByID", missionID).Return(mission, nil)
+		mockMissionRepo.On("GetByIDForUpdate", missionID).Return(mission, nil)
 		mockTargetRepo.On("CountByMissionID", missionID).Return(int64(3), nil)
 
-		result, err := missionService.CreateTarget(missionID, dto)
+		result, err := missionService.CreateTarget(context.Background(), missionID, dto)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -375,16 +917,17 @@ func TestMissionService_CreateTarget(t *testing.T) {
 	})
 
 	t.Run("mission not found", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
 		mockMissionRepo := new(MockMissionRepository)
 		mockTargetRepo := new(MockTargetRepository)
-		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
 
 		missionID := uint(999)
 		dto := models.CreateTargetDTO{Name: "Target Alpha", Country: "USA"}
 
-		mockMissionRepo.On("GetByID", missionID).Return(nil, errors.New("mission not found"))
+		mockMissionRepo.On("GetByIDForUpdate", missionID).Return(nil, errors.New("mission not found"))
 
-		result, err := missionService.CreateTarget(missionID, dto)
+		result, err := missionService.CreateTarget(context.Background(), missionID, dto)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -393,20 +936,21 @@ func TestMissionService_CreateTarget(t *testing.T) {
 	})
 
 	t.Run("target creation fails", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
 		mockMissionRepo := new(MockMissionRepository)
 		mockTargetRepo := new(MockTargetRepository)
-		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
 
 		missionID := uint(1)
 		dto := models.CreateTargetDTO{Name: "Target Alpha", Country: "USA"}
 
 		mission := &models.Mission{ID: 1, Complete: false}
 
-		mockMissionRepo.On("GetByID", missionID).Return(mission, nil)
+		mockMissionRepo.On("GetByIDForUpdate", missionID).Return(mission, nil)
 		mockTargetRepo.On("CountByMissionID", missionID).Return(int64(1), nil)
 		mockTargetRepo.On("Create", mock.AnythingOfType("*models.Target")).Return(errors.New("database error"))
 
-		result, err := missionService.CreateTarget(missionID, dto)
+		result, err := missionService.CreateTarget(context.Background(), missionID, dto)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -418,26 +962,30 @@ func TestMissionService_CreateTarget(t *testing.T) {
 
 func TestMissionService_UpdateTarget(t *testing.T) {
 	t.Run("successful target update - notes only", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
 		mockMissionRepo := new(MockMissionRepository)
 		mockTargetRepo := new(MockTargetRepository)
-		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
 
 		missionID, targetID := uint(1), uint(1)
 		newNotes := "Updated notes"
 		dto := models.UpdateTargetDTO{Notes: &newNotes}
 
-		target := &models.Target{ID: 1, MissionID: 1, Name: "Target Alpha", Complete: false}
+		target := &models.Target{ID: 1, MissionID: 1, Name: "Target Alpha", Status: models.TargetStatusPending}
 		mission := &models.Mission{ID: 1, Complete: false}
 		updatedMission := &models.Mission{ID: 1, Complete: false}
 
-		mockTargetRepo.On("GetByID", targetID).Return(target, nil)
-		mockMissionRepo.On("GetByID", missionID).Return(mission, nil)
+		mockTargetRepo.On("GetByMissionAndID", missionID, targetID).Return(target, nil)
+		mockMissionRepo.On("GetByIDForUpdate", missionID).Return(mission, nil)
 		mockTargetRepo.On("Update", mock.MatchedBy(func(t *models.Target) bool {
 			return t.Notes == newNotes && t.ID == targetID
 		})).Return(nil)
+		mockTargetRepo.On("CreateNoteRevision", mock.MatchedBy(func(revision *models.TargetNoteRevision) bool {
+			return revision.TargetID == targetID && revision.Notes == newNotes
+		})).Return(nil)
 		mockMissionRepo.On("GetByID", missionID).Return(updatedMission, nil)
 
-		result, err := missionService.UpdateTarget(missionID, targetID, dto)
+		result, err := missionService.UpdateTarget(context.Background(), missionID, targetID, dto)
 
 		assert.NoError(t, err)
 		assert.Equal(t, updatedMission, result)
@@ -446,93 +994,227 @@ func TestMissionService_UpdateTarget(t *testing.T) {
 	})
 
 	t.Run("successful target update - complete status", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
 		mockMissionRepo := new(MockMissionRepository)
 		mockTargetRepo := new(MockTargetRepository)
-		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
 
 		missionID, targetID := uint(1), uint(1)
 		complete := true
 		dto := models.UpdateTargetDTO{Complete: &complete}
 
-		target := &models.Target{ID: 1, MissionID: 1, Name: "Target Alpha", Complete: false}
-		mission := &models.Mission{ID: 1, Complete: false}
+		target := &models.Target{ID: 1, MissionID: 1, Name: "Target Alpha", Status: models.TargetStatusPending}
+		mission := &models.Mission{ID: 1, Complete: false, Targets: []models.Target{
+			{ID: 1, MissionID: 1, Name: "Target Alpha", Status: models.TargetStatusNeutralized, Complete: true},
+			{ID: 2, MissionID: 1, Name: "Target Beta", Status: models.TargetStatusUnderSurveillance},
+		}}
 		updatedMission := &models.Mission{ID: 1, Complete: false}
 
-		mockTargetRepo.On("GetByID", targetID).Return(target, nil)
-		mockMissionRepo.On("GetByID", missionID).Return(mission, nil)
+		mockMissionRepo.On("GetByIDForUpdate", missionID).Return(&models.Mission{ID: 1}, nil)
+		mockTargetRepo.On("GetByMissionAndID", missionID, targetID).Return(target, nil)
+		mockMissionRepo.On("GetByID", missionID).Return(mission, nil).Once()
+		mockTargetRepo.On("CreateTransition", mock.MatchedBy(func(tr *models.TargetTransition) bool {
+			return tr.TargetID == targetID && tr.From == models.TargetStatusPending && tr.To == models.TargetStatusNeutralized
+		})).Return(nil)
 		mockTargetRepo.On("Update", mock.MatchedBy(func(t *models.Target) bool {
-			return t.Complete == complete && t.ID == targetID
+			return t.Complete == complete && t.Status == models.TargetStatusNeutralized && t.ID == targetID
 		})).Return(nil)
 		mockMissionRepo.On("GetByID", missionID).Return(updatedMission, nil)
 
-		result, err := missionService.UpdateTarget(missionID, targetID, dto)
+		result, err := missionService.UpdateTarget(context.Background(), missionID, targetID, dto)
 
 		assert.NoError(t, err)
 		assert.Equal(t, updatedMission, result)
 		mockTargetRepo.AssertExpectations(t)
 		mockMissionRepo.AssertExpectations(t)
+		mockMissionRepo.AssertNotCalled(t, "Update", mock.Anything)
 	})
 
-	t.Run("target does not belong to mission", func(t *testing.T) {
+	t.Run("status transition", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
 		mockMissionRepo := new(MockMissionRepository)
 		mockTargetRepo := new(MockTargetRepository)
-		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
 
 		missionID, targetID := uint(1), uint(1)
-		dto := models.UpdateTargetDTO{Notes: new(string)}
+		status := models.TargetStatusCompromised
+		dto := models.UpdateTargetDTO{Status: &status}
+
+		target := &models.Target{ID: 1, MissionID: 1, Name: "Target Alpha", Status: models.TargetStatusUnderSurveillance}
+		mission := &models.Mission{ID: 1, Complete: false}
+
+		mockTargetRepo.On("GetByMissionAndID", missionID, targetID).Return(target, nil)
+		mockMissionRepo.On("GetByIDForUpdate", missionID).Return(mission, nil)
+		mockTargetRepo.On("CreateTransition", mock.MatchedBy(func(tr *models.TargetTransition) bool {
+			return tr.From == models.TargetStatusUnderSurveillance && tr.To == models.TargetStatusCompromised
+		})).Return(nil)
+		mockTargetRepo.On("Update", mock.MatchedBy(func(t *models.Target) bool {
+			return t.Status == models.TargetStatusCompromised && !t.Complete
+		})).Return(nil)
+		mockMissionRepo.On("GetByID", missionID).Return(mission, nil)
 
-		target := &models.Target{ID: 1, MissionID: 2, Name: "Target Alpha", Complete: false}
+		_, err := missionService.UpdateTarget(context.Background(), missionID, targetID, dto)
 
-		mockTargetRepo.On("GetByID", targetID).Return(target, nil)
+		assert.NoError(t, err)
+		mockTargetRepo.AssertExpectations(t)
+		mockMissionRepo.AssertExpectations(t)
+		mockMissionRepo.AssertNotCalled(t, "Update", mock.Anything)
+	})
 
-		result, err := missionService.UpdateTarget(missionID, targetID, dto)
+	t.Run("invalid status transition", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
+		mockMissionRepo := new(MockMissionRepository)
+		mockTargetRepo := new(MockTargetRepository)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
+
+		missionID, targetID := uint(1), uint(1)
+		status := models.TargetStatusPending
+		dto := models.UpdateTargetDTO{Status: &status}
+
+		target := &models.Target{ID: 1, MissionID: 1, Name: "Target Alpha", Status: models.TargetStatusUnderSurveillance}
+		mission := &models.Mission{ID: 1, Complete: false}
+
+		mockTargetRepo.On("GetByMissionAndID", missionID, targetID).Return(target, nil)
+		mockMissionRepo.On("GetByIDForUpdate", missionID).Return(mission, nil)
+
+		result, err := missionService.UpdateTarget(context.Background(), missionID, targetID, dto)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
-		assert.Contains(t, err.Error(), "target does not belong to this mission")
+		assert.IsType(t, custerr.ConflictErr{}, err)
+		assert.Contains(t, err.Error(), "cannot change target status from \"under_surveillance\" to \"pending\"")
+		mockTargetRepo.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("last terminal target completes mission", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
+		mockMissionRepo := new(MockMissionRepository)
+		mockTargetRepo := new(MockTargetRepository)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
+
+		missionID, targetID := uint(1), uint(1)
+		status := models.TargetStatusEscaped
+		dto := models.UpdateTargetDTO{Status: &status}
+
+		target := &models.Target{ID: 1, MissionID: 1, Name: "Target Alpha", Status: models.TargetStatusCompromised}
+		mission := &models.Mission{ID: 1, Complete: false, Targets: []models.Target{
+			{ID: 1, MissionID: 1, Name: "Target Alpha", Status: models.TargetStatusEscaped, Complete: true},
+			{ID: 2, MissionID: 1, Name: "Target Beta", Status: models.TargetStatusNeutralized, Complete: true},
+		}}
+
+		mockMissionRepo.On("GetByIDForUpdate", missionID).Return(&models.Mission{ID: 1}, nil)
+		mockTargetRepo.On("GetByMissionAndID", missionID, targetID).Return(target, nil)
+		mockMissionRepo.On("GetByID", missionID).Return(mission, nil)
+		mockTargetRepo.On("CreateTransition", mock.AnythingOfType("*models.TargetTransition")).Return(nil)
+		mockTargetRepo.On("Update", mock.AnythingOfType("*models.Target")).Return(nil)
+		mockMissionRepo.On("Update", mock.MatchedBy(func(m *models.Mission) bool {
+			return m.ID == missionID && m.Complete
+		})).Return(nil)
+
+		_, err := missionService.UpdateTarget(context.Background(), missionID, targetID, dto)
+
+		assert.NoError(t, err)
+		mockTargetRepo.AssertExpectations(t)
+		mockMissionRepo.AssertExpectations(t)
+	})
+
+	t.Run("completion sees targets completed concurrently", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
+		mockMissionRepo := new(MockMissionRepository)
+		mockTargetRepo := new(MockTargetRepository)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
+
+		missionID, targetID := uint(1), uint(1)
+		complete := true
+		dto := models.UpdateTargetDTO{Complete: &complete}
+
+		target := &models.Target{ID: 1, MissionID: 1, Name: "Target Alpha", Status: models.TargetStatusUnderSurveillance}
+		// target 2 was neutralized by another request while this one waited
+		// for the mission lock
+		fresh := &models.Mission{ID: 1, Complete: false, Targets: []models.Target{
+			{ID: 1, MissionID: 1, Name: "Target Alpha", Status: models.TargetStatusNeutralized, Complete: true},
+			{ID: 2, MissionID: 1, Name: "Target Beta", Status: models.TargetStatusNeutralized, Complete: true},
+		}}
+
+		lock := mockMissionRepo.On("GetByIDForUpdate", missionID).Return(&models.Mission{ID: 1}, nil)
+		mockTargetRepo.On("GetByMissionAndID", missionID, targetID).Return(target, nil).NotBefore(lock)
+		mockTargetRepo.On("CreateTransition", mock.AnythingOfType("*models.TargetTransition")).Return(nil)
+		update := mockTargetRepo.On("Update", target).Return(nil)
+		mockMissionRepo.On("GetByID", missionID).Return(fresh, nil).NotBefore(update)
+		mockMissionRepo.On("Update", mock.MatchedBy(func(m *models.Mission) bool {
+			return m == fresh && m.Complete
+		})).Return(nil)
+
+		result, err := missionService.UpdateTarget(context.Background(), missionID, targetID, dto)
+
+		assert.NoError(t, err)
+		assert.True(t, result.Complete)
 		mockTargetRepo.AssertExpectations(t)
+		mockMissionRepo.AssertExpectations(t)
+	})
+
+	t.Run("target does not belong to mission", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
+		mockMissionRepo := new(MockMissionRepository)
+		mockTargetRepo := new(MockTargetRepository)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
+
+		missionID, targetID := uint(1), uint(1)
+		dto := models.UpdateTargetDTO{Notes: new(string)}
+
+		mockMissionRepo.On("GetByIDForUpdate", missionID).Return(&models.Mission{ID: 1}, nil)
+		mockTargetRepo.On("GetByMissionAndID", missionID, targetID).Return(nil, custerr.NewNotFoundErr("no target with id \"1\" in mission \"1\""))
+
+		result, err := missionService.UpdateTarget(context.Background(), missionID, targetID, dto)
+
+		assert.Nil(t, result)
+		assert.IsType(t, custerr.NotFoundErr{}, err)
+		mockTargetRepo.AssertNotCalled(t, "Update", mock.Anything)
+		mockMissionRepo.AssertNotCalled(t, "GetByID", mock.Anything)
 	})
 
 	t.Run("cannot update completed target", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
 		mockMissionRepo := new(MockMissionRepository)
 		mockTargetRepo := new(MockTargetRepository)
-		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
 
 		missionID, targetID := uint(1), uint(1)
 		newNotes := "Updated notes"
 		dto := models.UpdateTargetDTO{Notes: &newNotes}
 
-		target := &models.Target{ID: 1, MissionID: 1, Name: "Target Alpha", Complete: true}
+		target := &models.Target{ID: 1, MissionID: 1, Name: "Target Alpha", Status: models.TargetStatusNeutralized, Complete: true}
 		mission := &models.Mission{ID: 1, Complete: false}
 
-		mockTargetRepo.On("GetByID", targetID).Return(target, nil)
-		mockMissionRepo.On("GetByID", missionID).Return(mission, nil)
+		mockTargetRepo.On("GetByMissionAndID", missionID, targetID).Return(target, nil)
+		mockMissionRepo.On("GetByIDForUpdate", missionID).Return(mission, nil)
 
-		result, err := missionService.UpdateTarget(missionID, targetID, dto)
+		result, err := missionService.UpdateTarget(context.Background(), missionID, targetID, dto)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
-		assert.Contains(t, err.Error(), "cannot update notes of completed target")
+		assert.Contains(t, err.Error(), "cannot update target in terminal status \"neutralized\"")
 		mockTargetRepo.AssertExpectations(t)
 		mockMissionRepo.AssertExpectations(t)
 	})
 
 	t.Run("cannot update target on completed mission", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
 		mockMissionRepo := new(MockMissionRepository)
 		mockTargetRepo := new(MockTargetRepository)
-		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
 
 		missionID, targetID := uint(1), uint(1)
 		newNotes := "Updated notes"
 		dto := models.UpdateTargetDTO{Notes: &newNotes}
 
-		target := &models.Target{ID: 1, MissionID: 1, Name: "Target Alpha", Complete: false}
+		target := &models.Target{ID: 1, MissionID: 1, Name: "Target Alpha", Status: models.TargetStatusPending}
 		mission := &models.Mission{ID: 1, Complete: true}
 
-		mockTargetRepo.On("GetByID", targetID).Return(target, nil)
-		mockMissionRepo.On("GetByID", missionID).Return(mission, nil)
+		mockTargetRepo.On("GetByMissionAndID", missionID, targetID).Return(target, nil)
+		mockMissionRepo.On("GetByIDForUpdate", missionID).Return(mission, nil)
 
-		result, err := missionService.UpdateTarget(missionID, targetID, dto)
+		result, err := missionService.UpdateTarget(context.Background(), missionID, targetID, dto)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -542,16 +1224,18 @@ func TestMissionService_UpdateTarget(t *testing.T) {
 	})
 
 	t.Run("target not found", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
 		mockMissionRepo := new(MockMissionRepository)
 		mockTargetRepo := new(MockTargetRepository)
-		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
 
 		missionID, targetID := uint(1), uint(999)
 		dto := models.UpdateTargetDTO{Notes: new(string)}
 
-		mockTargetRepo.On("GetByID", targetID).Return(nil, errors.New("target not found"))
+		mockMissionRepo.On("GetByIDForUpdate", missionID).Return(&models.Mission{ID: 1}, nil)
+		mockTargetRepo.On("GetByMissionAndID", missionID, targetID).Return(nil, errors.New("target not found"))
 
-		result, err := missionService.UpdateTarget(missionID, targetID, dto)
+		result, err := missionService.UpdateTarget(context.Background(), missionID, targetID, dto)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -562,21 +1246,23 @@ func TestMissionService_UpdateTarget(t *testing.T) {
 
 func TestMissionService_DeleteTarget(t *testing.T) {
 	t.Run("successful target deletion", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
 		mockMissionRepo := new(MockMissionRepository)
 		mockTargetRepo := new(MockTargetRepository)
-		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
 
 		missionID, targetID := uint(1), uint(1)
 
 		target := &models.Target{ID: 1, MissionID: 1, Name: "Target Alpha", Complete: false}
 		updatedMission := &models.Mission{ID: 1, Complete: false}
 
-		mockTargetRepo.On("GetByID", targetID).Return(target, nil)
+		mockTargetRepo.On("GetByMissionAndID", missionID, targetID).Return(target, nil)
+		mockMissionRepo.On("GetByIDForUpdate", missionID).Return(&models.Mission{ID: 1}, nil)
 		mockTargetRepo.On("CountByMissionID", missionID).Return(int64(2), nil)
 		mockTargetRepo.On("Delete", targetID).Return(nil)
 		mockMissionRepo.On("GetByID", missionID).Return(updatedMission, nil)
 
-		result, err := missionService.DeleteTarget(missionID, targetID)
+		result, err := missionService.DeleteTarget(context.Background(), missionID, targetID)
 
 		assert.NoError(t, err)
 		assert.Equal(t, updatedMission, result)
@@ -585,56 +1271,61 @@ func TestMissionService_DeleteTarget(t *testing.T) {
 	})
 
 	t.Run("target does not belong to mission", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
 		mockMissionRepo := new(MockMissionRepository)
 		mockTargetRepo := new(MockTargetRepository)
-		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
 
 		missionID, targetID := uint(1), uint(1)
 
-		target := &models.Target{ID: 1, MissionID: 2, Name: "Target Alpha", Complete: false}
-
-		mockTargetRepo.On("GetByID", targetID).Return(target, nil)
+		mockMissionRepo.On("GetByIDForUpdate", missionID).Return(&models.Mission{ID: 1}, nil)
+		mockTargetRepo.On("GetByMissionAndID", missionID, targetID).Return(nil, custerr.NewNotFoundErr("no target with id \"1\" in mission \"1\""))
 
-		result, err := missionService.DeleteTarget(missionID, targetID)
+		result, err := missionService.DeleteTarget(context.Background(), missionID, targetID)
 
-		assert.Error(t, err)
 		assert.Nil(t, result)
-		assert.Contains(t, err.Error(), "target does not belong to this mission")
-		mockTargetRepo.AssertExpectations(t)
+		assert.IsType(t, custerr.NotFoundErr{}, err)
+		mockTargetRepo.AssertNotCalled(t, "Delete", mock.Anything)
 	})
 
 	t.Run("cannot delete completed target", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
 		mockMissionRepo := new(MockMissionRepository)
 		mockTargetRepo := new(MockTargetRepository)
-		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
 
 		missionID, targetID := uint(1), uint(1)
 
-		target := &models.Target{ID: 1, MissionID: 1, Name: "Target Alpha", Complete: true}
+		target := &models.Target{ID: 1, MissionID: 1, Name: "Target Alpha", Status: models.TargetStatusNeutralized, Complete: true}
 
-		mockTargetRepo.On("GetByID", targetID).Return(target, nil)
+		// read under the mission lock, so a concurrent completion is seen
+		lock := mockMissionRepo.On("GetByIDForUpdate", missionID).Return(&models.Mission{ID: 1}, nil)
+		mockTargetRepo.On("GetByMissionAndID", missionID, targetID).Return(target, nil).NotBefore(lock)
 
-		result, err := missionService.DeleteTarget(missionID, targetID)
+		result, err := missionService.DeleteTarget(context.Background(), missionID, targetID)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
 		assert.Contains(t, err.Error(), "cannot delete completed target")
 		mockTargetRepo.AssertExpectations(t)
+		mockTargetRepo.AssertNotCalled(t, "Delete", mock.Anything)
 	})
 
 	t.Run("cannot delete last target", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
 		mockMissionRepo := new(MockMissionRepository)
 		mockTargetRepo := new(MockTargetRepository)
-		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
 
 		missionID, targetID := uint(1), uint(1)
 
 		target := &models.Target{ID: 1, MissionID: 1, Name: "Target Alpha", Complete: false}
 
-		mockTargetRepo.On("GetByID", targetID).Return(target, nil)
+		mockTargetRepo.On("GetByMissionAndID", missionID, targetID).Return(target, nil)
+		mockMissionRepo.On("GetByIDForUpdate", missionID).Return(&models.Mission{ID: 1}, nil)
 		mockTargetRepo.On("CountByMissionID", missionID).Return(int64(1), nil)
 
-		result, err := missionService.DeleteTarget(missionID, targetID)
+		result, err := missionService.DeleteTarget(context.Background(), missionID, targetID)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -643,15 +1334,17 @@ func TestMissionService_DeleteTarget(t *testing.T) {
 	})
 
 	t.Run("target not found", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
 		mockMissionRepo := new(MockMissionRepository)
 		mockTargetRepo := new(MockTargetRepository)
-		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
 
 		missionID, targetID := uint(1), uint(999)
 
-		mockTargetRepo.On("GetByID", targetID).Return(nil, errors.New("target not found"))
+		mockMissionRepo.On("GetByIDForUpdate", missionID).Return(&models.Mission{ID: 1}, nil)
+		mockTargetRepo.On("GetByMissionAndID", missionID, targetID).Return(nil, errors.New("target not found"))
 
-		result, err := missionService.DeleteTarget(missionID, targetID)
+		result, err := missionService.DeleteTarget(context.Background(), missionID, targetID)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -660,18 +1353,20 @@ func TestMissionService_DeleteTarget(t *testing.T) {
 	})
 
 	t.Run("database error during count", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
 		mockMissionRepo := new(MockMissionRepository)
 		mockTargetRepo := new(MockTargetRepository)
-		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
 
 		missionID, targetID := uint(1), uint(1)
 
 		target := &models.Target{ID: 1, MissionID: 1, Name: "Target Alpha", Complete: false}
 
-		mockTargetRepo.On("GetByID", targetID).Return(target, nil)
+		mockTargetRepo.On("GetByMissionAndID", missionID, targetID).Return(target, nil)
+		mockMissionRepo.On("GetByIDForUpdate", missionID).Return(&models.Mission{ID: 1}, nil)
 		mockTargetRepo.On("CountByMissionID", missionID).Return(int64(0), errors.New("database error"))
 
-		result, err := missionService.DeleteTarget(missionID, targetID)
+		result, err := missionService.DeleteTarget(context.Background(), missionID, targetID)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -680,23 +1375,232 @@ func TestMissionService_DeleteTarget(t *testing.T) {
 	})
 
 	t.Run("database error during deletion", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
 		mockMissionRepo := new(MockMissionRepository)
 		mockTargetRepo := new(MockTargetRepository)
-		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
 
 		missionID, targetID := uint(1), uint(1)
 
 		target := &models.Target{ID: 1, MissionID: 1, Name: "Target Alpha", Complete: false}
 
-		mockTargetRepo.On("GetByID", targetID).Return(target, nil)
+		mockTargetRepo.On("GetByMissionAndID", missionID, targetID).Return(target, nil)
+		mockMissionRepo.On("GetByIDForUpdate", missionID).Return(&models.Mission{ID: 1}, nil)
 		mockTargetRepo.On("CountByMissionID", missionID).Return(int64(2), nil)
 		mockTargetRepo.On("Delete", targetID).Return(errors.New("delete failed"))
 
-		result, err := missionService.DeleteTarget(missionID, targetID)
+		result, err := missionService.DeleteTarget(context.Background(), missionID, targetID)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
 		assert.Contains(t, err.Error(), "delete failed")
 		mockTargetRepo.AssertExpectations(t)
 	})
+
+	t.Run("deleting last open target completes mission", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
+		mockMissionRepo := new(MockMissionRepository)
+		mockTargetRepo := new(MockTargetRepository)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
+
+		missionID, targetID := uint(1), uint(2)
+
+		target := &models.Target{ID: 2, MissionID: 1, Name: "Target Beta", Status: models.TargetStatusPending}
+		remaining := &models.Mission{ID: 1, Targets: []models.Target{
+			{ID: 1, MissionID: 1, Status: models.TargetStatusNeutralized, Complete: true},
+		}}
+
+		mockTargetRepo.On("GetByMissionAndID", missionID, targetID).Return(target, nil)
+		mockMissionRepo.On("GetByIDForUpdate", missionID).Return(&models.Mission{ID: 1}, nil)
+		mockTargetRepo.On("CountByMissionID", missionID).Return(int64(2), nil)
+		mockTargetRepo.On("Delete", targetID).Return(nil)
+		mockMissionRepo.On("GetByID", missionID).Return(remaining, nil)
+		mockMissionRepo.On("Update", mock.MatchedBy(func(m *models.Mission) bool {
+			return m.ID == missionID && m.Complete
+		})).Return(nil)
+
+		result, err := missionService.DeleteTarget(context.Background(), missionID, targetID)
+
+		assert.NoError(t, err)
+		assert.True(t, result.Complete)
+		mockTargetRepo.AssertExpectations(t)
+		mockMissionRepo.AssertExpectations(t)
+	})
+}
+
+func TestMissionService_TargetRevisions(t *testing.T) {
+	t.Run("notes change is recorded with its author", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
+		mockMissionRepo := new(MockMissionRepository)
+		mockTargetRepo := new(MockTargetRepository)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
+
+		newNotes := "Seen at the docks"
+		target := &models.Target{ID: 1, MissionID: 1, Notes: "Seen at the station", Status: models.TargetStatusPending}
+
+		mockTargetRepo.On("GetByMissionAndID", uint(1), uint(1)).Return(target, nil)
+		mockMissionRepo.On("GetByIDForUpdate", uint(1)).Return(&models.Mission{ID: 1}, nil)
+		mockMissionRepo.On("GetByID", uint(1)).Return(&models.Mission{ID: 1}, nil)
+		mockTargetRepo.On("Update", target).Return(nil)
+		mockTargetRepo.On("CreateNoteRevision", &models.TargetNoteRevision{TargetID: 1, Author: "agent-tom", Notes: newNotes}).Return(nil)
+
+		ctx := actor.WithName(context.Background(), "agent-tom")
+		_, err := missionService.UpdateTarget(ctx, 1, 1, models.UpdateTargetDTO{Notes: &newNotes})
+
+		assert.NoError(t, err)
+		mockTargetRepo.AssertExpectations(t)
+	})
+
+	t.Run("unchanged notes create no revision", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
+		mockMissionRepo := new(MockMissionRepository)
+		mockTargetRepo := new(MockTargetRepository)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
+
+		notes := "Seen at the station"
+		target := &models.Target{ID: 1, MissionID: 1, Notes: notes, Status: models.TargetStatusPending}
+
+		mockTargetRepo.On("GetByMissionAndID", uint(1), uint(1)).Return(target, nil)
+		mockMissionRepo.On("GetByIDForUpdate", uint(1)).Return(&models.Mission{ID: 1}, nil)
+		mockMissionRepo.On("GetByID", uint(1)).Return(&models.Mission{ID: 1}, nil)
+		mockTargetRepo.On("Update", target).Return(nil)
+
+		_, err := missionService.UpdateTarget(context.Background(), 1, 1, models.UpdateTargetDTO{Notes: &notes})
+
+		assert.NoError(t, err)
+		mockTargetRepo.AssertNotCalled(t, "CreateNoteRevision", mock.Anything)
+	})
+
+	t.Run("lists revisions", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
+		mockMissionRepo := new(MockMissionRepository)
+		mockTargetRepo := new(MockTargetRepository)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
+
+		revisions := []models.TargetNoteRevision{{ID: 1, TargetID: 1, Notes: "a"}, {ID: 2, TargetID: 1, Notes: "b"}}
+
+		mockTargetRepo.On("GetByMissionAndID", uint(1), uint(1)).Return(&models.Target{ID: 1, MissionID: 1}, nil)
+		mockTargetRepo.On("GetNoteRevisions", uint(1)).Return(revisions, nil)
+
+		result, err := missionService.GetTargetRevisions(context.Background(), 1, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, revisions, result)
+		mockTargetRepo.AssertExpectations(t)
+	})
+
+	t.Run("target does not belong to mission", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
+		mockMissionRepo := new(MockMissionRepository)
+		mockTargetRepo := new(MockTargetRepository)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
+
+		mockTargetRepo.On("GetByMissionAndID", uint(1), uint(1)).Return(nil, custerr.NewNotFoundErr("no target with id \"1\" in mission \"1\""))
+
+		result, err := missionService.GetTargetRevision(context.Background(), 1, 1, 1)
+
+		assert.Nil(t, result)
+		assert.IsType(t, custerr.NotFoundErr{}, err)
+		mockTargetRepo.AssertNotCalled(t, "GetNoteRevision", mock.Anything, mock.Anything)
+	})
+
+	t.Run("diffs two revisions", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
+		mockMissionRepo := new(MockMissionRepository)
+		mockTargetRepo := new(MockTargetRepository)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
+
+		mockTargetRepo.On("GetByMissionAndID", uint(1), uint(1)).Return(&models.Target{ID: 1, MissionID: 1}, nil)
+		mockTargetRepo.On("GetNoteRevision", uint(1), uint(3)).Return(&models.TargetNoteRevision{ID: 3, Notes: "armed\nwears a hat"}, nil)
+		mockTargetRepo.On("GetNoteRevision", uint(1), uint(5)).Return(&models.TargetNoteRevision{ID: 5, Notes: "armed\nwears a scarf"}, nil)
+
+		result, err := missionService.DiffTargetRevisions(context.Background(), 1, 1, 3, 5)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &models.TargetNotesDiff{
+			TargetID: 1,
+			From:     3,
+			To:       5,
+			Lines: []textdiff.Line{
+				{Op: textdiff.OpEqual, Text: "armed"},
+				{Op: textdiff.OpDelete, Text: "wears a hat"},
+				{Op: textdiff.OpInsert, Text: "wears a scarf"},
+			},
+		}, result)
+	})
+
+	t.Run("diff with unknown revision", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
+		mockMissionRepo := new(MockMissionRepository)
+		mockTargetRepo := new(MockTargetRepository)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
+
+		mockTargetRepo.On("GetByMissionAndID", uint(1), uint(1)).Return(&models.Target{ID: 1, MissionID: 1}, nil)
+		mockTargetRepo.On("GetNoteRevision", uint(1), uint(3)).Return(nil, custerr.NewNotFoundErr("no note revision with id \"3\" for target \"1\""))
+
+		result, err := missionService.DiffTargetRevisions(context.Background(), 1, 1, 3, 5)
+
+		assert.Nil(t, result)
+		assert.IsType(t, custerr.NotFoundErr{}, err)
+	})
+}
+
+func TestMissionService_UpdateTarget_FieldCat(t *testing.T) {
+	catID, otherCatID := uint(3), uint(4)
+
+	t.Run("field cat updates target of own mission", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
+		mockMissionRepo := new(MockMissionRepository)
+		mockTargetRepo := new(MockTargetRepository)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
+
+		target := &models.Target{ID: 1, MissionID: 1, Status: models.TargetStatusPending}
+
+		mockTargetRepo.On("GetByMissionAndID", uint(1), uint(1)).Return(target, nil)
+		mockMissionRepo.On("GetByIDForUpdate", uint(1)).Return(&models.Mission{ID: 1, CatID: &catID}, nil)
+		mockMissionRepo.On("GetByID", uint(1)).Return(&models.Mission{ID: 1, CatID: &catID}, nil)
+		mockTargetRepo.On("CreateTransition", mock.AnythingOfType("*models.TargetTransition")).Return(nil)
+		mockTargetRepo.On("Update", target).Return(nil)
+
+		ctx := auth.WithPrincipal(context.Background(), &auth.Principal{Subject: "tom", Role: auth.RoleFieldCat, CatID: &catID})
+		status := models.TargetStatusUnderSurveillance
+		_, err := missionService.UpdateTarget(ctx, 1, 1, models.UpdateTargetDTO{Status: &status})
+
+		assert.NoError(t, err)
+		mockTargetRepo.AssertExpectations(t)
+	})
+
+	t.Run("field cat cannot update target of another cat's mission", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
+		mockMissionRepo := new(MockMissionRepository)
+		mockTargetRepo := new(MockTargetRepository)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
+
+		mockTargetRepo.On("GetByMissionAndID", uint(1), uint(1)).Return(&models.Target{ID: 1, MissionID: 1, Status: models.TargetStatusPending}, nil)
+		mockMissionRepo.On("GetByIDForUpdate", uint(1)).Return(&models.Mission{ID: 1, CatID: &otherCatID}, nil)
+
+		ctx := auth.WithPrincipal(context.Background(), &auth.Principal{Subject: "tom", Role: auth.RoleFieldCat, CatID: &catID})
+		notes := "spotted"
+		result, err := missionService.UpdateTarget(ctx, 1, 1, models.UpdateTargetDTO{Notes: &notes})
+
+		assert.Nil(t, result)
+		assert.IsType(t, custerr.ForbiddenErr{}, err)
+		mockTargetRepo.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("field cat cannot update target of unassigned mission", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
+		mockMissionRepo := new(MockMissionRepository)
+		mockTargetRepo := new(MockTargetRepository)
+		missionService := service.NewMissionService(mockMissionRepo, mockTargetRepo, newMockUnitOfWork(mockCatRepo, mockMissionRepo, mockTargetRepo))
+
+		mockTargetRepo.On("GetByMissionAndID", uint(1), uint(1)).Return(&models.Target{ID: 1, MissionID: 1, Status: models.TargetStatusPending}, nil)
+		mockMissionRepo.On("GetByIDForUpdate", uint(1)).Return(&models.Mission{ID: 1}, nil)
+
+		ctx := auth.WithPrincipal(context.Background(), &auth.Principal{Subject: "tom", Role: auth.RoleFieldCat, CatID: &catID})
+		notes := "spotted"
+		_, err := missionService.UpdateTarget(ctx, 1, 1, models.UpdateTargetDTO{Notes: &notes})
+
+		assert.IsType(t, custerr.ForbiddenErr{}, err)
+	})
 }
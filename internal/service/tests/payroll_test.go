@@ -0,0 +1,123 @@
+package tests
+
+import (
+	"context"
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/internal/service"
+	"spy-cat-agency/pkg/custerr"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func payrollQuery(from, to time.Time) models.PayrollQuery {
+	return models.PayrollQuery{From: &from, To: &to}
+}
+
+func TestPayrollService_GetReport(t *testing.T) {
+	t.Run("prorates salaries around a mid-period change", func(t *testing.T) {
+		mockRepo := new(MockCatRepository)
+		payrollService := service.NewPayrollService(mockRepo)
+
+		mockRepo.On("GetPayrollCats", day(2024, 1, 1), day(2024, 2, 1)).Return([]models.Cat{
+			{ID: 1, CreateCatDTO: models.CreateCatDTO{Name: "Whiskers"}, Salaries: []models.CatSalary{
+				{Salary: 36500, EffectiveFrom: day(2023, 6, 1).Add(9 * time.Hour)},
+				{Salary: 73000, EffectiveFrom: day(2024, 1, 11).Add(15 * time.Hour)},
+			}},
+		}, nil)
+
+		report, err := payrollService.GetReport(context.Background(), payrollQuery(day(2024, 1, 1), day(2024, 1, 31)))
+
+		require.NoError(t, err)
+		assert.Equal(t, "2024-01-01", report.From)
+		assert.Equal(t, "2024-01-31", report.To)
+		assert.Equal(t, 31, report.Days)
+		require.Len(t, report.Cats, 1)
+		assert.Equal(t, []models.PayrollPeriod{
+			{From: "2024-01-01", To: "2024-01-10", Salary: 36500, Days: 10, Amount: 1000},
+			{From: "2024-01-11", To: "2024-01-31", Salary: 73000, Days: 21, Amount: 4200},
+		}, report.Cats[0].Periods)
+		assert.Equal(t, 31, report.Cats[0].Days)
+		assert.Equal(t, 5200.0, report.Cats[0].Owed)
+		assert.Equal(t, 5200.0, report.Total)
+	})
+
+	t.Run("last change of a day wins", func(t *testing.T) {
+		mockRepo := new(MockCatRepository)
+		payrollService := service.NewPayrollService(mockRepo)
+
+		mockRepo.On("GetPayrollCats", mock.Anything, mock.Anything).Return([]models.Cat{
+			{ID: 1, Salaries: []models.CatSalary{
+				{Salary: 1000, EffectiveFrom: day(2024, 3, 1).Add(8 * time.Hour)},
+				{Salary: 2000, EffectiveFrom: day(2024, 3, 1).Add(12 * time.Hour)},
+			}},
+		}, nil)
+
+		report, err := payrollService.GetReport(context.Background(), payrollQuery(day(2024, 3, 1), day(2024, 3, 1)))
+
+		require.NoError(t, err)
+		require.Len(t, report.Cats[0].Periods, 1)
+		assert.Equal(t, 2000.0, report.Cats[0].Periods[0].Salary)
+		assert.Equal(t, 5.48, report.Cats[0].Owed)
+	})
+
+	t.Run("deleted cat stops accruing on its deletion day", func(t *testing.T) {
+		mockRepo := new(MockCatRepository)
+		payrollService := service.NewPayrollService(mockRepo)
+
+		mockRepo.On("GetPayrollCats", mock.Anything, mock.Anything).Return([]models.Cat{
+			{
+				ID:        1,
+				DeletedAt: gorm.DeletedAt{Time: day(2024, 1, 6).Add(10 * time.Hour), Valid: true},
+				Salaries:  []models.CatSalary{{Salary: 36500, EffectiveFrom: day(2023, 1, 1)}},
+			},
+			{
+				ID:        2,
+				DeletedAt: gorm.DeletedAt{Time: day(2024, 1, 1).Add(time.Hour), Valid: true},
+				Salaries:  []models.CatSalary{{Salary: 36500, EffectiveFrom: day(2023, 1, 1)}},
+			},
+		}, nil)
+
+		report, err := payrollService.GetReport(context.Background(), payrollQuery(day(2024, 1, 1), day(2024, 1, 31)))
+
+		require.NoError(t, err)
+		require.Len(t, report.Cats, 1)
+		assert.True(t, report.Cats[0].Deleted)
+		assert.Equal(t, 5, report.Cats[0].Days)
+		assert.Equal(t, 500.0, report.Total)
+	})
+
+	t.Run("cat hired mid-period is paid from its first salary", func(t *testing.T) {
+		mockRepo := new(MockCatRepository)
+		payrollService := service.NewPayrollService(mockRepo)
+
+		mockRepo.On("GetPayrollCats", mock.Anything, mock.Anything).Return([]models.Cat{
+			{ID: 1, Salaries: []models.CatSalary{{Salary: 36500, EffectiveFrom: day(2024, 1, 30).Add(16 * time.Hour)}}},
+		}, nil)
+
+		report, err := payrollService.GetReport(context.Background(), payrollQuery(day(2024, 1, 1), day(2024, 1, 31)))
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, report.Cats[0].Days)
+		assert.Equal(t, 200.0, report.Total)
+	})
+
+	t.Run("rejects to before from", func(t *testing.T) {
+		mockRepo := new(MockCatRepository)
+		payrollService := service.NewPayrollService(mockRepo)
+
+		report, err := payrollService.GetReport(context.Background(), payrollQuery(day(2024, 2, 1), day(2024, 1, 31)))
+
+		assert.Nil(t, report)
+		assert.IsType(t, custerr.BadRequestErr{}, err)
+		mockRepo.AssertNotCalled(t, "GetPayrollCats", mock.Anything, mock.Anything)
+	})
+}
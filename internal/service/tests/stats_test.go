@@ -0,0 +1,119 @@
+package tests
+
+import (
+	"context"
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/internal/service"
+	"spy-cat-agency/pkg/custerr"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockStatsRepository struct {
+	mock.Mock
+}
+
+func (m *MockStatsRepository) Payroll(filter models.StatsFilter) (*models.PayrollStats, error) {
+	args := m.Called(filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PayrollStats), args.Error(1)
+}
+
+func (m *MockStatsRepository) Missions(filter models.StatsFilter) (*models.MissionStats, error) {
+	args := m.Called(filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.MissionStats), args.Error(1)
+}
+
+func (m *MockStatsRepository) CatCompletion(filter models.StatsFilter) ([]models.CatCompletionStats, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]models.CatCompletionStats), args.Error(1)
+}
+
+func (m *MockStatsRepository) TargetsByCountry(filter models.StatsFilter) ([]models.CountryStats, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]models.CountryStats), args.Error(1)
+}
+
+func (m *MockStatsRepository) IdleCats(filter models.StatsFilter) ([]models.IdleCat, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]models.IdleCat), args.Error(1)
+}
+
+func (m *MockStatsRepository) CountIdleCats(filter models.StatsFilter) (int64, error) {
+	args := m.Called(filter)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func TestStatsService_GetOverview(t *testing.T) {
+	t.Run("combines aggregates", func(t *testing.T) {
+		mockRepo := new(MockStatsRepository)
+		statsService := service.NewStatsService(mockRepo)
+
+		filter := models.StatsFilter{}
+		mockRepo.On("Payroll", filter).Return(&models.PayrollStats{Cats: 2, Total: 3000, Average: 1500}, nil)
+		mockRepo.On("Missions", filter).Return(&models.MissionStats{Total: 3, Open: 2, Complete: 1, AverageTargets: 2}, nil)
+		mockRepo.On("CountIdleCats", filter).Return(int64(1), nil)
+
+		stats, err := statsService.GetOverview(context.Background(), filter)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3000.0, stats.Payroll.Total)
+		assert.Equal(t, 0.3333, stats.Missions.CompletionRate)
+		assert.Equal(t, int64(1), stats.IdleCats)
+		mockRepo.AssertNotCalled(t, "IdleCats", mock.Anything)
+	})
+
+	t.Run("no missions has zero completion rate", func(t *testing.T) {
+		mockRepo := new(MockStatsRepository)
+		statsService := service.NewStatsService(mockRepo)
+
+		filter := models.StatsFilter{}
+		mockRepo.On("Payroll", filter).Return(&models.PayrollStats{}, nil)
+		mockRepo.On("Missions", filter).Return(&models.MissionStats{}, nil)
+		mockRepo.On("CountIdleCats", filter).Return(int64(0), nil)
+
+		stats, err := statsService.GetOverview(context.Background(), filter)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0.0, stats.Missions.CompletionRate)
+	})
+
+	t.Run("rejects empty range", func(t *testing.T) {
+		mockRepo := new(MockStatsRepository)
+		statsService := service.NewStatsService(mockRepo)
+
+		from := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+		to := from.AddDate(0, -1, 0)
+
+		stats, err := statsService.GetOverview(context.Background(), models.StatsFilter{From: &from, To: &to})
+
+		assert.Nil(t, stats)
+		assert.IsType(t, custerr.BadRequestErr{}, err)
+		mockRepo.AssertNotCalled(t, "Payroll", mock.Anything)
+	})
+}
+
+func TestStatsService_GetCatCompletion(t *testing.T) {
+	mockRepo := new(MockStatsRepository)
+	statsService := service.NewStatsService(mockRepo)
+
+	filter := models.StatsFilter{DateField: "updated_at"}
+	mockRepo.On("CatCompletion", filter).Return([]models.CatCompletionStats{
+		{CatID: 1, Missions: 4, Completed: 3},
+		{CatID: 2, Missions: 2, Completed: 0},
+	}, nil)
+
+	stats, err := statsService.GetCatCompletion(context.Background(), filter)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0.75, stats[0].CompletionRate)
+	assert.Equal(t, 0.0, stats[1].CompletionRate)
+}
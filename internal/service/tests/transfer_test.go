@@ -0,0 +1,317 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/internal/service"
+	"spy-cat-agency/pkg/catapi"
+	"spy-cat-agency/pkg/custerr"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTransferService(catRepo *MockCatRepository, missionRepo *MockMissionRepository, targetRepo *MockTargetRepository, validator *MockCatValidator) *service.TransferService {
+	return service.NewTransferService(catRepo, missionRepo, newMockUnitOfWork(catRepo, missionRepo, targetRepo), validator)
+}
+
+func importRowErrors(t *testing.T, err error) []models.ImportRowError {
+	badRequest, ok := err.(custerr.BadRequestErr)
+	if !assert.True(t, ok, "expected bad request, got %v", err) {
+		return nil
+	}
+	rowErrs, _ := badRequest.Details()["errors"].([]models.ImportRowError)
+	return rowErrs
+}
+
+func TestTransferService_ImportCats(t *testing.T) {
+	t.Run("imports CSV rows with canonical breeds", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
+		mockValidator := new(MockCatValidator)
+		transferService := newTransferService(mockCatRepo, new(MockMissionRepository), new(MockTargetRepository), mockValidator)
+
+		csv := "name,breed,years_experience,salary\nWhiskers,siam,3,1200\nMittens,Persian,0,900.50\n"
+
+		mockValidator.On("ValidateBreed", "siam").Return(&catapi.CatAPIBreed{ID: "siam", Name: "Siamese"}, nil)
+		mockValidator.On("ValidateBreed", "Persian").Return(&catapi.CatAPIBreed{ID: "pers", Name: "Persian"}, nil)
+		nextID := uint(0)
+		mockCatRepo.On("Create", mock.AnythingOfType("*models.Cat")).Return(nil).Run(func(args mock.Arguments) {
+			nextID++
+			args.Get(0).(*models.Cat).ID = nextID
+		})
+		mockCatRepo.On("CreateSalary", mock.AnythingOfType("*models.CatSalary")).Return(nil)
+
+		result, err := transferService.ImportCats(context.Background(), models.TransferFormatCSV, strings.NewReader(csv))
+
+		assert.NoError(t, err)
+		assert.Equal(t, &models.ImportResult{Created: 2, IDs: []uint{1, 2}}, result)
+		mockCatRepo.AssertCalled(t, "Create", mock.MatchedBy(func(cat *models.Cat) bool {
+			return cat.Name == "Whiskers" && cat.Breed == "Siamese" && cat.BreedID == "siam"
+		}))
+		mockCatRepo.AssertCalled(t, "CreateSalary", mock.MatchedBy(func(salary *models.CatSalary) bool {
+			return salary.CatID == 2 && salary.Salary == 900.50
+		}))
+	})
+
+	t.Run("reports every invalid row and imports nothing", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
+		mockValidator := new(MockCatValidator)
+		transferService := newTransferService(mockCatRepo, new(MockMissionRepository), new(MockTargetRepository), mockValidator)
+
+		json := `[
+			{"name": "Whiskers", "breed": "Siamese", "years_experience": 3, "salary": 1200},
+			{"name": "", "breed": "Siames", "years_experience": -1, "salary": 100}
+		]`
+
+		mockValidator.On("ValidateBreed", "Siamese").Return(&catapi.CatAPIBreed{ID: "siam", Name: "Siamese"}, nil)
+		mockValidator.On("ValidateBreed", "Siames").Return(nil, nil)
+		mockValidator.On("SuggestBreeds", "Siames").Return([]catapi.BreedSuggestion{{ID: "siam", Name: "Siamese"}}, nil)
+
+		result, err := transferService.ImportCats(context.Background(), models.TransferFormatJSON, strings.NewReader(json))
+
+		assert.Nil(t, result)
+		rowErrs := importRowErrors(t, err)
+		assert.Len(t, rowErrs, 3)
+		for _, rowErr := range rowErrs {
+			assert.Equal(t, 2, rowErr.Row)
+		}
+		assert.Contains(t, rowErrs[2].Message, "did you mean Siamese")
+		mockCatRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("reports unparsable CSV values by line", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
+		transferService := newTransferService(mockCatRepo, new(MockMissionRepository), new(MockTargetRepository), new(MockCatValidator))
+
+		csv := "name,years_experience,breed,salary\nWhiskers,three,Siamese,lots\n"
+
+		_, err := transferService.ImportCats(context.Background(), models.TransferFormatCSV, strings.NewReader(csv))
+
+		assert.Equal(t, []models.ImportRowError{
+			{Row: 2, Field: "years_experience", Message: "years_experience must be a whole number"},
+			{Row: 2, Field: "salary", Message: "salary must be a number"},
+		}, importRowErrors(t, err))
+		mockCatRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("rejects unknown CSV columns", func(t *testing.T) {
+		transferService := newTransferService(new(MockCatRepository), new(MockMissionRepository), new(MockTargetRepository), new(MockCatValidator))
+
+		_, err := transferService.ImportCats(context.Background(), models.TransferFormatCSV, strings.NewReader("name,age\nWhiskers,3\n"))
+
+		assert.IsType(t, custerr.BadRequestErr{}, err)
+	})
+
+	t.Run("rejects duplicate CSV columns", func(t *testing.T) {
+		transferService := newTransferService(new(MockCatRepository), new(MockMissionRepository), new(MockTargetRepository), new(MockCatValidator))
+
+		_, err := transferService.ImportCats(context.Background(), models.TransferFormatCSV, strings.NewReader("name,years_experience,breed,salary,Name\nWhiskers,3,Siamese,1200,Tom\n"))
+
+		assert.IsType(t, custerr.BadRequestErr{}, err)
+		assert.EqualError(t, err, "duplicate CSV column \"name\"")
+		assert.Equal(t, models.ErrCodeInvalidImport, err.(custerr.BadRequestErr).Code())
+	})
+}
+
+func TestTransferService_ImportMissions(t *testing.T) {
+	t.Run("imports CSV missions grouped by mission column", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
+		mockMissionRepo := new(MockMissionRepository)
+		mockTargetRepo := new(MockTargetRepository)
+		transferService := newTransferService(mockCatRepo, mockMissionRepo, mockTargetRepo, new(MockCatValidator))
+
+		csv := "mission,cat_id,target_name,target_country\n" +
+			"a,3,Boris,Russia\n" +
+			"b,,Olga,Poland\n" +
+			"a,3,Ivan,Ukraine\n"
+
+		catID := uint(3)
+		mockCatRepo.On("GetByID", catID).Return(&models.Cat{ID: catID}, nil)
+		mockCatRepo.On("GetByIDForUpdate", catID).Return(&models.Cat{ID: catID}, nil)
+		mockMissionRepo.On("GetActiveByCatID", catID).Return(nil, nil)
+		nextID := uint(0)
+		mockMissionRepo.On("Create", mock.AnythingOfType("*models.Mission")).Return(nil).Run(func(args mock.Arguments) {
+			nextID++
+			args.Get(0).(*models.Mission).ID = nextID
+		})
+		mockTargetRepo.On("Create", mock.AnythingOfType("*models.Target")).Return(nil)
+		mockMissionRepo.On("Update", mock.MatchedBy(func(m *models.Mission) bool {
+			return m.ID == 1 && *m.CatID == catID
+		})).Return(nil)
+		mockMissionRepo.On("CreateAssignment", mock.MatchedBy(func(a *models.MissionAssignment) bool {
+			return a.MissionID == 1 && *a.ToCatID == catID && a.Reason == "imported"
+		})).Return(nil)
+
+		result, err := transferService.ImportMissions(context.Background(), models.TransferFormatCSV, strings.NewReader(csv))
+
+		assert.NoError(t, err)
+		assert.Equal(t, &models.ImportResult{Created: 2, IDs: []uint{1, 2}}, result)
+		mockTargetRepo.AssertNumberOfCalls(t, "Create", 3)
+		mockMissionRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects target counts, busy and duplicate cats", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
+		mockMissionRepo := new(MockMissionRepository)
+		transferService := newTransferService(mockCatRepo, mockMissionRepo, new(MockTargetRepository), new(MockCatValidator))
+
+		json := `[
+			{"targets": []},
+			{"cat_id": 3, "targets": [{"name": "Boris", "country": "Russia"}]},
+			{"cat_id": 4, "targets": [{"name": "Olga", "country": "Poland"}]},
+			{"cat_id": 4, "targets": [{"name": "Ivan", "country": ""}]}
+		]`
+
+		busyCatID := uint(3)
+		mockCatRepo.On("GetByID", busyCatID).Return(&models.Cat{ID: busyCatID}, nil)
+		mockMissionRepo.On("GetActiveByCatID", busyCatID).Return(&models.Mission{ID: 9, CatID: &busyCatID}, nil)
+		mockCatRepo.On("GetByID", uint(4)).Return(&models.Cat{ID: 4}, nil)
+		mockMissionRepo.On("GetActiveByCatID", uint(4)).Return(nil, nil)
+
+		result, err := transferService.ImportMissions(context.Background(), models.TransferFormatJSON, strings.NewReader(json))
+
+		assert.Nil(t, result)
+		rowErrs := importRowErrors(t, err)
+		rows := make([]int, len(rowErrs))
+		for i, rowErr := range rowErrs {
+			rows[i] = rowErr.Row
+		}
+		assert.Equal(t, []int{1, 2, 4, 4}, rows)
+		mockMissionRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("reports CSV missions without targets", func(t *testing.T) {
+		mockMissionRepo := new(MockMissionRepository)
+		transferService := newTransferService(new(MockCatRepository), mockMissionRepo, new(MockTargetRepository), new(MockCatValidator))
+
+		csv := "mission,cat_id,target_name,target_country,target_notes\n" +
+			"1,,Boris,Russia,\n" +
+			"2,,,,\n"
+
+		result, err := transferService.ImportMissions(context.Background(), models.TransferFormatCSV, strings.NewReader(csv))
+
+		assert.Nil(t, result)
+		assert.Equal(t, []models.ImportRowError{
+			{Row: 3, Field: "targets", Message: "missions must have 1-3 targets, got 0"},
+		}, importRowErrors(t, err))
+		mockMissionRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+}
+
+func TestTransferService_Export(t *testing.T) {
+	t.Run("exports cats as importable CSV", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
+		transferService := newTransferService(mockCatRepo, new(MockMissionRepository), new(MockTargetRepository), new(MockCatValidator))
+
+		cats := []models.Cat{
+			{ID: 1, CreateCatDTO: models.CreateCatDTO{Name: "Whiskers", YearsExperience: 3, Breed: "Siamese", Salary: 1200.5}},
+		}
+		mockCatRepo.On("GetAll", mock.MatchedBy(func(filter models.CatFilter) bool {
+			return filter.Page == 1 && filter.PerPage == models.MaxPerPage && filter.Breed == "Siamese"
+		})).Return(cats, int64(1), nil)
+
+		data, err := transferService.ExportCats(context.Background(), models.CatExportFilter{
+			TransferQuery: models.TransferQuery{Format: models.TransferFormatCSV},
+			Breed:         "Siamese",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "name,years_experience,breed,salary\nWhiskers,3,Siamese,1200.5\n", string(data))
+	})
+
+	t.Run("exports missions one target per CSV line", func(t *testing.T) {
+		mockMissionRepo := new(MockMissionRepository)
+		transferService := newTransferService(new(MockCatRepository), mockMissionRepo, new(MockTargetRepository), new(MockCatValidator))
+
+		catID := uint(3)
+		missions := []models.Mission{
+			{ID: 7, CatID: &catID, Targets: []models.Target{
+				{Name: "Boris", Country: "Russia", Notes: "tall, dark"},
+				{Name: "Ivan", Country: "Ukraine"},
+			}},
+		}
+		mockMissionRepo.On("GetAll", mock.AnythingOfType("models.MissionFilter")).Return(missions, int64(1), nil)
+
+		data, err := transferService.ExportMissions(context.Background(), models.MissionExportFilter{
+			TransferQuery: models.TransferQuery{Format: models.TransferFormatCSV},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "mission,cat_id,target_name,target_country,target_notes\n"+
+			"1,3,Boris,Russia,\"tall, dark\"\n"+
+			"1,3,Ivan,Ukraine,\n", string(data))
+	})
+
+	t.Run("keeps missions without targets in CSV", func(t *testing.T) {
+		mockMissionRepo := new(MockMissionRepository)
+		transferService := newTransferService(new(MockCatRepository), mockMissionRepo, new(MockTargetRepository), new(MockCatValidator))
+
+		catID := uint(3)
+		missions := []models.Mission{
+			{ID: 7, CatID: &catID},
+			{ID: 8, Targets: []models.Target{{Name: "Olga", Country: "Poland"}}},
+		}
+		mockMissionRepo.On("GetAll", mock.AnythingOfType("models.MissionFilter")).Return(missions, int64(2), nil)
+
+		data, err := transferService.ExportMissions(context.Background(), models.MissionExportFilter{
+			TransferQuery: models.TransferQuery{Format: models.TransferFormatCSV},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "mission,cat_id,target_name,target_country,target_notes\n"+
+			"1,3,,,\n"+
+			"2,,Olga,Poland,\n", string(data))
+	})
+
+	t.Run("exported missions import again without reassigning cats", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
+		mockMissionRepo := new(MockMissionRepository)
+		mockTargetRepo := new(MockTargetRepository)
+		transferService := newTransferService(mockCatRepo, mockMissionRepo, mockTargetRepo, new(MockCatValidator))
+
+		activeCatID, retiredCatID := uint(3), uint(4)
+		missions := []models.Mission{
+			{ID: 7, CatID: &activeCatID, Targets: []models.Target{{Name: "Boris", Country: "Russia"}}},
+			{ID: 8, CatID: &retiredCatID, Complete: true, Targets: []models.Target{{Name: "Olga", Country: "Poland", Complete: true}}},
+		}
+		mockMissionRepo.On("GetAll", mock.AnythingOfType("models.MissionFilter")).Return(missions, int64(2), nil)
+
+		data, err := transferService.ExportMissions(context.Background(), models.MissionExportFilter{
+			TransferQuery: models.TransferQuery{Format: models.TransferFormatCSV},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "mission,cat_id,target_name,target_country,target_notes\n"+
+			"1,3,Boris,Russia,\n"+
+			"2,,Olga,Poland,\n", string(data))
+
+		// the active mission moved to the new database with its cat, which is
+		// free there
+		mockCatRepo.On("GetByID", activeCatID).Return(&models.Cat{ID: activeCatID}, nil)
+		mockCatRepo.On("GetByIDForUpdate", activeCatID).Return(&models.Cat{ID: activeCatID}, nil)
+		mockMissionRepo.On("GetActiveByCatID", activeCatID).Return(nil, nil)
+		nextID := uint(0)
+		mockMissionRepo.On("Create", mock.AnythingOfType("*models.Mission")).Return(nil).Run(func(args mock.Arguments) {
+			nextID++
+			args.Get(0).(*models.Mission).ID = nextID
+		})
+		mockTargetRepo.On("Create", mock.AnythingOfType("*models.Target")).Return(nil)
+		mockMissionRepo.On("Update", mock.MatchedBy(func(m *models.Mission) bool {
+			return m.ID == 1 && *m.CatID == activeCatID
+		})).Return(nil)
+		mockMissionRepo.On("CreateAssignment", mock.MatchedBy(func(a *models.MissionAssignment) bool {
+			return a.MissionID == 1 && *a.ToCatID == activeCatID
+		})).Return(nil)
+
+		result, err := transferService.ImportMissions(context.Background(), models.TransferFormatCSV, bytes.NewReader(data))
+
+		assert.NoError(t, err)
+		assert.Equal(t, &models.ImportResult{Created: 2, IDs: []uint{1, 2}}, result)
+		mockMissionRepo.AssertExpectations(t)
+		mockCatRepo.AssertNotCalled(t, "GetByID", retiredCatID)
+		mockMissionRepo.AssertNotCalled(t, "GetActiveByCatID", retiredCatID)
+	})
+}
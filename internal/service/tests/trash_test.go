@@ -0,0 +1,243 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"spy-cat-agency/internal/actor"
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/internal/service"
+	"spy-cat-agency/pkg/custerr"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTrashService(catRepo *MockCatRepository, missionRepo *MockMissionRepository, targetRepo *MockTargetRepository) (*service.TrashService, *MockUnitOfWork) {
+	uow := newMockUnitOfWork(catRepo, missionRepo, targetRepo)
+	return service.NewTrashService(catRepo, missionRepo, targetRepo, uow), uow
+}
+
+func TestTrashService_RestoreCat(t *testing.T) {
+	t.Run("restores deleted cat", func(t *testing.T) {
+		mockRepo := new(MockCatRepository)
+		trashService, uow := newTrashService(mockRepo, new(MockMissionRepository), new(MockTargetRepository))
+
+		cat := &models.Cat{ID: 1}
+		mockRepo.On("GetDeletedByID", uint(1)).Return(cat, nil)
+		mockRepo.On("Restore", uint(1)).Return(nil)
+		mockRepo.On("GetByID", uint(1)).Return(cat, nil)
+
+		result, err := trashService.RestoreCat(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, cat, result)
+		assert.Equal(t, models.AuditActionRestore, uow.audit.entries[0].Action)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("cat is not deleted", func(t *testing.T) {
+		mockRepo := new(MockCatRepository)
+		trashService, _ := newTrashService(mockRepo, new(MockMissionRepository), new(MockTargetRepository))
+
+		mockRepo.On("GetDeletedByID", uint(1)).Return(nil, custerr.NewNotFoundErr("no deleted cat with id \"1\""))
+
+		result, err := trashService.RestoreCat(context.Background(), 1)
+
+		assert.Nil(t, result)
+		assert.IsType(t, custerr.NotFoundErr{}, err)
+		mockRepo.AssertNotCalled(t, "Restore", mock.Anything)
+	})
+}
+
+func TestTrashService_RestoreMission(t *testing.T) {
+	t.Run("restores mission and reclaims its cat", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
+		mockMissionRepo := new(MockMissionRepository)
+		mockTargetRepo := new(MockTargetRepository)
+		trashService, uow := newTrashService(mockCatRepo, mockMissionRepo, mockTargetRepo)
+
+		catID := uint(3)
+		mission := &models.Mission{ID: 1, CatID: &catID}
+		mockMissionRepo.On("GetDeletedByIDForUpdate", uint(1)).Return(mission, nil)
+		mockTargetRepo.On("CountByMissionID", uint(1)).Return(int64(2), nil)
+		mockCatRepo.On("GetByIDForUpdate", catID).Return(&models.Cat{ID: catID}, nil)
+		mockMissionRepo.On("GetActiveByCatID", catID).Return(nil, nil)
+		mockMissionRepo.On("Restore", uint(1)).Return(nil)
+		mockMissionRepo.On("GetByID", uint(1)).Return(mission, nil)
+
+		result, err := trashService.RestoreMission(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, mission, result)
+		assert.Equal(t, models.AuditEntityMission, uow.audit.entries[0].EntityType)
+		mockMissionRepo.AssertExpectations(t)
+	})
+
+	t.Run("mission without targets", func(t *testing.T) {
+		mockMissionRepo := new(MockMissionRepository)
+		mockTargetRepo := new(MockTargetRepository)
+		trashService, _ := newTrashService(new(MockCatRepository), mockMissionRepo, mockTargetRepo)
+
+		mockMissionRepo.On("GetDeletedByIDForUpdate", uint(1)).Return(&models.Mission{ID: 1}, nil)
+		mockTargetRepo.On("CountByMissionID", uint(1)).Return(int64(0), nil)
+
+		result, err := trashService.RestoreMission(context.Background(), 1)
+
+		assert.Nil(t, result)
+		assert.IsType(t, custerr.ConflictErr{}, err)
+		mockMissionRepo.AssertNotCalled(t, "Restore", mock.Anything)
+	})
+
+	t.Run("cat is busy on another mission", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
+		mockMissionRepo := new(MockMissionRepository)
+		mockTargetRepo := new(MockTargetRepository)
+		trashService, _ := newTrashService(mockCatRepo, mockMissionRepo, mockTargetRepo)
+
+		catID := uint(3)
+		mockMissionRepo.On("GetDeletedByIDForUpdate", uint(1)).Return(&models.Mission{ID: 1, CatID: &catID}, nil)
+		mockTargetRepo.On("CountByMissionID", uint(1)).Return(int64(1), nil)
+		mockCatRepo.On("GetByIDForUpdate", catID).Return(&models.Cat{ID: catID}, nil)
+		mockMissionRepo.On("GetActiveByCatID", catID).Return(&models.Mission{ID: 2, CatID: &catID}, nil)
+
+		result, err := trashService.RestoreMission(context.Background(), 1)
+
+		assert.Nil(t, result)
+		assert.IsType(t, custerr.ConflictErr{}, err)
+		mockMissionRepo.AssertNotCalled(t, "Restore", mock.Anything)
+	})
+
+	t.Run("cat is deleted", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
+		mockMissionRepo := new(MockMissionRepository)
+		mockTargetRepo := new(MockTargetRepository)
+		trashService, _ := newTrashService(mockCatRepo, mockMissionRepo, mockTargetRepo)
+
+		catID := uint(3)
+		mockMissionRepo.On("GetDeletedByIDForUpdate", uint(1)).Return(&models.Mission{ID: 1, CatID: &catID}, nil)
+		mockTargetRepo.On("CountByMissionID", uint(1)).Return(int64(1), nil)
+		mockCatRepo.On("GetByIDForUpdate", catID).Return(nil, custerr.NewNotFoundErr("cat not found"))
+
+		result, err := trashService.RestoreMission(context.Background(), 1)
+
+		assert.Nil(t, result)
+		assert.IsType(t, custerr.ConflictErr{}, err)
+		mockMissionRepo.AssertNotCalled(t, "Restore", mock.Anything)
+	})
+}
+
+func TestTrashService_RestoreTarget(t *testing.T) {
+	t.Run("restores target", func(t *testing.T) {
+		mockMissionRepo := new(MockMissionRepository)
+		mockTargetRepo := new(MockTargetRepository)
+		trashService, _ := newTrashService(new(MockCatRepository), mockMissionRepo, mockTargetRepo)
+
+		target := &models.Target{ID: 5, MissionID: 1}
+		mockTargetRepo.On("GetDeletedByID", uint(5)).Return(target, nil)
+		mockMissionRepo.On("GetByIDForUpdate", uint(1)).Return(&models.Mission{ID: 1}, nil)
+		mockTargetRepo.On("CountByMissionID", uint(1)).Return(int64(2), nil)
+		mockTargetRepo.On("Restore", uint(5)).Return(nil)
+		mockTargetRepo.On("GetByID", uint(5)).Return(target, nil)
+
+		result, err := trashService.RestoreTarget(context.Background(), 5)
+
+		assert.NoError(t, err)
+		assert.Equal(t, target, result)
+		mockTargetRepo.AssertExpectations(t)
+	})
+
+	t.Run("mission is deleted", func(t *testing.T) {
+		mockMissionRepo := new(MockMissionRepository)
+		mockTargetRepo := new(MockTargetRepository)
+		trashService, _ := newTrashService(new(MockCatRepository), mockMissionRepo, mockTargetRepo)
+
+		mockTargetRepo.On("GetDeletedByID", uint(5)).Return(&models.Target{ID: 5, MissionID: 1}, nil)
+		mockMissionRepo.On("GetByIDForUpdate", uint(1)).Return(nil, custerr.NewNotFoundErr("mission not found"))
+
+		result, err := trashService.RestoreTarget(context.Background(), 5)
+
+		assert.Nil(t, result)
+		assert.IsType(t, custerr.ConflictErr{}, err)
+		mockTargetRepo.AssertNotCalled(t, "Restore", mock.Anything)
+	})
+
+	t.Run("mission is complete", func(t *testing.T) {
+		mockMissionRepo := new(MockMissionRepository)
+		mockTargetRepo := new(MockTargetRepository)
+		trashService, _ := newTrashService(new(MockCatRepository), mockMissionRepo, mockTargetRepo)
+
+		mockTargetRepo.On("GetDeletedByID", uint(5)).Return(&models.Target{ID: 5, MissionID: 1}, nil)
+		mockMissionRepo.On("GetByIDForUpdate", uint(1)).Return(&models.Mission{ID: 1, Complete: true}, nil)
+
+		result, err := trashService.RestoreTarget(context.Background(), 5)
+
+		assert.Nil(t, result)
+		assert.IsType(t, custerr.ConflictErr{}, err)
+		mockTargetRepo.AssertNotCalled(t, "Restore", mock.Anything)
+	})
+
+	t.Run("mission already has 3 targets", func(t *testing.T) {
+		mockMissionRepo := new(MockMissionRepository)
+		mockTargetRepo := new(MockTargetRepository)
+		trashService, _ := newTrashService(new(MockCatRepository), mockMissionRepo, mockTargetRepo)
+
+		mockTargetRepo.On("GetDeletedByID", uint(5)).Return(&models.Target{ID: 5, MissionID: 1}, nil)
+		mockMissionRepo.On("GetByIDForUpdate", uint(1)).Return(&models.Mission{ID: 1}, nil)
+		mockTargetRepo.On("CountByMissionID", uint(1)).Return(int64(3), nil)
+
+		result, err := trashService.RestoreTarget(context.Background(), 5)
+
+		assert.Nil(t, result)
+		assert.IsType(t, custerr.ConflictErr{}, err)
+		mockTargetRepo.AssertNotCalled(t, "Restore", mock.Anything)
+	})
+}
+
+func TestTrashService_Purge(t *testing.T) {
+	t.Run("purges and audits each entity type", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
+		mockMissionRepo := new(MockMissionRepository)
+		mockTargetRepo := new(MockTargetRepository)
+		trashService, uow := newTrashService(mockCatRepo, mockMissionRepo, mockTargetRepo)
+
+		before := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		mockTargetRepo.On("Purge", before).Return(int64(4), nil)
+		mockMissionRepo.On("Purge", before).Return(int64(2), nil)
+		mockCatRepo.On("Purge", before).Return(int64(0), nil)
+
+		result, err := trashService.Purge(actor.WithName(context.Background(), "cli:root"), before)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &models.PurgeResult{Cats: 0, Missions: 2, Targets: 4}, result)
+		require.Len(t, uow.audit.entries, 2)
+		entry := uow.audit.entries[0]
+		assert.Equal(t, models.AuditActionPurge, entry.Action)
+		assert.Equal(t, models.AuditEntityTarget, entry.EntityType)
+		assert.Equal(t, uint(0), entry.EntityID)
+		assert.Equal(t, "cli:root", entry.Actor)
+		assert.JSONEq(t, `{"deleted_before":"2024-01-01T00:00:00Z","count":4}`, string(entry.Before))
+		assert.Nil(t, entry.After)
+		assert.Equal(t, models.AuditEntityMission, uow.audit.entries[1].EntityType)
+	})
+
+	t.Run("audit failure rolls the purge back", func(t *testing.T) {
+		mockCatRepo := new(MockCatRepository)
+		mockMissionRepo := new(MockMissionRepository)
+		mockTargetRepo := new(MockTargetRepository)
+		trashService, uow := newTrashService(mockCatRepo, mockMissionRepo, mockTargetRepo)
+		uow.audit.err = errors.New("audit write failed")
+
+		before := time.Now().Add(-24 * time.Hour)
+		mockTargetRepo.On("Purge", before).Return(int64(1), nil)
+		mockMissionRepo.On("Purge", before).Return(int64(0), nil)
+		mockCatRepo.On("Purge", before).Return(int64(0), nil)
+
+		result, err := trashService.Purge(context.Background(), before)
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "audit write failed")
+	})
+}
@@ -0,0 +1,315 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/pkg/catapi"
+	"spy-cat-agency/pkg/custerr"
+	"strings"
+	"time"
+)
+
+// TransferService imports and exports cats and missions in bulk. Imports
+// validate every row before anything is written and then insert all rows in
+// a single transaction.
+type TransferService struct {
+	catRepo      CatRepository
+	missionRepo  MissionRepository
+	uow          UnitOfWork
+	catValidator catapi.CatValidator
+}
+
+func NewTransferService(catRepo CatRepository, missionRepo MissionRepository, uow UnitOfWork, catValidator catapi.CatValidator) *TransferService {
+	return &TransferService{
+		catRepo:      catRepo,
+		missionRepo:  missionRepo,
+		uow:          uow,
+		catValidator: catValidator,
+	}
+}
+
+func (s *TransferService) ImportCats(ctx context.Context, format models.TransferFormat, r io.Reader) (*models.ImportResult, error) {
+	rows, err := decodeCats(format, r)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkImportSize(len(rows)); err != nil {
+		return nil, err
+	}
+
+	var rowErrs []models.ImportRowError
+	cats := make([]*models.Cat, 0, len(rows))
+	for _, row := range rows {
+		errs := row.errs
+		if len(errs) == 0 {
+			cat, catErrs, err := s.validateCat(row)
+			if err != nil {
+				return nil, err
+			}
+			errs = catErrs
+			cats = append(cats, cat)
+		}
+		rowErrs = append(rowErrs, errs...)
+	}
+	if len(rowErrs) > 0 {
+		return nil, invalidRowsErr(rowErrs)
+	}
+
+	result := &models.ImportResult{IDs: make([]uint, 0, len(cats))}
+	err = s.uow.Do(func(repos Repositories) error {
+		for _, cat := range cats {
+			if err := repos.Cat.Create(cat); err != nil {
+				return err
+			}
+			if err := recordSalary(ctx, repos.Cat, cat.ID, cat.Salary, time.Now()); err != nil {
+				return err
+			}
+			if err := recordAudit(ctx, repos.Audit, models.AuditActionCreate, models.AuditEntityCat, cat.ID, nil, catSnapshot(cat)); err != nil {
+				return err
+			}
+			result.IDs = append(result.IDs, cat.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result.Created = len(result.IDs)
+	return result, nil
+}
+
+// validateCat applies the rules of creating a single cat and resolves its
+// breed to the canonical TheCatAPI name.
+func (s *TransferService) validateCat(row catRow) (*models.Cat, []models.ImportRowError, error) {
+	var errs []models.ImportRowError
+	dto := row.dto
+
+	dto.Name = strings.TrimSpace(dto.Name)
+	if dto.Name == "" {
+		errs = append(errs, rowErr(row.row, "name", "name is required"))
+	}
+	if dto.YearsExperience < 0 {
+		errs = append(errs, rowErr(row.row, "years_experience", "years_experience must be at least 0"))
+	}
+	if dto.Salary < 0 {
+		errs = append(errs, rowErr(row.row, "salary", "salary must be at least 0"))
+	}
+
+	cat := &models.Cat{CreateCatDTO: dto}
+	if strings.TrimSpace(dto.Breed) == "" {
+		errs = append(errs, rowErr(row.row, "breed", "breed is required"))
+		return cat, errs, nil
+	}
+
+	breed, err := s.catValidator.ValidateBreed(dto.Breed)
+	if err != nil {
+		return nil, nil, err
+	}
+	if breed == nil {
+		message := fmt.Sprintf("invalid cat breed %q", dto.Breed)
+		suggestions, err := s.catValidator.SuggestBreeds(dto.Breed)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(suggestions) > 0 {
+			names := make([]string, len(suggestions))
+			for i, suggestion := range suggestions {
+				names[i] = suggestion.Name
+			}
+			message += ", did you mean " + strings.Join(names, ", ")
+		}
+		errs = append(errs, rowErr(row.row, "breed", message))
+		return cat, errs, nil
+	}
+
+	cat.Breed = breed.Name
+	cat.BreedID = breed.ID
+	return cat, errs, nil
+}
+
+func (s *TransferService) ImportMissions(ctx context.Context, format models.TransferFormat, r io.Reader) (*models.ImportResult, error) {
+	rows, err := decodeMissions(format, r)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkImportSize(len(rows)); err != nil {
+		return nil, err
+	}
+
+	var rowErrs []models.ImportRowError
+	claimedBy := map[uint]int{}
+	for _, row := range rows {
+		errs := row.errs
+		if len(errs) == 0 {
+			missionErrs, err := s.validateMission(row, claimedBy)
+			if err != nil {
+				return nil, err
+			}
+			errs = missionErrs
+		}
+		rowErrs = append(rowErrs, errs...)
+	}
+	if len(rowErrs) > 0 {
+		return nil, invalidRowsErr(rowErrs)
+	}
+
+	result := &models.ImportResult{IDs: make([]uint, 0, len(rows))}
+	err = s.uow.Do(func(repos Repositories) error {
+		for _, row := range rows {
+			mission, err := createMission(ctx, repos, row.record.Targets)
+			if err != nil {
+				return err
+			}
+
+			if catID := row.record.CatID; catID != nil {
+				// the cat may have been taken since it was validated
+				if err := claimCat(repos, *catID); err != nil {
+					return err
+				}
+				if err := changeAssignment(ctx, repos, mission, catID, "imported"); err != nil {
+					return err
+				}
+			}
+			result.IDs = append(result.IDs, mission.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result.Created = len(result.IDs)
+	return result, nil
+}
+
+// validateMission applies the rules of creating and assigning a single
+// mission. claimedBy tracks the rows cats were assigned to so far, as a
+// cat can only take one of the imported missions.
+func (s *TransferService) validateMission(row missionRow, claimedBy map[uint]int) ([]models.ImportRowError, error) {
+	var errs []models.ImportRowError
+	record := row.record
+
+	if len(record.Targets) < 1 || len(record.Targets) > 3 {
+		errs = append(errs, rowErr(row.row, "targets", fmt.Sprintf("missions must have 1-3 targets, got %d", len(record.Targets))))
+	}
+	for i, target := range record.Targets {
+		if strings.TrimSpace(target.Name) == "" {
+			errs = append(errs, rowErr(row.targetRows[i], fmt.Sprintf("targets[%d].name", i), "target name is required"))
+		}
+		if strings.TrimSpace(target.Country) == "" {
+			errs = append(errs, rowErr(row.targetRows[i], fmt.Sprintf("targets[%d].country", i), "target country is required"))
+		}
+	}
+
+	if record.CatID == nil {
+		return errs, nil
+	}
+	catID := *record.CatID
+
+	if other, ok := claimedBy[catID]; ok {
+		return append(errs, rowErr(row.row, "cat_id", fmt.Sprintf("cat \"%d\" is already assigned to the mission in row %d", catID, other))), nil
+	}
+	claimedBy[catID] = row.row
+
+	if _, err := s.catRepo.GetByID(catID); err != nil {
+		if errors.As(err, new(custerr.NotFoundErr)) {
+			return append(errs, rowErr(row.row, "cat_id", err.Error())), nil
+		}
+		return nil, err
+	}
+
+	activeMission, err := s.missionRepo.GetActiveByCatID(catID)
+	if err != nil {
+		return nil, err
+	}
+	if activeMission != nil {
+		errs = append(errs, rowErr(row.row, "cat_id", fmt.Sprintf("cat \"%d\" already has an active mission", catID)))
+	}
+
+	return errs, nil
+}
+
+func (s *TransferService) ExportCats(ctx context.Context, filter models.CatExportFilter) ([]byte, error) {
+	cats, err := allPages(func(query models.PageQuery) ([]models.Cat, int64, error) {
+		return s.catRepo.GetAll(models.CatFilter{
+			PageQuery:        query,
+			Breed:            filter.Breed,
+			HasActiveMission: filter.HasActiveMission,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]models.CreateCatDTO, len(cats))
+	for i, cat := range cats {
+		dtos[i] = cat.CreateCatDTO
+	}
+	return encodeCats(filter.Format, dtos)
+}
+
+func (s *TransferService) ExportMissions(ctx context.Context, filter models.MissionExportFilter) ([]byte, error) {
+	missions, err := allPages(func(query models.PageQuery) ([]models.Mission, int64, error) {
+		return s.missionRepo.GetAll(models.MissionFilter{
+			PageQuery: query,
+			Complete:  filter.Complete,
+			CatID:     filter.CatID,
+			Country:   filter.Country,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]models.MissionRecord, len(missions))
+	for i, mission := range missions {
+		records[i] = models.MissionRecord{
+			Targets: make([]models.CreateTargetDTO, len(mission.Targets)),
+		}
+		// imported missions are open, so a completed one must not take its
+		// cat away from whatever the cat does now
+		if !mission.Complete {
+			records[i].CatID = mission.CatID
+		}
+		for j, target := range mission.Targets {
+			records[i].Targets[j] = models.CreateTargetDTO{Name: target.Name, Country: target.Country, Notes: target.Notes}
+		}
+	}
+	return encodeMissions(filter.Format, records)
+}
+
+// allPages fetches every page of a listing in id order.
+func allPages[T any](fetch func(query models.PageQuery) ([]T, int64, error)) ([]T, error) {
+	all := []T{}
+	for page := 1; ; page++ {
+		items, total, err := fetch(models.PageQuery{Page: page, PerPage: models.MaxPerPage, Sort: "id"})
+		if err != nil {
+			return nil, err
+		}
+
+		all = append(all, items...)
+		if len(items) < models.MaxPerPage || int64(len(all)) >= total {
+			return all, nil
+		}
+	}
+}
+
+func checkImportSize(rows int) error {
+	if rows == 0 {
+		return custerr.NewBadRequestErr("import has no rows").WithCode(models.ErrCodeInvalidImport)
+	}
+	if rows > models.MaxImportRows {
+		return custerr.NewBadRequestErr(fmt.Sprintf("import has %d rows, at most %d are allowed at once", rows, models.MaxImportRows)).WithCode(models.ErrCodeInvalidImport)
+	}
+	return nil
+}
+
+func invalidRowsErr(errs []models.ImportRowError) error {
+	return custerr.NewBadRequestErrWithDetails("import has invalid rows, nothing was imported", map[string]any{
+		"errors": errs,
+	}).WithCode(models.ErrCodeInvalidImport)
+}
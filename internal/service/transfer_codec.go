@@ -0,0 +1,313 @@
+package service
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/pkg/custerr"
+	"strconv"
+	"strings"
+)
+
+var (
+	catCSVHeader     = []string{"name", "years_experience", "breed", "salary"}
+	missionCSVHeader = []string{"mission", "cat_id", "target_name", "target_country", "target_notes"}
+)
+
+// catRow is a decoded cat along with where it came from and anything that
+// already failed while parsing it.
+type catRow struct {
+	row  int
+	dto  models.CreateCatDTO
+	errs []models.ImportRowError
+}
+
+// missionRow is a decoded mission. In CSV files every target is its own
+// line, so targetRows records the line of each target.
+type missionRow struct {
+	row        int
+	targetRows []int
+	record     models.MissionRecord
+	errs       []models.ImportRowError
+}
+
+func decodeCats(format models.TransferFormat, r io.Reader) ([]catRow, error) {
+	if format == models.TransferFormatCSV {
+		return decodeCatsCSV(r)
+	}
+
+	var dtos []models.CreateCatDTO
+	if err := decodeJSON(r, &dtos); err != nil {
+		return nil, err
+	}
+
+	rows := make([]catRow, len(dtos))
+	for i, dto := range dtos {
+		rows[i] = catRow{row: i + 1, dto: dto}
+	}
+	return rows, nil
+}
+
+func decodeCatsCSV(r io.Reader) ([]catRow, error) {
+	reader, columns, err := readCSVHeader(r, catCSVHeader, catCSVHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []catRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, csvErr(err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := catRow{row: line}
+		row.dto.Name = record[columns["name"]]
+		row.dto.Breed = record[columns["breed"]]
+
+		value := strings.TrimSpace(record[columns["years_experience"]])
+		if row.dto.YearsExperience, err = strconv.Atoi(value); err != nil {
+			row.errs = append(row.errs, rowErr(line, "years_experience", "years_experience must be a whole number"))
+		}
+		value = strings.TrimSpace(record[columns["salary"]])
+		if row.dto.Salary, err = strconv.ParseFloat(value, 64); err != nil {
+			row.errs = append(row.errs, rowErr(line, "salary", "salary must be a number"))
+		}
+
+		rows = append(rows, row)
+	}
+}
+
+func decodeMissions(format models.TransferFormat, r io.Reader) ([]missionRow, error) {
+	if format == models.TransferFormatCSV {
+		return decodeMissionsCSV(r)
+	}
+
+	var records []models.MissionRecord
+	if err := decodeJSON(r, &records); err != nil {
+		return nil, err
+	}
+
+	rows := make([]missionRow, len(records))
+	for i, record := range records {
+		targetRows := make([]int, len(record.Targets))
+		for j := range targetRows {
+			targetRows[j] = i + 1
+		}
+		rows[i] = missionRow{row: i + 1, targetRows: targetRows, record: record}
+	}
+	return rows, nil
+}
+
+// decodeMissionsCSV groups target lines into missions by their mission
+// column, which only has to be unique within the file.
+func decodeMissionsCSV(r io.Reader) ([]missionRow, error) {
+	reader, columns, err := readCSVHeader(r, missionCSVHeader, missionCSVHeader[:4])
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []*missionRow
+	byKey := map[string]*missionRow{}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, csvErr(err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		key := strings.TrimSpace(record[columns["mission"]])
+		if key == "" {
+			rows = append(rows, &missionRow{row: line, errs: []models.ImportRowError{rowErr(line, "mission", "mission is required")}})
+			continue
+		}
+
+		row, ok := byKey[key]
+		if !ok {
+			row = &missionRow{row: line}
+			byKey[key] = row
+			rows = append(rows, row)
+		}
+
+		var catID *uint
+		if value := strings.TrimSpace(record[columns["cat_id"]]); value != "" {
+			id, err := strconv.ParseUint(value, 10, 32)
+			if err != nil || id == 0 {
+				row.errs = append(row.errs, rowErr(line, "cat_id", "cat_id must be a positive whole number"))
+			} else {
+				catID = new(uint)
+				*catID = uint(id)
+			}
+		}
+		switch {
+		case !ok:
+			row.record.CatID = catID
+		case !sameCatID(row.record.CatID, catID):
+			row.errs = append(row.errs, rowErr(line, "cat_id", fmt.Sprintf("cat_id differs from the first row of mission %q", key)))
+		}
+
+		target := models.CreateTargetDTO{
+			Name:    record[columns["target_name"]],
+			Country: record[columns["target_country"]],
+		}
+		if column, ok := columns["target_notes"]; ok {
+			target.Notes = record[column]
+		}
+		// a line with empty target columns stands for a mission without targets
+		if target == (models.CreateTargetDTO{}) {
+			continue
+		}
+		row.record.Targets = append(row.record.Targets, target)
+		row.targetRows = append(row.targetRows, line)
+	}
+
+	result := make([]missionRow, len(rows))
+	for i, row := range rows {
+		result[i] = *row
+	}
+	return result, nil
+}
+
+func sameCatID(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// readCSVHeader reads the header line and maps each known column to its
+// index, so columns may come in any order and optional ones may be left out.
+func readCSVHeader(r io.Reader, known, required []string) (*csv.Reader, map[string]int, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil, custerr.NewBadRequestErr("import has no rows").WithCode(models.ErrCodeInvalidImport)
+	}
+	if err != nil {
+		return nil, nil, csvErr(err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !slices.Contains(known, name) {
+			return nil, nil, custerr.NewBadRequestErr(fmt.Sprintf("unknown CSV column %q, expected %s", name, strings.Join(known, ", "))).WithCode(models.ErrCodeInvalidImport)
+		}
+		if _, ok := columns[name]; ok {
+			return nil, nil, custerr.NewBadRequestErr(fmt.Sprintf("duplicate CSV column %q", name)).WithCode(models.ErrCodeInvalidImport)
+		}
+		columns[name] = i
+	}
+	for _, name := range required {
+		if _, ok := columns[name]; !ok {
+			return nil, nil, custerr.NewBadRequestErr(fmt.Sprintf("missing CSV column %q", name)).WithCode(models.ErrCodeInvalidImport)
+		}
+	}
+
+	return reader, columns, nil
+}
+
+func decodeJSON(r io.Reader, v any) error {
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		if errors.Is(err, io.EOF) {
+			return custerr.NewBadRequestErr("import has no rows").WithCode(models.ErrCodeInvalidImport)
+		}
+		if tooLarge(err) {
+			return err
+		}
+		return custerr.NewBadRequestErr(fmt.Sprintf("invalid JSON: %v", err)).WithCode(models.ErrCodeInvalidImport)
+	}
+	return nil
+}
+
+func csvErr(err error) error {
+	if tooLarge(err) {
+		return err
+	}
+	return custerr.NewBadRequestErr(fmt.Sprintf("invalid CSV: %v", err)).WithCode(models.ErrCodeInvalidImport)
+}
+
+func rowErr(row int, field, message string) models.ImportRowError {
+	return models.ImportRowError{Row: row, Field: field, Message: message}
+}
+
+func encodeCats(format models.TransferFormat, cats []models.CreateCatDTO) ([]byte, error) {
+	if format != models.TransferFormatCSV {
+		return encodeJSON(cats)
+	}
+
+	records := [][]string{catCSVHeader}
+	for _, cat := range cats {
+		records = append(records, []string{
+			cat.Name,
+			strconv.Itoa(cat.YearsExperience),
+			cat.Breed,
+			strconv.FormatFloat(cat.Salary, 'f', -1, 64),
+		})
+	}
+	return encodeCSV(records)
+}
+
+// encodeMissions numbers missions from 1 in CSV files, one line per target.
+// A mission without targets gets a single line with empty target columns, so
+// it is not lost from the file.
+func encodeMissions(format models.TransferFormat, missions []models.MissionRecord) ([]byte, error) {
+	if format != models.TransferFormatCSV {
+		return encodeJSON(missions)
+	}
+
+	records := [][]string{missionCSVHeader}
+	for i, mission := range missions {
+		catID := ""
+		if mission.CatID != nil {
+			catID = strconv.FormatUint(uint64(*mission.CatID), 10)
+		}
+		for _, target := range mission.Targets {
+			records = append(records, []string{strconv.Itoa(i + 1), catID, target.Name, target.Country, target.Notes})
+		}
+		if len(mission.Targets) == 0 {
+			records = append(records, []string{strconv.Itoa(i + 1), catID, "", "", ""})
+		}
+	}
+	return encodeCSV(records)
+}
+
+func encodeJSON(v any) ([]byte, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, custerr.NewInternalErr(err)
+	}
+	return data, nil
+}
+
+func encodeCSV(records [][]string) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := csv.NewWriter(&buf).WriteAll(records); err != nil {
+		return nil, custerr.NewInternalErr(err)
+	}
+	return buf.Bytes(), nil
+}
+
+// tooLarge reports a body cut off by http.MaxBytesReader, which is left for
+// the error handler to answer with 413.
+func tooLarge(err error) bool {
+	var maxBytesErr *http.MaxBytesError
+	return errors.As(err, &maxBytesErr)
+}
@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/pkg/custerr"
+	"time"
+)
+
+// TrashService lists, restores and purges soft deleted cats, missions and
+// targets. Restoring re-checks the invariants the record would otherwise
+// bypass on its way back.
+type TrashService struct {
+	catRepo     CatRepository
+	missionRepo MissionRepository
+	targetRepo  TargetRepository
+	uow         UnitOfWork
+}
+
+func NewTrashService(catRepo CatRepository, missionRepo MissionRepository, targetRepo TargetRepository, uow UnitOfWork) *TrashService {
+	return &TrashService{
+		catRepo:     catRepo,
+		missionRepo: missionRepo,
+		targetRepo:  targetRepo,
+		uow:         uow,
+	}
+}
+
+func (s *TrashService) GetDeletedCats(ctx context.Context, query models.PageQuery) (*models.Page[models.Cat], error) {
+	cats, total, err := s.catRepo.GetDeleted(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.NewPage(cats, query, total), nil
+}
+
+func (s *TrashService) GetDeletedMissions(ctx context.Context, query models.PageQuery) (*models.Page[models.Mission], error) {
+	missions, total, err := s.missionRepo.GetDeleted(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.NewPage(missions, query, total), nil
+}
+
+func (s *TrashService) GetDeletedTargets(ctx context.Context, query models.PageQuery) (*models.Page[models.Target], error) {
+	targets, total, err := s.targetRepo.GetDeleted(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.NewPage(targets, query, total), nil
+}
+
+func (s *TrashService) RestoreCat(ctx context.Context, id uint) (*models.Cat, error) {
+	err := s.uow.Do(func(repos Repositories) error {
+		cat, err := repos.Cat.GetDeletedByID(id)
+		if err != nil {
+			return err
+		}
+
+		if err := repos.Cat.Restore(id); err != nil {
+			return err
+		}
+		return recordAudit(ctx, repos.Audit, models.AuditActionRestore, models.AuditEntityCat, id, nil, catSnapshot(cat))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.catRepo.GetByID(id)
+}
+
+func (s *TrashService) RestoreMission(ctx context.Context, id uint) (*models.Mission, error) {
+	err := s.uow.Do(func(repos Repositories) error {
+		// locked like in RestoreTarget, so the target count stays valid
+		mission, err := repos.Mission.GetDeletedByIDForUpdate(id)
+		if err != nil {
+			return err
+		}
+
+		count, err := repos.Target.CountByMissionID(id)
+		if err != nil {
+			return err
+		}
+		if count < 1 || count > 3 {
+			return custerr.NewConflictErr(fmt.Sprintf("cannot restore mission with %d targets, missions must have 1-3 targets", count)).WithCode(models.ErrCodeMissionTargetLimit)
+		}
+
+		// an open mission takes its cat back, which must still be free
+		if mission.CatID != nil && !mission.Complete {
+			err := claimCat(repos, *mission.CatID)
+			if errors.As(err, new(custerr.NotFoundErr)) {
+				return custerr.NewConflictErr(fmt.Sprintf("cannot restore mission while its cat \"%d\" is deleted", *mission.CatID)).WithCode(models.ErrCodeParentDeleted)
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := repos.Mission.Restore(id); err != nil {
+			return err
+		}
+		return recordAudit(ctx, repos.Audit, models.AuditActionRestore, models.AuditEntityMission, id, nil, missionSnapshot(mission))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.missionRepo.GetByID(id)
+}
+
+func (s *TrashService) RestoreTarget(ctx context.Context, id uint) (*models.Target, error) {
+	err := s.uow.Do(func(repos Repositories) error {
+		target, err := repos.Target.GetDeletedByID(id)
+		if err != nil {
+			return err
+		}
+
+		mission, err := repos.Mission.GetByIDForUpdate(target.MissionID)
+		if errors.As(err, new(custerr.NotFoundErr)) {
+			return custerr.NewConflictErr(fmt.Sprintf("cannot restore target while its mission \"%d\" is deleted", target.MissionID)).WithCode(models.ErrCodeParentDeleted)
+		}
+		if err != nil {
+			return err
+		}
+
+		if mission.Complete {
+			return custerr.NewConflictErr("cannot restore target of completed mission").WithCode(models.ErrCodeMissionCompleted)
+		}
+
+		count, err := repos.Target.CountByMissionID(mission.ID)
+		if err != nil {
+			return err
+		}
+		if count >= 3 {
+			return custerr.NewConflictErr("mission cannot have more than 3 targets").WithCode(models.ErrCodeMissionTargetLimit)
+		}
+
+		if err := repos.Target.Restore(id); err != nil {
+			return err
+		}
+		return recordAudit(ctx, repos.Audit, models.AuditActionRestore, models.AuditEntityTarget, id, nil, targetSnapshot(target))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.targetRepo.GetByID(id)
+}
+
+// purgeState is the audited state of a purge, which removes rows in bulk.
+type purgeState struct {
+	DeletedBefore time.Time `json:"deleted_before"`
+	Count         int64     `json:"count"`
+}
+
+// Purge permanently removes everything deleted before the given time.
+// Targets go first, as purging a mission takes its targets with it.
+func (s *TrashService) Purge(ctx context.Context, before time.Time) (*models.PurgeResult, error) {
+	var result models.PurgeResult
+
+	err := s.uow.Do(func(repos Repositories) error {
+		var err error
+		if result.Targets, err = repos.Target.Purge(before); err != nil {
+			return err
+		}
+		if result.Missions, err = repos.Mission.Purge(before); err != nil {
+			return err
+		}
+		if result.Cats, err = repos.Cat.Purge(before); err != nil {
+			return err
+		}
+
+		purged := []struct {
+			entityType string
+			count      int64
+		}{
+			{models.AuditEntityTarget, result.Targets},
+			{models.AuditEntityMission, result.Missions},
+			{models.AuditEntityCat, result.Cats},
+		}
+		for _, p := range purged {
+			if p.count == 0 {
+				continue
+			}
+			state := purgeState{DeletedBefore: before, Count: p.count}
+			if err := recordAudit(ctx, repos.Audit, models.AuditActionPurge, p.entityType, 0, state, nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
@@ -21,6 +21,16 @@ import (
 //	@host		localhost:8080
 //	@BasePath	/api/v1
 
+//	@securityDefinitions.apikey	BearerAuth
+//	@in							header
+//	@name						Authorization
+//	@description				JWT signed with JWT_SECRET, sent as "Bearer <token>"
+
+//	@securityDefinitions.apikey	ApiKeyAuth
+//	@in							header
+//	@name						X-API-Key
+//	@description				Service account key from API_KEYS
+
 func main() {
 	if err := cmd.Execute(); err != nil {
 		log.Fatal(err)
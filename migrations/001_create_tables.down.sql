@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS targets;
+DROP TABLE IF EXISTS missions;
+DROP TABLE IF EXISTS cats;
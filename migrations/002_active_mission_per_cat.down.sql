@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_missions_active_cat_id;
@@ -0,0 +1,2 @@
+-- A cat can only have one active (incomplete) mission at a time
+CREATE UNIQUE INDEX IF NOT EXISTS idx_missions_active_cat_id ON missions(cat_id) WHERE complete = false AND deleted_at IS NULL;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_cats_breed_id;
+
+ALTER TABLE cats DROP COLUMN IF EXISTS breed_id;
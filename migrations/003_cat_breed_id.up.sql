@@ -0,0 +1,4 @@
+-- Canonical TheCatAPI breed id, cats.breed holds the matching display name
+ALTER TABLE cats ADD COLUMN IF NOT EXISTS breed_id VARCHAR(255);
+
+CREATE INDEX IF NOT EXISTS idx_cats_breed_id ON cats(breed_id);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS target_transitions;
+
+ALTER TABLE targets DROP COLUMN IF EXISTS status;
@@ -0,0 +1,16 @@
+-- Target lifecycle status, complete is kept in sync with terminal statuses
+ALTER TABLE targets ADD COLUMN IF NOT EXISTS status VARCHAR(32) NOT NULL DEFAULT 'pending'
+    CHECK (status IN ('pending', 'under_surveillance', 'compromised', 'escaped', 'neutralized'));
+
+UPDATE targets SET status = 'neutralized' WHERE complete = true AND status = 'pending';
+
+-- Create target status transitions table
+CREATE TABLE IF NOT EXISTS target_transitions (
+    id SERIAL PRIMARY KEY,
+    target_id INTEGER NOT NULL REFERENCES targets(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    from_status VARCHAR(32) NOT NULL,
+    to_status VARCHAR(32) NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_target_transitions_target_id ON target_transitions(target_id);
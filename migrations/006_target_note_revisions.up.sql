@@ -0,0 +1,15 @@
+-- Create target note revisions table
+CREATE TABLE IF NOT EXISTS target_note_revisions (
+    id SERIAL PRIMARY KEY,
+    target_id INTEGER NOT NULL REFERENCES targets(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    author VARCHAR(255) NOT NULL,
+    notes TEXT,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_target_note_revisions_target_id ON target_note_revisions(target_id);
+
+-- Notes written before revisions existed become the first revision
+INSERT INTO target_note_revisions (target_id, author, notes, created_at)
+SELECT t.id, 'anonymous', t.notes, t.updated_at FROM targets t
+WHERE t.notes <> '' AND NOT EXISTS (SELECT 1 FROM target_note_revisions r WHERE r.target_id = t.id);
@@ -0,0 +1,18 @@
+-- Create mission assignments table, cat ids are kept without foreign keys
+-- so the history survives cats being removed
+CREATE TABLE IF NOT EXISTS mission_assignments (
+    id SERIAL PRIMARY KEY,
+    mission_id INTEGER NOT NULL REFERENCES missions(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    from_cat_id INTEGER,
+    to_cat_id INTEGER,
+    reason TEXT,
+    actor VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_mission_assignments_mission_id ON mission_assignments(mission_id);
+
+-- Cats assigned before assignments were recorded get their assignment
+INSERT INTO mission_assignments (mission_id, to_cat_id, reason, actor, created_at)
+SELECT m.id, m.cat_id, '', 'anonymous', m.updated_at FROM missions m
+WHERE m.cat_id IS NOT NULL AND NOT EXISTS (SELECT 1 FROM mission_assignments a WHERE a.mission_id = m.id);
@@ -0,0 +1,16 @@
+-- Create cat salaries table, every salary a cat had and the date it took effect
+CREATE TABLE IF NOT EXISTS cat_salaries (
+    id SERIAL PRIMARY KEY,
+    cat_id INTEGER NOT NULL REFERENCES cats(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    salary DECIMAL(10,2) NOT NULL CHECK (salary >= 0),
+    effective_from TIMESTAMP NOT NULL,
+    actor VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_cat_salaries_cat_id_effective_from ON cat_salaries(cat_id, effective_from);
+
+-- Current salaries become the first entry, effective since the cat was created
+INSERT INTO cat_salaries (cat_id, salary, effective_from, actor, created_at)
+SELECT c.id, c.salary, c.created_at, 'anonymous', NOW() FROM cats c
+WHERE NOT EXISTS (SELECT 1 FROM cat_salaries s WHERE s.cat_id = c.id);
@@ -0,0 +1,8 @@
+// Package migrations embeds the numbered SQL migrations, so the binary
+// carries the schema it expects.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,235 @@
+package catapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultBaseURL = "https://api.thecatapi.com/v1"
+	DefaultTTL     = time.Hour
+	defaultTimeout = 10 * time.Second
+)
+
+var ErrCatalogUnavailable = errors.New("breed catalog is unavailable")
+
+type CatalogConfig struct {
+	// BaseURL of TheCatAPI, without the trailing /breeds.
+	BaseURL string
+	// TTL is how long a downloaded breed list is served before refetching.
+	TTL time.Duration
+	// SnapshotPath is where the last good breed list is persisted. The
+	// snapshot is served when the upstream API is unreachable. Empty
+	// disables persistence.
+	SnapshotPath string
+	Timeout      time.Duration
+}
+
+// Catalog is an in-memory, TTL cached copy of TheCatAPI breed list backed by
+// an on-disk snapshot.
+type Catalog struct {
+	client       *http.Client
+	baseURL      string
+	ttl          time.Duration
+	snapshotPath string
+
+	refreshMu sync.Mutex
+
+	mu        sync.RWMutex
+	breeds    []CatAPIBreed
+	fetchedAt time.Time
+}
+
+type snapshot struct {
+	FetchedAt time.Time     `json:"fetched_at"`
+	Breeds    []CatAPIBreed `json:"breeds"`
+}
+
+func NewCatalog(cfg CatalogConfig) *Catalog {
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = DefaultBaseURL
+	}
+	if cfg.TTL <= 0 {
+		cfg.TTL = DefaultTTL
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+
+	c := &Catalog{
+		client:       &http.Client{Timeout: cfg.Timeout},
+		baseURL:      strings.TrimRight(cfg.BaseURL, "/"),
+		ttl:          cfg.TTL,
+		snapshotPath: cfg.SnapshotPath,
+	}
+
+	if snap, err := c.loadSnapshot(); err == nil {
+		c.breeds, c.fetchedAt = snap.Breeds, snap.FetchedAt
+	}
+
+	return c
+}
+
+// Breeds returns the cached breed list, refetching it when the TTL has
+// expired. If the upstream API fails, the last known list is returned
+// instead, so only a catalog that never had any data reports an error.
+func (c *Catalog) Breeds() ([]CatAPIBreed, error) {
+	if breeds, fresh := c.cached(); fresh {
+		return breeds, nil
+	}
+
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+
+	// another caller may have refreshed while we waited for the lock
+	if breeds, fresh := c.cached(); fresh {
+		return breeds, nil
+	}
+
+	if err := c.refresh(); err != nil {
+		if breeds, _ := c.cached(); breeds != nil {
+			log.Printf("catapi: serving stale breed catalog: %v", err)
+			return breeds, nil
+		}
+		return nil, errors.Join(ErrCatalogUnavailable, err)
+	}
+
+	breeds, _ := c.cached()
+	return breeds, nil
+}
+
+// Refresh unconditionally refetches the breed list from the upstream API.
+func (c *Catalog) Refresh() error {
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+
+	return c.refresh()
+}
+
+// Run refreshes the catalog immediately and then every interval until ctx is
+// done. Failures are logged and the previous data is kept.
+func (c *Catalog) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = c.ttl
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.Refresh(); err != nil {
+			log.Printf("catapi: breed catalog refresh failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *Catalog) cached() ([]CatAPIBreed, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.breeds, c.breeds != nil && time.Since(c.fetchedAt) < c.ttl
+}
+
+func (c *Catalog) refresh() error {
+	breeds, err := c.fetch()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	c.mu.Lock()
+	c.breeds, c.fetchedAt = breeds, now
+	c.mu.Unlock()
+
+	if err := c.saveSnapshot(snapshot{FetchedAt: now, Breeds: breeds}); err != nil {
+		log.Printf("catapi: failed to save breed snapshot: %v", err)
+	}
+	return nil
+}
+
+func (c *Catalog) fetch() ([]CatAPIBreed, error) {
+	req, err := http.NewRequest("GET", c.baseURL+"/breeds", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("cat API returned status %d", resp.StatusCode)
+	}
+
+	var breeds []CatAPIBreed
+	if err := json.NewDecoder(resp.Body).Decode(&breeds); err != nil {
+		return nil, err
+	}
+	if breeds == nil {
+		breeds = []CatAPIBreed{}
+	}
+
+	return breeds, nil
+}
+
+func (c *Catalog) loadSnapshot() (*snapshot, error) {
+	if c.snapshotPath == "" {
+		return nil, os.ErrNotExist
+	}
+
+	data, err := os.ReadFile(c.snapshotPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var snap snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, err
+	}
+	if snap.Breeds == nil {
+		return nil, fmt.Errorf("breed snapshot %s is empty", c.snapshotPath)
+	}
+
+	return &snap, nil
+}
+
+// saveSnapshot writes to a temporary file first so a crash mid-write never
+// leaves a truncated snapshot behind.
+func (c *Catalog) saveSnapshot(snap snapshot) error {
+	if c.snapshotPath == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.snapshotPath), 0o755); err != nil {
+		return err
+	}
+
+	tmp := c.snapshotPath + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, c.snapshotPath)
+}
@@ -1,62 +1,61 @@
 package catapi
 
 import (
-	"encoding/json"
-	"fmt"
-	"net/http"
 	"strings"
-	"time"
 )
 
 type CatValidator interface {
-	ValidateBreed(breed string) (bool, error)
+	// ValidateBreed looks the breed up by name or id, case-insensitively,
+	// and returns the canonical catalog entry or nil if there is no match.
+	ValidateBreed(breed string) (*CatAPIBreed, error)
+	// SuggestBreeds returns the catalog breeds closest to a breed that
+	// failed validation.
+	SuggestBreeds(breed string) ([]BreedSuggestion, error)
 }
 
 type catValidator struct {
-	client *http.Client
+	catalog *Catalog
 }
 
 type CatAPIBreed struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	AltNames     string `json:"alt_names,omitempty"`
+	Origin       string `json:"origin,omitempty"`
+	CountryCode  string `json:"country_code,omitempty"`
+	Temperament  string `json:"temperament,omitempty"`
+	Description  string `json:"description,omitempty"`
+	LifeSpan     string `json:"life_span,omitempty"`
+	WikipediaURL string `json:"wikipedia_url,omitempty"`
 }
 
-func NewCatValidator() CatValidator {
+func NewCatValidator(catalog *Catalog) CatValidator {
 	return &catValidator{
-		client: &http.Client{
-			Timeout: 10 * time.Second,
-		},
+		catalog: catalog,
 	}
 }
 
-func (v *catValidator) ValidateBreed(breed string) (bool, error) {
-	url := "https://api.thecatapi.com/v1/breeds"
-	req, err := http.NewRequest("GET", url, nil)
+func (v *catValidator) ValidateBreed(breed string) (*CatAPIBreed, error) {
+	breeds, err := v.catalog.Breeds()
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
-	resp, err := v.client.Do(req)
-	if err != nil {
-		return false, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return false, fmt.Errorf("cat API returned status %d", resp.StatusCode)
+	breed = strings.TrimSpace(breed)
+	for _, b := range breeds {
+		if strings.EqualFold(b.Name, breed) || strings.EqualFold(b.ID, breed) {
+			return &b, nil
+		}
 	}
 
-	var breeds []CatAPIBreed
-	if err := json.NewDecoder(resp.Body).Decode(&breeds); err != nil {
-		return false, err
-	}
+	return nil, nil
+}
 
-	breedLower := strings.ToLower(breed)
-	for _, b := range breeds {
-		if strings.ToLower(b.Name) == breedLower {
-			return true, nil
-		}
+func (v *catValidator) SuggestBreeds(breed string) ([]BreedSuggestion, error) {
+	breeds, err := v.catalog.Breeds()
+	if err != nil {
+		return nil, err
 	}
 
-	return false, nil
+	return SuggestBreeds(breeds, breed), nil
 }
@@ -0,0 +1,84 @@
+package catapi
+
+import (
+	"sort"
+	"strings"
+)
+
+const maxSuggestions = 3
+
+type BreedSuggestion struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// SuggestBreeds returns up to three catalog breeds whose name starts with
+// query or is within a small edit distance of it, best matches first.
+func SuggestBreeds(breeds []CatAPIBreed, query string) []BreedSuggestion {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return []BreedSuggestion{}
+	}
+
+	// allow roughly one typo per three characters, but at least two
+	maxDistance := max(2, len([]rune(query))/3)
+
+	type candidate struct {
+		breed    CatAPIBreed
+		prefix   bool
+		distance int
+	}
+
+	var candidates []candidate
+	for _, b := range breeds {
+		name := strings.ToLower(b.Name)
+		c := candidate{
+			breed:    b,
+			prefix:   strings.HasPrefix(name, query),
+			distance: levenshtein(query, name),
+		}
+		if c.prefix || c.distance <= maxDistance {
+			candidates = append(candidates, c)
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].prefix != candidates[j].prefix {
+			return candidates[i].prefix
+		}
+		if candidates[i].distance != candidates[j].distance {
+			return candidates[i].distance < candidates[j].distance
+		}
+		return candidates[i].breed.Name < candidates[j].breed.Name
+	})
+
+	suggestions := make([]BreedSuggestion, 0, maxSuggestions)
+	for _, c := range candidates[:min(len(candidates), maxSuggestions)] {
+		suggestions = append(suggestions, BreedSuggestion{ID: c.breed.ID, Name: c.breed.Name})
+	}
+	return suggestions
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
@@ -0,0 +1,147 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"spy-cat-agency/pkg/catapi"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testBreeds = []catapi.CatAPIBreed{
+	{ID: "pers", Name: "Persian"},
+	{ID: "siam", Name: "Siamese"},
+}
+
+type fakeCatAPI struct {
+	server *httptest.Server
+	hits   atomic.Int32
+	down   atomic.Bool
+}
+
+func newFakeCatAPI(t *testing.T) *fakeCatAPI {
+	api := &fakeCatAPI{}
+	api.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		api.hits.Add(1)
+		if api.down.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if r.URL.Path != "/v1/breeds" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(testBreeds)
+	}))
+	t.Cleanup(api.server.Close)
+	return api
+}
+
+func TestCatalog_Breeds(t *testing.T) {
+	t.Run("caches breeds within ttl", func(t *testing.T) {
+		api := newFakeCatAPI(t)
+		catalog := catapi.NewCatalog(catapi.CatalogConfig{BaseURL: api.server.URL + "/v1", TTL: time.Hour})
+
+		for range 3 {
+			breeds, err := catalog.Breeds()
+			require.NoError(t, err)
+			assert.Equal(t, testBreeds, breeds)
+		}
+
+		assert.Equal(t, int32(1), api.hits.Load())
+	})
+
+	t.Run("refetches after ttl expires", func(t *testing.T) {
+		api := newFakeCatAPI(t)
+		catalog := catapi.NewCatalog(catapi.CatalogConfig{BaseURL: api.server.URL + "/v1", TTL: time.Millisecond})
+
+		_, err := catalog.Breeds()
+		require.NoError(t, err)
+		time.Sleep(5 * time.Millisecond)
+		_, err = catalog.Breeds()
+		require.NoError(t, err)
+
+		assert.Equal(t, int32(2), api.hits.Load())
+	})
+
+	t.Run("serves stale breeds when upstream fails", func(t *testing.T) {
+		api := newFakeCatAPI(t)
+		catalog := catapi.NewCatalog(catapi.CatalogConfig{BaseURL: api.server.URL + "/v1", TTL: time.Millisecond})
+
+		_, err := catalog.Breeds()
+		require.NoError(t, err)
+
+		api.down.Store(true)
+		time.Sleep(5 * time.Millisecond)
+
+		breeds, err := catalog.Breeds()
+		assert.NoError(t, err)
+		assert.Equal(t, testBreeds, breeds)
+	})
+
+	t.Run("falls back to snapshot when upstream is unreachable", func(t *testing.T) {
+		api := newFakeCatAPI(t)
+		snapshotPath := filepath.Join(t.TempDir(), "breeds.json")
+
+		warm := catapi.NewCatalog(catapi.CatalogConfig{BaseURL: api.server.URL + "/v1", SnapshotPath: snapshotPath})
+		require.NoError(t, warm.Refresh())
+
+		api.down.Store(true)
+
+		cold := catapi.NewCatalog(catapi.CatalogConfig{
+			BaseURL:      api.server.URL + "/v1",
+			TTL:          time.Millisecond,
+			SnapshotPath: snapshotPath,
+		})
+		breeds, err := cold.Breeds()
+
+		assert.NoError(t, err)
+		assert.Equal(t, testBreeds, breeds)
+	})
+
+	t.Run("fails without upstream or snapshot", func(t *testing.T) {
+		api := newFakeCatAPI(t)
+		api.down.Store(true)
+		catalog := catapi.NewCatalog(catapi.CatalogConfig{
+			BaseURL:      api.server.URL + "/v1",
+			SnapshotPath: filepath.Join(t.TempDir(), "missing.json"),
+		})
+
+		breeds, err := catalog.Breeds()
+
+		assert.ErrorIs(t, err, catapi.ErrCatalogUnavailable)
+		assert.Nil(t, breeds)
+	})
+}
+
+func TestCatValidator_ValidateBreed(t *testing.T) {
+	api := newFakeCatAPI(t)
+	validator := catapi.NewCatValidator(catapi.NewCatalog(catapi.CatalogConfig{BaseURL: api.server.URL + "/v1"}))
+
+	t.Run("matches name case-insensitively", func(t *testing.T) {
+		breed, err := validator.ValidateBreed("siamese")
+
+		assert.NoError(t, err)
+		assert.Equal(t, &testBreeds[1], breed)
+	})
+
+	t.Run("matches breed id", func(t *testing.T) {
+		breed, err := validator.ValidateBreed("PERS")
+
+		assert.NoError(t, err)
+		assert.Equal(t, &testBreeds[0], breed)
+	})
+
+	t.Run("rejects unknown breed", func(t *testing.T) {
+		breed, err := validator.ValidateBreed("Dragon")
+
+		assert.NoError(t, err)
+		assert.Nil(t, breed)
+	})
+}
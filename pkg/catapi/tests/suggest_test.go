@@ -0,0 +1,42 @@
+package tests
+
+import (
+	"spy-cat-agency/pkg/catapi"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuggestBreeds(t *testing.T) {
+	breeds := []catapi.CatAPIBreed{
+		{ID: "abys", Name: "Abyssinian"},
+		{ID: "pers", Name: "Persian"},
+		{ID: "siam", Name: "Siamese"},
+		{ID: "sava", Name: "Savannah"},
+		{ID: "sfol", Name: "Scottish Fold"},
+	}
+
+	t.Run("suggests breed for typo", func(t *testing.T) {
+		suggestions := catapi.SuggestBreeds(breeds, "Siamse")
+
+		assert.Equal(t, []catapi.BreedSuggestion{{ID: "siam", Name: "Siamese"}}, suggestions)
+	})
+
+	t.Run("prefers prefix matches", func(t *testing.T) {
+		suggestions := catapi.SuggestBreeds(breeds, "sc")
+
+		assert.Equal(t, []catapi.BreedSuggestion{{ID: "sfol", Name: "Scottish Fold"}}, suggestions)
+	})
+
+	t.Run("ignores unrelated breeds", func(t *testing.T) {
+		suggestions := catapi.SuggestBreeds(breeds, "Dragon")
+
+		assert.Empty(t, suggestions)
+	})
+
+	t.Run("limits number of suggestions", func(t *testing.T) {
+		suggestions := catapi.SuggestBreeds(breeds, "s")
+
+		assert.Len(t, suggestions, 3)
+	})
+}
@@ -1,13 +1,39 @@
 package custerr
 
 type BadRequestErr struct {
-	msg string
+	base
 }
 
 func NewBadRequestErr(msg string) BadRequestErr {
-	return BadRequestErr{msg}
+	return BadRequestErr{base{msg: msg}}
 }
 
-func (e BadRequestErr) Error() string {
-	return e.msg
+// NewBadRequestErrWithDetails attaches extra fields that are returned to the
+// client alongside the error message.
+func NewBadRequestErrWithDetails(msg string, details map[string]any) BadRequestErr {
+	return BadRequestErr{base{msg: msg, meta: details}}
+}
+
+func (e BadRequestErr) WithCode(code Code) BadRequestErr {
+	e.code = code
+	return e
+}
+
+func (e BadRequestErr) WithCause(err error) BadRequestErr {
+	e.cause = err
+	return e
+}
+
+func (e BadRequestErr) WithMeta(key string, value any) BadRequestErr {
+	e.base = e.withMeta(key, value)
+	return e
+}
+
+func (e BadRequestErr) Code() Code {
+	return codeOr(e.code, CodeBadRequest)
+}
+
+// Details is the metadata of the error.
+func (e BadRequestErr) Details() map[string]any {
+	return e.meta
 }
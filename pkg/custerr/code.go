@@ -0,0 +1,28 @@
+package custerr
+
+// Code is a stable, machine readable identifier of an error that clients can
+// branch on instead of parsing messages. Every error type has a default code
+// that more specific ones replace with WithCode.
+type Code string
+
+const (
+	CodeBadRequest   Code = "BAD_REQUEST"
+	CodeUnauthorized Code = "UNAUTHORIZED"
+	CodeForbidden    Code = "FORBIDDEN"
+	CodeNotFound     Code = "NOT_FOUND"
+	CodeConflict     Code = "CONFLICT"
+	CodeInternal     Code = "INTERNAL"
+
+	// codes of database constraint violations, see Classify
+	CodeDuplicate           Code = "DUPLICATE"
+	CodeReferenceNotFound   Code = "REFERENCE_NOT_FOUND"
+	CodeStillReferenced     Code = "STILL_REFERENCED"
+	CodeConstraintViolation Code = "CONSTRAINT_VIOLATION"
+)
+
+func codeOr(code, fallback Code) Code {
+	if code == "" {
+		return fallback
+	}
+	return code
+}
@@ -1,13 +1,28 @@
 package custerr
 
 type ConflictErr struct {
-	msg string
+	base
 }
 
 func NewConflictErr(msg string) ConflictErr {
-	return ConflictErr{msg}
+	return ConflictErr{base{msg: msg}}
 }
 
-func (e ConflictErr) Error() string {
-	return e.msg
+func (e ConflictErr) WithCode(code Code) ConflictErr {
+	e.code = code
+	return e
+}
+
+func (e ConflictErr) WithCause(err error) ConflictErr {
+	e.cause = err
+	return e
+}
+
+func (e ConflictErr) WithMeta(key string, value any) ConflictErr {
+	e.base = e.withMeta(key, value)
+	return e
+}
+
+func (e ConflictErr) Code() Code {
+	return codeOr(e.code, CodeConflict)
 }
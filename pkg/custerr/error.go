@@ -0,0 +1,44 @@
+package custerr
+
+// Error is implemented by every error of this package.
+type Error interface {
+	error
+	Code() Code
+	// Meta returns extra facts about the error, e.g. the violated
+	// constraint, that are returned to the client next to the message
+	Meta() map[string]any
+}
+
+// base holds what all error types have in common. The types are values, so
+// every With method returns an updated copy.
+type base struct {
+	msg   string
+	code  Code
+	cause error
+	meta  map[string]any
+}
+
+func (b base) Error() string {
+	return b.msg
+}
+
+// Unwrap returns the error this one was created from, if any, so errors.Is
+// and errors.As see through to the original gorm or driver error.
+func (b base) Unwrap() error {
+	return b.cause
+}
+
+func (b base) Meta() map[string]any {
+	return b.meta
+}
+
+// withMeta copies the metadata so copies of an error never share it.
+func (b base) withMeta(key string, value any) base {
+	meta := make(map[string]any, len(b.meta)+1)
+	for k, v := range b.meta {
+		meta[k] = v
+	}
+	meta[key] = value
+	b.meta = meta
+	return b
+}
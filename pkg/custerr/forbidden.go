@@ -0,0 +1,28 @@
+package custerr
+
+type ForbiddenErr struct {
+	base
+}
+
+func NewForbiddenErr(msg string) ForbiddenErr {
+	return ForbiddenErr{base{msg: msg}}
+}
+
+func (e ForbiddenErr) WithCode(code Code) ForbiddenErr {
+	e.code = code
+	return e
+}
+
+func (e ForbiddenErr) WithCause(err error) ForbiddenErr {
+	e.cause = err
+	return e
+}
+
+func (e ForbiddenErr) WithMeta(key string, value any) ForbiddenErr {
+	e.base = e.withMeta(key, value)
+	return e
+}
+
+func (e ForbiddenErr) Code() Code {
+	return codeOr(e.code, CodeForbidden)
+}
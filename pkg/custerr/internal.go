@@ -1,15 +1,27 @@
 package custerr
 
-import "errors"
-
 type InternalErr struct {
-	msg string
+	base
 }
 
+// NewInternalErr wraps an unexpected error. Its message includes the cause
+// for logs, it is never shown to clients.
 func NewInternalErr(err error) InternalErr {
-	return InternalErr{msg: errors.Join(errors.New("internal server error"), err).Error()}
+	return InternalErr{base{msg: "internal server error", cause: err}}
+}
+
+func (e InternalErr) WithMeta(key string, value any) InternalErr {
+	e.base = e.withMeta(key, value)
+	return e
 }
 
 func (e InternalErr) Error() string {
-	return e.msg
+	if e.cause == nil {
+		return e.msg
+	}
+	return e.msg + ": " + e.cause.Error()
+}
+
+func (e InternalErr) Code() Code {
+	return CodeInternal
 }
@@ -1,13 +1,28 @@
 package custerr
 
 type NotFoundErr struct {
-	msg string
+	base
 }
 
 func NewNotFoundErr(msg string) NotFoundErr {
-	return NotFoundErr{msg}
+	return NotFoundErr{base{msg: msg}}
 }
 
-func (e NotFoundErr) Error() string {
-	return e.msg
+func (e NotFoundErr) WithCode(code Code) NotFoundErr {
+	e.code = code
+	return e
+}
+
+func (e NotFoundErr) WithCause(err error) NotFoundErr {
+	e.cause = err
+	return e
+}
+
+func (e NotFoundErr) WithMeta(key string, value any) NotFoundErr {
+	e.base = e.withMeta(key, value)
+	return e
+}
+
+func (e NotFoundErr) Code() Code {
+	return codeOr(e.code, CodeNotFound)
 }
@@ -0,0 +1,60 @@
+package custerr
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Postgres error codes, see
+// https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	PgUniqueViolation     = "23505"
+	PgForeignKeyViolation = "23503"
+	PgCheckViolation      = "23514"
+	PgNotNullViolation    = "23502"
+)
+
+// Classify turns a database error into the custerr kind it stands for,
+// wrapping it as the cause. Constraint violations become client errors with
+// the constraint in their metadata, errors of this package are returned as
+// is and anything else is an InternalErr. Callers that know what a
+// constraint means should check for it before falling back to Classify.
+func Classify(err error) error {
+	if err == nil {
+		return nil
+	}
+	var custErr Error
+	if errors.As(err, &custErr) {
+		return err
+	}
+
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return NewInternalErr(err)
+	}
+
+	switch pgErr.Code {
+	case PgUniqueViolation:
+		return NewConflictErr("record already exists").
+			WithCode(CodeDuplicate).WithCause(err).WithMeta("constraint", pgErr.ConstraintName)
+	case PgForeignKeyViolation:
+		// the same code is raised for a missing parent on insert or update
+		// and for deleting a parent that still has children
+		if strings.HasPrefix(pgErr.Message, "update or delete") {
+			return NewConflictErr("record is still referenced by other records").
+				WithCode(CodeStillReferenced).WithCause(err).WithMeta("constraint", pgErr.ConstraintName)
+		}
+		return NewNotFoundErr("referenced record does not exist").
+			WithCode(CodeReferenceNotFound).WithCause(err).WithMeta("constraint", pgErr.ConstraintName)
+	case PgCheckViolation:
+		return NewBadRequestErr("value violates a constraint").
+			WithCode(CodeConstraintViolation).WithCause(err).WithMeta("constraint", pgErr.ConstraintName)
+	case PgNotNullViolation:
+		return NewBadRequestErr(pgErr.ColumnName+" is required").
+			WithCode(CodeConstraintViolation).WithCause(err).WithMeta("column", pgErr.ColumnName)
+	default:
+		return NewInternalErr(err)
+	}
+}
@@ -0,0 +1,126 @@
+package tests
+
+import (
+	"errors"
+	"fmt"
+	"spy-cat-agency/pkg/custerr"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestWrapping(t *testing.T) {
+	t.Run("errors.Is sees the cause", func(t *testing.T) {
+		err := custerr.NewNotFoundErr("no cat with id \"1\"").WithCause(gorm.ErrRecordNotFound)
+
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+		assert.Equal(t, "no cat with id \"1\"", err.Error())
+	})
+
+	t.Run("errors.As finds wrapped custerr", func(t *testing.T) {
+		err := fmt.Errorf("assigning cat: %w", custerr.NewConflictErr("cat already has an active mission"))
+
+		var conflict custerr.ConflictErr
+		assert.ErrorAs(t, err, &conflict)
+		assert.Equal(t, custerr.CodeConflict, conflict.Code())
+	})
+
+	t.Run("internal error keeps cause", func(t *testing.T) {
+		cause := errors.New("connection refused")
+		err := custerr.NewInternalErr(cause)
+
+		assert.ErrorIs(t, err, cause)
+		assert.Equal(t, "internal server error: connection refused", err.Error())
+	})
+}
+
+func TestCodesAndMeta(t *testing.T) {
+	t.Run("default and specific codes", func(t *testing.T) {
+		assert.Equal(t, custerr.CodeBadRequest, custerr.NewBadRequestErr("invalid id").Code())
+		assert.Equal(t, custerr.Code("INVALID_ID"), custerr.NewBadRequestErr("invalid id").WithCode("INVALID_ID").Code())
+	})
+
+	t.Run("meta is copied on write", func(t *testing.T) {
+		original := custerr.NewConflictErr("conflict").WithMeta("cat_id", 1)
+		changed := original.WithMeta("mission_id", 2)
+
+		assert.Equal(t, map[string]any{"cat_id": 1}, original.Meta())
+		assert.Equal(t, map[string]any{"cat_id": 1, "mission_id": 2}, changed.Meta())
+	})
+
+	t.Run("bad request details are its meta", func(t *testing.T) {
+		err := custerr.NewBadRequestErrWithDetails("invalid cat breed", map[string]any{"suggestions": []string{"Siamese"}})
+
+		assert.Equal(t, err.Meta(), err.Details())
+	})
+}
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *pgconn.PgError
+		want error
+		code custerr.Code
+	}{
+		{
+			name: "unique violation",
+			err:  &pgconn.PgError{Code: custerr.PgUniqueViolation, ConstraintName: "idx_cats_name"},
+			want: custerr.ConflictErr{},
+			code: custerr.CodeDuplicate,
+		},
+		{
+			name: "missing parent",
+			err:  &pgconn.PgError{Code: custerr.PgForeignKeyViolation, Message: "insert or update on table \"missions\" violates foreign key constraint \"fk_missions_cat\""},
+			want: custerr.NotFoundErr{},
+			code: custerr.CodeReferenceNotFound,
+		},
+		{
+			name: "parent still referenced",
+			err:  &pgconn.PgError{Code: custerr.PgForeignKeyViolation, Message: "update or delete on table \"cats\" violates foreign key constraint \"fk_missions_cat\" on table \"missions\""},
+			want: custerr.ConflictErr{},
+			code: custerr.CodeStillReferenced,
+		},
+		{
+			name: "check violation",
+			err:  &pgconn.PgError{Code: custerr.PgCheckViolation, ConstraintName: "cat_salaries_salary_check"},
+			want: custerr.BadRequestErr{},
+			code: custerr.CodeConstraintViolation,
+		},
+		{
+			name: "other database error",
+			err:  &pgconn.PgError{Code: "53300"},
+			want: custerr.InternalErr{},
+			code: custerr.CodeInternal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wrapped := fmt.Errorf("saving: %w", tt.err)
+
+			err := custerr.Classify(wrapped)
+
+			assert.IsType(t, tt.want, err)
+			assert.Equal(t, tt.code, err.(custerr.Error).Code())
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+
+	t.Run("constraint is in meta", func(t *testing.T) {
+		err := custerr.Classify(&pgconn.PgError{Code: custerr.PgUniqueViolation, ConstraintName: "idx_cats_name"})
+
+		assert.Equal(t, "idx_cats_name", err.(custerr.Error).Meta()["constraint"])
+	})
+
+	t.Run("keeps custerr and nil", func(t *testing.T) {
+		notFound := custerr.NewNotFoundErr("no cat with id \"1\"")
+
+		assert.Equal(t, notFound, custerr.Classify(notFound))
+		assert.NoError(t, custerr.Classify(nil))
+	})
+
+	t.Run("unknown errors are internal", func(t *testing.T) {
+		assert.IsType(t, custerr.InternalErr{}, custerr.Classify(errors.New("connection refused")))
+	})
+}
@@ -0,0 +1,28 @@
+package custerr
+
+type UnauthorizedErr struct {
+	base
+}
+
+func NewUnauthorizedErr(msg string) UnauthorizedErr {
+	return UnauthorizedErr{base{msg: msg}}
+}
+
+func (e UnauthorizedErr) WithCode(code Code) UnauthorizedErr {
+	e.code = code
+	return e
+}
+
+func (e UnauthorizedErr) WithCause(err error) UnauthorizedErr {
+	e.cause = err
+	return e
+}
+
+func (e UnauthorizedErr) WithMeta(key string, value any) UnauthorizedErr {
+	e.base = e.withMeta(key, value)
+	return e
+}
+
+func (e UnauthorizedErr) Code() Code {
+	return codeOr(e.code, CodeUnauthorized)
+}
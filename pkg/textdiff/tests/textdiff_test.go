@@ -0,0 +1,46 @@
+package tests
+
+import (
+	"spy-cat-agency/pkg/textdiff"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLines(t *testing.T) {
+	t.Run("identical texts", func(t *testing.T) {
+		lines := textdiff.Lines("seen at the docks\nwears a hat", "seen at the docks\nwears a hat")
+
+		assert.Equal(t, []textdiff.Line{
+			{Op: textdiff.OpEqual, Text: "seen at the docks"},
+			{Op: textdiff.OpEqual, Text: "wears a hat"},
+		}, lines)
+	})
+
+	t.Run("changed line", func(t *testing.T) {
+		lines := textdiff.Lines("seen at the docks\nwears a hat\narmed", "seen at the docks\nwears a scarf\narmed")
+
+		assert.Equal(t, []textdiff.Line{
+			{Op: textdiff.OpEqual, Text: "seen at the docks"},
+			{Op: textdiff.OpDelete, Text: "wears a hat"},
+			{Op: textdiff.OpInsert, Text: "wears a scarf"},
+			{Op: textdiff.OpEqual, Text: "armed"},
+		}, lines)
+	})
+
+	t.Run("from empty text", func(t *testing.T) {
+		lines := textdiff.Lines("", "first sighting\n")
+
+		assert.Equal(t, []textdiff.Line{{Op: textdiff.OpInsert, Text: "first sighting"}}, lines)
+	})
+
+	t.Run("to empty text", func(t *testing.T) {
+		lines := textdiff.Lines("first sighting", "")
+
+		assert.Equal(t, []textdiff.Line{{Op: textdiff.OpDelete, Text: "first sighting"}}, lines)
+	})
+
+	t.Run("both empty", func(t *testing.T) {
+		assert.Empty(t, textdiff.Lines("", ""))
+	})
+}